@@ -40,6 +40,8 @@ type Airport struct {
 	CRDARegions map[string]*CRDARegion `json:"crda_regions"`
 	CRDAPairs   []CRDAPair             `json:"crda_pairs"`
 
+	FMAPairs []FMAPair `json:"fma_pairs"`
+
 	ATPAVolumes           map[string]*ATPAVolume `json:"atpa_volumes"`
 	OmitArrivalScratchpad bool                   `json:"omit_arrival_scratchpad"`
 	DepartureRunwaysAsOne []string               `json:"departure_runways_as_one"`
@@ -475,7 +477,7 @@ func (ap *Airport) PostDeserialize(icao string, loc Locator, nmPerLongitude floa
 			}
 		*/
 
-		wp, err := parseWaypoints(dep.Route)
+		wp, err := ParseWaypoints(dep.Route)
 		if err != nil {
 			e.Error(err)
 		}
@@ -661,6 +663,64 @@ func (ap *Airport) PostDeserialize(icao string, loc Locator, nmPerLongitude floa
 		e.Pop()
 	}
 
+	for i := range ap.FMAPairs {
+		pair := &ap.FMAPairs[i]
+		e.Push("FMA pair " + pair.Runways[0] + "/" + pair.Runways[1])
+
+		var rwys [2]Runway
+		found := true
+		for j, id := range pair.Runways {
+			var ok bool
+			if rwys[j], ok = LookupRunway(icao, id); !ok {
+				e.ErrorString("runway %q is unknown. Options: %s", id, DB.Airports[icao].ValidRunways())
+				found = false
+			}
+		}
+		if pair.MonitorController != "" {
+			if _, ok := controlPositions[pair.MonitorController]; !ok {
+				e.ErrorString("monitor_controller %q unknown", pair.MonitorController)
+			}
+		}
+		if !found {
+			e.Pop()
+			continue
+		}
+		if math.HeadingDifference(rwys[0].Heading, rwys[1].Heading) > 3 {
+			e.ErrorString("runways %s and %s are not parallel", rwys[0].Id, rwys[1].Id)
+		}
+
+		// Defaults if things are not specified
+		if pair.NTZWidth == 0 {
+			pair.NTZWidth = 2000
+		}
+		if pair.NTZLength == 0 {
+			pair.NTZLength = 10
+		}
+		if pair.Ceiling == 0 {
+			pair.Ceiling = float32(DB.Airports[icao].Elevation + 5000)
+		}
+
+		pair.Thresholds = [2]math.Point2LL{rwys[0].Threshold, rwys[1].Threshold}
+		pair.Heading = rwys[0].Heading
+
+		w := pair.NTZWidth / 2 / math.NauticalMilesToFeet
+		if sep := pair.CenterlineSeparation(nmPerLongitude, magneticVariation); 2*w >= sep {
+			e.ErrorString("NTZ width %.0f feet is wider than the runways' %.0f foot separation", pair.NTZWidth,
+				sep*math.NauticalMilesToFeet)
+		}
+
+		origin, along, across := pair.Frame(nmPerLongitude, magneticVariation)
+		far := math.Add2f(origin, math.Scale2f(along, pair.NTZLength))
+		quad := [4][2]float32{
+			math.Add2f(origin, math.Scale2f(across, -w)), math.Add2f(far, math.Scale2f(across, -w)),
+			math.Add2f(far, math.Scale2f(across, w)), math.Add2f(origin, math.Scale2f(across, w))}
+		for j, p := range quad {
+			pair.NTZ[j] = math.NM2LL(p, nmPerLongitude)
+		}
+
+		e.Pop()
+	}
+
 	// Generate reasonable default ATPA volumes for any runways they aren't
 	// specified for.
 	if ap.ATPAVolumes == nil {
@@ -388,6 +388,71 @@ func (ar *CRDARegion) QualificationPolygon(nmPerLongitude float32) []math.Point2
 	return poly
 }
 
+// FMAPair describes a pair of parallel runways used for simultaneous
+// independent approaches that are watched by final monitor controllers
+// using the STARS final monitor aid (FMA). The no transgression zone (NTZ)
+// is centered between the runways' extended centerlines, starting abeam
+// the thresholds.
+type FMAPair struct {
+	Runways   [2]string `json:"runways"`
+	NTZWidth  float32   `json:"ntz_width"`  // feet
+	NTZLength float32   `json:"ntz_length"` // nm
+	Ceiling   float32   `json:"ceiling"`    // tracks above this altitude aren't monitored
+
+	// Final monitor position, which may issue break-outs to aircraft on
+	// either final regardless of who is controlling them.
+	MonitorController ControlPosition `json:"monitor_controller"`
+
+	// Set in Airport PostDeserialize()
+	Thresholds [2]math.Point2LL
+	Heading    float32 // magnetic heading of the final approach courses
+	NTZ        [4]math.Point2LL
+}
+
+// Frame returns the point midway between the runway thresholds and unit
+// vectors along the final approach course, pointing away from the
+// runways, and perpendicular to it, all in nm coordinates.
+func (f *FMAPair) Frame(nmPerLongitude, magneticVariation float32) (origin, along, across [2]float32) {
+	t0, t1 := math.LL2NM(f.Thresholds[0], nmPerLongitude), math.LL2NM(f.Thresholds[1], nmPerLongitude)
+	origin = math.Scale2f(math.Add2f(t0, t1), 0.5)
+	along = math.SinCos(math.Radians(f.Heading - magneticVariation + 180))
+	across = [2]float32{-along[1], along[0]}
+	return
+}
+
+// FinalOffset returns the distance in nm of p along the final approach
+// courses from the runway thresholds and its lateral offset in nm from
+// the NTZ's centerline.
+func (f *FMAPair) FinalOffset(p math.Point2LL, nmPerLongitude, magneticVariation float32) (along, across float32) {
+	origin, va, vc := f.Frame(nmPerLongitude, magneticVariation)
+	d := math.Sub2f(math.LL2NM(p, nmPerLongitude), origin)
+	return math.Dot(d, va), math.Dot(d, vc)
+}
+
+// CenterlineSeparation returns the distance in nm between the runways'
+// extended centerlines.
+func (f *FMAPair) CenterlineSeparation(nmPerLongitude, magneticVariation float32) float32 {
+	_, a0 := f.FinalOffset(f.Thresholds[0], nmPerLongitude, magneticVariation)
+	_, a1 := f.FinalOffset(f.Thresholds[1], nmPerLongitude, magneticVariation)
+	return math.Abs(a0 - a1)
+}
+
+// Monitored returns true if an aircraft at the given position and altitude
+// is on or near one of the final approach courses alongside the NTZ.
+func (f *FMAPair) Monitored(p math.Point2LL, alt, nmPerLongitude, magneticVariation float32) bool {
+	if alt > f.Ceiling {
+		return false
+	}
+	along, across := f.FinalOffset(p, nmPerLongitude, magneticVariation)
+	return along >= 0 && along <= f.NTZLength &&
+		math.Abs(across) <= f.CenterlineSeparation(nmPerLongitude, magneticVariation)
+}
+
+// InNTZ returns true if p is inside the NTZ.
+func (f *FMAPair) InNTZ(p math.Point2LL) bool {
+	return math.PointInPolygon2LL(p, f.NTZ[:])
+}
+
 type ControllerAirspaceVolume struct {
 	LowerLimit    int               `json:"lower"`
 	UpperLimit    int               `json:"upper"`
@@ -380,6 +380,22 @@ func (h HeadingIntent) Render(rt *RadioTransmission, r *rand.Rand) {
 	}
 }
 
+// BreakoutIntent represents a break-out from a final approach course,
+// issued by final monitor controllers when an aircraft on the adjacent
+// final blunders toward the no transgression zone.
+type BreakoutIntent struct {
+	Heading  HeadingIntent
+	Altitude *AltitudeIntent
+}
+
+func (b BreakoutIntent) Render(rt *RadioTransmission, r *rand.Rand) {
+	rt.Add("[breaking out|breakout|we're breaking out],")
+	b.Heading.Render(rt, r)
+	if b.Altitude != nil {
+		b.Altitude.Render(rt, r)
+	}
+}
+
 // ReportHeadingIntent represents "say heading" responses
 type ReportHeadingIntent struct {
 	Current  float32
@@ -458,6 +474,11 @@ const (
 	NavCrossFixAt
 	NavResumeOwnNav
 	NavAltitudeDiscretion
+	NavDeviationApproved
+	NavDeviationDenied
+	NavRequestDeniedAltitude
+	NavRequestDeniedDirect
+	NavRideReportAcknowledged
 )
 
 // NavigationIntent represents navigation commands (direct, hold, depart fix, etc.)
@@ -503,6 +524,16 @@ func (n NavigationIntent) Render(rt *RadioTransmission, r *rand.Rand) {
 		rt.Add("[own navigation|resuming own navigation]")
 	case NavAltitudeDiscretion:
 		rt.Add("[altitude our discretion|altitude our discretion, maintain VFR]")
+	case NavDeviationApproved:
+		rt.Add("[deviating for weather|deviation approved, we'll head around it|deviating]")
+	case NavDeviationDenied:
+		rt.Add("[roger, we'll stay on course for now|okay, staying on course for now]")
+	case NavRequestDeniedAltitude:
+		rt.Add("[roger|okay], [we'll stay here for now|staying at our altitude]")
+	case NavRequestDeniedDirect:
+		rt.Add("[roger|okay], [we'll stay on the route|staying on the route]")
+	case NavRideReportAcknowledged:
+		rt.Add("[thanks|thanks for that], [we'll let you know if we want to change altitude|we'll stay here for now]")
 	}
 }
 
@@ -207,16 +207,19 @@ var (
 		"callsign": &CallsignSnippetFormatter{},
 		"ch":       &LetterSnippetFormatter{},
 		"dctrl":    &DepControllerSnippetFormatter{},
+		"digits":   &DigitsSnippetFormatter{},
 		"fix":      &FixSnippetFormatter{},
 		"freq":     &FrequencySnippetFormatter{},
 		"gf":       &GroupFormSnippetFormatter{},
 		"hdg":      &HeadingSnippetFormatter{},
+		"hgt":      &HeightSnippetFormatter{},
 		"num":      &BasicNumberSnippetFormatter{},
 		"rwy":      &RunwaySnippetFormatter{},
 		"sid":      &SIDSnippetFormatter{},
 		"mach":     &MachSnippetFormatter{},
 		"spd":      &SpeedSnippetFormatter{},
 		"star":     &STARSnippetFormatter{},
+		"vis":      &VisibilitySnippetFormatter{},
 	}
 )
 
@@ -1462,6 +1465,112 @@ func (GroupFormSnippetFormatter) Validate(arg any) error {
 	return nil
 }
 
+///////////////////////////////////////////////////////////////////////////
+// DigitsSnippetFormatter
+
+// DigitsSnippetFormatter formats a string of digits (e.g., "2992" for an
+// altimeter setting) so that each digit is spoken individually.
+type DigitsSnippetFormatter struct{}
+
+func (DigitsSnippetFormatter) Written(arg any) string {
+	return arg.(string)
+}
+
+func (DigitsSnippetFormatter) Spoken(r *rand.Rand, arg any) string {
+	var d []string
+	for _, ch := range arg.(string) {
+		d = append(d, sayDigit(int(ch-'0')))
+	}
+	return strings.Join(d, " ")
+}
+
+func (DigitsSnippetFormatter) Validate(arg any) error {
+	if s, ok := arg.(string); !ok {
+		return fmt.Errorf("expected string arg, got %T", arg)
+	} else if s == "" || strings.ContainsFunc(s, func(ch rune) bool { return ch < '0' || ch > '9' }) {
+		return fmt.Errorf("%q: expected only digits", s)
+	}
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////
+// HeightSnippetFormatter
+
+// HeightSnippetFormatter formats heights above ground level, e.g. of cloud
+// layers; unlike altitudes, they are never spoken as flight levels.
+type HeightSnippetFormatter struct{}
+
+func (HeightSnippetFormatter) Written(arg any) string {
+	return strconv.Itoa(arg.(int))
+}
+
+func (HeightSnippetFormatter) Spoken(r *rand.Rand, arg any) string {
+	hgt := 100 * (arg.(int) / 100)
+	th, hu := hgt/1000, (hgt%1000)/100
+	switch {
+	case th == 0:
+		return sayDigit(hu) + " hundred"
+	case hu == 0:
+		return sayDigits(th, 0) + " thousand"
+	default:
+		return sayDigits(th, 0) + " thousand " + sayDigit(hu) + " hundred"
+	}
+}
+
+func (HeightSnippetFormatter) Validate(arg any) error {
+	if _, ok := arg.(int); !ok {
+		return fmt.Errorf("expected int arg, got %T", arg)
+	}
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////
+// VisibilitySnippetFormatter
+
+// VisibilitySnippetFormatter formats visibilities in statute miles,
+// including fractional ones.
+type VisibilitySnippetFormatter struct{}
+
+// visibilityParts splits a visibility into a whole number of miles and a
+// fraction, rounded to the nearest quarter mile.
+func visibilityParts(vis float32) (int, int) {
+	q := int(vis*4 + 0.5)
+	return q / 4, q % 4
+}
+
+func (VisibilitySnippetFormatter) Written(arg any) string {
+	whole, quarters := visibilityParts(arg.(float32))
+	frac := []string{"", "1/4", "1/2", "3/4"}[quarters]
+	switch {
+	case quarters == 0:
+		return strconv.Itoa(whole)
+	case whole == 0:
+		return frac
+	default:
+		return strconv.Itoa(whole) + " " + frac
+	}
+}
+
+func (VisibilitySnippetFormatter) Spoken(r *rand.Rand, arg any) string {
+	whole, quarters := visibilityParts(arg.(float32))
+	frac := []string{"", "one quarter", "one half", "three quarters"}[quarters]
+	switch {
+	case quarters == 0:
+		return groupForm(whole)
+	case whole == 0:
+		return frac
+	default:
+		return groupForm(whole) + " and " + frac
+	}
+}
+
+func (VisibilitySnippetFormatter) Validate(arg any) error {
+	if _, ok := arg.(float32); !ok {
+		return fmt.Errorf("expected float32 arg, got %T", arg)
+	}
+	return nil
+}
+
 ///////////////////////////////////////////////////////////////////////////
 // BeaconCodeSnippetFormatter
 
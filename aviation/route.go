@@ -499,7 +499,7 @@ func (wa WaypointArray) Encode() string {
 func (wa *WaypointArray) UnmarshalJSON(b []byte) error {
 	if len(b) >= 2 && b[0] == '"' && b[len(b)-1] == '"' {
 		// Handle the string encoding used in scenario JSON files
-		wp, err := parseWaypoints(string(b[1 : len(b)-1]))
+		wp, err := ParseWaypoints(string(b[1 : len(b)-1]))
 		if err == nil {
 			*wa = wp
 		}
@@ -915,7 +915,9 @@ func parsePTExtent(pt *ProcedureTurn, extent string) error {
 	return nil
 }
 
-func parseWaypoints(str string) (WaypointArray, error) {
+// ParseWaypoints parses a route given as fixes and airways separated by
+// spaces; fixes may have the modifiers used in scenario files.
+func ParseWaypoints(str string) (WaypointArray, error) {
 	var waypoints WaypointArray
 	entries := strings.Fields(str)
 	for ei, field := range entries {
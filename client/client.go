@@ -44,6 +44,9 @@ type ControlClient struct {
 	sttTranscriber *stt.Transcriber
 	pttReleaseTime time.Time // Wall clock time when PTT was released (for latency tracking)
 
+	// Captured voice command waiting for a possible typed correction
+	pendingSTTCapture *sttCapture
+
 	// Last callsign that replied "AGAIN" - allows controller to repeat command without callsign
 	lastAgainCallsign av.ADSBCallsign
 
@@ -234,6 +237,8 @@ func (c *ControlClient) Status() string {
 }
 
 func (c *ControlClient) Disconnect() {
+	c.flushSTTCapture(true)
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -296,7 +301,10 @@ func (c *ControlClient) GetUpdates(eventStream *sim.EventStream, p platform.Plat
 	// has disabled TTS locally. This ensures pilots still join the frequency
 	// and text transmissions appear. Audio playback is controlled separately.
 	// The actual request is made after releasing the lock.
-	shouldRequestContact := c.transmissions.ShouldRequestContact()
+	// When replaying a recording, the recorded requests are made by the
+	// server instead, and pseudo-pilots aren't contacted at all.
+	shouldRequestContact := c.transmissions.ShouldRequestContact() && c.State.Replay == nil &&
+		c.State.UserPseudoPilot() == nil
 
 	if callbackErr == nil {
 		completedCalls, callbackErr = c.checkPendingRPCs(eventStream)
@@ -332,6 +340,8 @@ func (c *ControlClient) GetUpdates(eventStream *sim.EventStream, p platform.Plat
 		c.RequestContactTransmission()
 	}
 
+	c.flushSTTCapture(false)
+
 	// Invoke callbacks after releasing lock to avoid deadlock
 	if updateCallFinished != nil {
 		updateCallFinished.InvokeCallback(eventStream, &c.State)
@@ -493,6 +503,18 @@ func (c *ControlClient) GetPrecipURL(t time.Time, callback func(url string, next
 }
 
 func (c *ControlClient) GetAtmosGrid(t time.Time, callback func(*wx.AtmosGrid, error)) {
+	if sw := c.State.ScriptedWeather; sw != nil {
+		soa, err := sw.AtmosAt(t)
+		if callback != nil {
+			if soa != nil {
+				callback(soa.ToAOS().GetGrid(), err)
+			} else {
+				callback(nil, err)
+			}
+		}
+		return
+	}
+
 	spec := server.GetAtmosArgs{
 		Facility:       c.State.Facility,
 		Time:           t,
@@ -634,9 +656,9 @@ func (c *ControlClient) GetLastWhisperDurationMs() int64 {
 // synthesizeAndEnqueueReadback synthesizes text and enqueues it as a readback.
 // Called from a goroutine. On failure, Unhold() is called because RunAircraftCommands
 // calls Hold() before issuing the command to prevent contacts while waiting for the readback.
-func (c *ControlClient) synthesizeAndEnqueueReadback(callsign av.ADSBCallsign, text, voice string) {
+func (c *ControlClient) synthesizeAndEnqueueReadback(callsign av.ADSBCallsign, text string, voice sim.Voice) {
 	radioSeed := uint32(util.HashString64(string(callsign)))
-	if pcm, err := tts.SynthesizeReadbackTTS(text, voice, radioSeed); err != nil {
+	if pcm, err := tts.SynthesizeReadbackTTS(text, voice.Name, voice.Speed, voice.RadioEffect, radioSeed); err != nil {
 		c.lg.Errorf("TTS synthesis error for %s: %v", callsign, err)
 		c.transmissions.Unhold()
 	} else if pcm == nil {
@@ -648,12 +670,45 @@ func (c *ControlClient) synthesizeAndEnqueueReadback(callsign av.ADSBCallsign, t
 	}
 }
 
+// atisVoice is the TTS voice used for all ATIS broadcasts.
+const atisVoice = "am_michael"
+
+// PlayATIS shows the airport's current ATIS broadcast in the messages
+// and, if TTS is enabled, plays it over the radio.
+func (c *ControlClient) PlayATIS(ap string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	atis, ok := c.State.ATIS[ap]
+	if !ok {
+		return
+	}
+	if c.eventStream != nil {
+		c.eventStream.Post(sim.Event{
+			Type:        sim.StatusMessageEvent,
+			WrittenText: "ATIS: " + atis.Text,
+		})
+	}
+
+	if !*c.disableTTSPtr {
+		go func() {
+			radioSeed := uint32(util.HashString64(ap))
+			if pcm, err := tts.SynthesizeContactTTS(atis.Spoken, atisVoice, 0, 1, radioSeed); err != nil {
+				c.lg.Errorf("TTS synthesis error for %s ATIS: %v", ap, err)
+			} else if pcm != nil {
+				// No callsign, since it's not a pilot transmission.
+				c.transmissions.EnqueueTransmissionPCM("", av.RadioTransmissionReadback, pcm)
+			}
+		}()
+	}
+}
+
 // synthesizeAndEnqueueContact synthesizes text and enqueues it as a contact transmission.
 // Called from a goroutine. Unlike readbacks, no Hold() is acquired before requesting
 // contacts, so no Unhold() is needed on failure.
-func (c *ControlClient) synthesizeAndEnqueueContact(callsign av.ADSBCallsign, ty av.RadioTransmissionType, text, voice string) {
+func (c *ControlClient) synthesizeAndEnqueueContact(callsign av.ADSBCallsign, ty av.RadioTransmissionType, text string, voice sim.Voice) {
 	radioSeed := uint32(util.HashString64(string(callsign)))
-	if pcm, err := tts.SynthesizeContactTTS(text, voice, radioSeed); err != nil {
+	if pcm, err := tts.SynthesizeContactTTS(text, voice.Name, voice.Speed, voice.RadioEffect, radioSeed); err != nil {
 		c.lg.Errorf("TTS synthesis error for %s: %v", callsign, err)
 		return
 	} else if pcm != nil {
@@ -42,7 +42,7 @@ type ConnectionManager struct {
 	onError     func(error)
 }
 
-func MakeServerManager(serverAddress, additionalScenario, additionalVideoMap string, disableTTSPtr *bool, lg *log.Logger,
+func MakeServerManager(serverAddress, additionalScenario, additionalVideoMap, recordDir string, disableTTSPtr *bool, lg *log.Logger,
 	onNewClient func(*ControlClient), onError func(error)) (*ConnectionManager, util.ErrorLogger, string) {
 	cm := &ConnectionManager{
 		serverAddress:           serverAddress,
@@ -59,6 +59,7 @@ func MakeServerManager(serverAddress, additionalScenario, additionalVideoMap str
 		ExtraVideoMap: additionalVideoMap,
 		ServerAddress: serverAddress,
 		IsLocal:       true,
+		RecordDir:     recordDir,
 	}, lg)
 
 	if !errorLogger.HaveErrors() {
@@ -107,6 +108,29 @@ func (cm *ConnectionManager) LoadLocalSim(s *sim.Sim, initials string, lg *log.L
 	return cm.client, nil
 }
 
+// LoadReplay starts a local sim that plays back the session recorded in
+// the given file.
+func (cm *ConnectionManager) LoadReplay(filename string, initials string, lg *log.Logger) (*ControlClient, error) {
+	if cm.LocalServer == nil {
+		cm.LocalServer = <-cm.localServerChan
+	}
+
+	var result server.NewSimResult
+	req := server.LoadReplayRequest{Filename: filename, Initials: initials}
+	if err := cm.LocalServer.Call(server.LoadReplayRPC, &req, &result); err != nil {
+		return nil, server.TryDecodeError(err)
+	}
+
+	if cm.client != nil {
+		cm.client.Disconnect()
+	}
+	cm.client = NewControlClient(*result.SimState, result.ControllerToken, cm.disableTTSPtr, initials,
+		cm.LocalServer.RPCClient, lg)
+	cm.connectionStartTime = time.Now()
+
+	return cm.client, nil
+}
+
 func (cm *ConnectionManager) CreateNewSim(config server.NewSimRequest, initials string, srv *Server, lg *log.Logger) error {
 	var result server.NewSimResult
 
@@ -7,7 +7,6 @@ package client
 import (
 	"time"
 
-	whisper "github.com/mmp/vice/autowhisper"
 	av "github.com/mmp/vice/aviation"
 	"github.com/mmp/vice/math"
 	"github.com/mmp/vice/server"
@@ -322,6 +321,52 @@ func (c *ControlClient) GetAircraftDisplayState(callsign av.ADSBCallsign) (sim.A
 	return state, err
 }
 
+func (c *ControlClient) GetSessionScore() (sim.SessionScore, error) {
+	var score sim.SessionScore
+	err := c.client.callWithTimeout(server.GetSessionScoreRPC, c.controllerToken, &score)
+	return score, err
+}
+
+func (c *ControlClient) SaveCheckpoint(name string) (sim.CheckpointInfo, error) {
+	var info sim.CheckpointInfo
+	err := c.client.callWithTimeout(server.SaveCheckpointRPC, &server.CheckpointArgs{
+		ControllerToken: c.controllerToken,
+		Name:            name,
+	}, &info)
+	return info, err
+}
+
+func (c *ControlClient) GetCheckpoints() ([]sim.CheckpointInfo, error) {
+	var info []sim.CheckpointInfo
+	err := c.client.callWithTimeout(server.GetCheckpointsRPC, c.controllerToken, &info)
+	return info, err
+}
+
+func (c *ControlClient) DeleteCheckpoint(name string) error {
+	return c.client.callWithTimeout(server.DeleteCheckpointRPC, &server.CheckpointArgs{
+		ControllerToken: c.controllerToken,
+		Name:            name,
+	}, nil)
+}
+
+func (c *ControlClient) RestoreCheckpoint(name string, callback func(error)) {
+	var update server.SimStateUpdate
+	c.addCall(makeStateUpdateRPCCall(c.client.Go(server.RestoreCheckpointRPC, &server.CheckpointArgs{
+		ControllerToken: c.controllerToken,
+		Name:            name,
+	}, &update, nil), &update, callback))
+}
+
+// SeekReplay moves a sim that is replaying a recording to the given sim
+// time.
+func (c *ControlClient) SeekReplay(t time.Time, callback func(error)) {
+	var update server.SimStateUpdate
+	c.addCall(makeStateUpdateRPCCall(c.client.Go(server.SeekReplayRPC, &server.SeekReplayArgs{
+		ControllerToken: c.controllerToken,
+		SimTime:         t,
+	}, &update, nil), &update, callback))
+}
+
 func (c *ControlClient) GetSerializeSim() (*sim.Sim, error) {
 	var s sim.Sim
 	err := c.client.callWithTimeout(server.GetSerializeSimRPC, c.controllerToken, &s)
@@ -348,6 +393,13 @@ func (c *ControlClient) TriggerEmergency(emergencyName string) {
 	}, nil, nil), nil))
 }
 
+func (c *ControlClient) ChangeRunwayConfiguration(name string) {
+	c.addCall(makeRPCCall(c.client.Go(server.ChangeRunwayConfigurationRPC, &server.ChangeRunwayConfigurationArgs{
+		ControllerToken: c.controllerToken,
+		Configuration:   name,
+	}, nil, nil), nil))
+}
+
 func (c *ControlClient) FastForward() {
 	var update server.SimStateUpdate
 	c.addCall(makeStateUpdateRPCCall(c.client.Go(server.FastForwardRPC, c.controllerToken, &update, nil), &update, nil))
@@ -427,7 +479,9 @@ func (c *ControlClient) RunAircraftCommands(req AircraftCommandRequest,
 	// Get processor info for voice commands
 	var processorDesc string
 	if req.WhisperDuration > 0 {
-		processorDesc = whisper.ProcessorDescription()
+		processorDesc = sttProcessorDescription()
+	} else {
+		c.noteTypedCommand(string(req.Callsign), req.Commands)
 	}
 
 	rpcStart := time.Now()
@@ -455,7 +509,7 @@ func (c *ControlClient) RunAircraftCommands(req AircraftCommandRequest,
 
 			// Synthesize readback locally if text was returned
 			if enableTTS && result.ReadbackText != "" {
-				go c.synthesizeAndEnqueueReadback(result.ReadbackCallsign, result.ReadbackText, result.ReadbackVoiceName)
+				go c.synthesizeAndEnqueueReadback(result.ReadbackCallsign, result.ReadbackText, result.ReadbackVoice)
 			} else if enableTTS {
 				// No readback text - release hold
 				c.transmissions.Unhold()
@@ -470,6 +524,37 @@ func (c *ControlClient) RunAircraftCommands(req AircraftCommandRequest,
 		}))
 }
 
+// RunPseudoPilotCommands runs commands for an aircraft flown by the user,
+// who must have signed on as a pseudo-pilot.
+func (c *ControlClient) RunPseudoPilotCommands(callsign av.ADSBCallsign, commands string,
+	handleResult func(message string, remainingInput string)) {
+	var result server.AircraftCommandsResult
+	c.addCall(makeRPCCall(c.client.Go(server.RunPseudoPilotCommandsRPC, &server.PseudoPilotCommandsArgs{
+		ControllerToken: c.controllerToken,
+		Callsign:        callsign,
+		Commands:        commands,
+	}, &result, nil),
+		func(err error) {
+			if err != nil {
+				handleResult(err.Error(), commands)
+			} else {
+				handleResult(result.ErrorMessage, result.RemainingInput)
+			}
+		}))
+}
+
+// PseudoPilotTransmit sends a radio transmission composed by the
+// pseudo-pilot on behalf of one of their aircraft.
+func (c *ControlClient) PseudoPilotTransmit(callsign av.ADSBCallsign, text string, ty av.RadioTransmissionType,
+	callback func(error)) {
+	c.addCall(makeRPCCall(c.client.Go(server.PseudoPilotTransmitRPC, &server.PseudoPilotTransmitArgs{
+		ControllerToken: c.controllerToken,
+		Callsign:        callsign,
+		Text:            text,
+		Type:            ty,
+	}, nil, nil), callback))
+}
+
 // RequestContactTransmission requests the next pending contact transmission from the server.
 // The result (if any) will be synthesized locally and enqueued for playback.
 func (c *ControlClient) RequestContactTransmission() {
@@ -496,7 +581,7 @@ func (c *ControlClient) RequestContactTransmission() {
 				}
 
 				go c.synthesizeAndEnqueueContact(result.ContactCallsign, result.ContactType,
-					result.ContactText, result.ContactVoiceName)
+					result.ContactText, result.ContactVoice)
 			}
 		}))
 }
@@ -529,6 +614,24 @@ func (c *ControlClient) ConfigureFDAM(op sim.FDAMConfigOp, regionId string, call
 		}))
 }
 
+// TrialPlan probes the flight with the given altitude (if non-zero) or
+// direct to the given fix (if non-empty) without changing its clearance.
+func (c *ControlClient) TrialPlan(acid sim.ACID, altitude int, fix string,
+	callback func(conflicts []sim.ProbeConflict, err error)) {
+	var result server.TrialPlanResult
+	c.addCall(makeStateUpdateRPCCall(c.client.Go(server.TrialPlanRPC, &server.TrialPlanArgs{
+		ControllerToken: c.controllerToken,
+		ACID:            acid,
+		Altitude:        altitude,
+		Fix:             fix,
+	}, &result, nil), &result.SimStateUpdate,
+		func(err error) {
+			if callback != nil {
+				callback(result.Conflicts, err)
+			}
+		}))
+}
+
 // ConsolidateTCP consolidates the sendingTCP to the receivingTCW's keyboard.
 // sim.ConsolidationFull transfers active tracks; sim.ConsolidationBasic only inactive/future flights.
 func (c *ControlClient) ConsolidateTCP(receivingTCW sim.TCW, sendingTCP sim.TCP, consType sim.ConsolidationType, callback func(error)) {
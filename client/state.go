@@ -156,6 +156,12 @@ func (ss *SimState) GetUserConsolidation() *sim.TCPConsolidation {
 	return ss.CurrentConsolidation[ss.UserTCW]
 }
 
+// UserPseudoPilot returns the user's pseudo-pilot workstation if they
+// signed on as a pseudo-pilot and nil otherwise.
+func (ss *SimState) UserPseudoPilot() *sim.PseudoPilot {
+	return ss.PseudoPilots[ss.UserTCW]
+}
+
 // UserControlsTrack returns true if the current user controls the given track.
 func (ss *SimState) UserControlsTrack(track *sim.Track) bool {
 	return ss.TCWControlsTrack(ss.UserTCW, track)
@@ -160,8 +160,10 @@ func (tm *TransmissionManager) Update(p platform.Platform, paused, sttActive boo
 		defer tm.mu.Unlock()
 
 		tm.playing = false
-		tm.lastCallsign = qt.Callsign
-		tm.lastWasContact = isContact
+		if qt.Callsign != "" { // not a pilot (e.g., an ATIS broadcast)
+			tm.lastCallsign = qt.Callsign
+			tm.lastWasContact = isContact
+		}
 
 		// Different hold times based on transmission type:
 		// - After contact: 8 seconds (controller needs time to respond)
@@ -264,6 +266,23 @@ func (tm *TransmissionManager) IsContactRequested() bool {
 	return tm.contactRequested
 }
 
+///////////////////////////////////////////////////////////////////////////
+// Transcriber
+
+// Transcriber is implemented by speech-to-text backends. Audio is fed
+// to it as it is recorded during PTT; Stop ends the session and returns
+// the transcription along with the duration of the recorded audio.
+// StopWithAudio also returns the recorded audio (16kHz mono, normalized
+// to [-1,1]).
+type Transcriber interface {
+	AddSamples(samples []int16)
+	Stop() (text string, audioDuration time.Duration)
+	StopWithAudio() (text string, audioDuration time.Duration, audio []float32)
+}
+
+// The local whisper.cpp backend.
+var _ Transcriber = (*whisper.Transcriber)(nil)
+
 ///////////////////////////////////////////////////////////////////////////
 // Whisper integration
 
@@ -790,9 +809,28 @@ func WhisperModelError() error {
 }
 
 // IsSTTAvailable returns true if speech-to-text is available.
-// This blocks until the whisper model finishes loading.
+// Unless a remote transcription server is being used, this blocks until
+// the whisper model finishes loading.
 func IsSTTAvailable() bool {
-	return WhisperModelError() == nil
+	return remoteSTT() != nil || WhisperModelError() == nil
+}
+
+// sttModelName returns a description of the model used for transcription
+// for logging.
+func sttModelName() string {
+	if r := remoteSTT(); r != nil {
+		return "remote:" + r.Model
+	}
+	return GetWhisperModelName()
+}
+
+// sttProcessorDescription returns a description of where transcription is
+// done for logging.
+func sttProcessorDescription() string {
+	if r := remoteSTT(); r != nil {
+		return r.URL
+	}
+	return whisper.ProcessorDescription()
 }
 
 func makeWhisperPrompt(state SimState) string {
@@ -949,34 +987,43 @@ func (c *ControlClient) GetAndClearPTTReleaseTime() time.Time {
 
 // streamingSTT holds state for a transcription session.
 type streamingSTT struct {
-	transcriber *whisper.Transcriber
-	state       SimState // Snapshot of state at start of streaming
+	transcriber Transcriber
+	state       SimState    // Snapshot of state at start of streaming
+	capture     *sttCapture // Non-nil if STT capture is enabled
 }
 
 // StartStreamingSTT begins a transcription session.
 // Audio samples can be fed via FeedAudioToStreaming.
 // Call StopStreamingSTT to end the session and process the result.
 func (c *ControlClient) StartStreamingSTT(lg *log.Logger) error {
-	// Wait for initial model load to complete
-	<-whisperModelDone
-	if whisperModelErr != nil {
-		return fmt.Errorf("whisper LoadModelFromBytes: %w", whisperModelErr)
-	}
-
 	// Snapshot state for prompt construction
 	state := c.State
 
-	st := whisper.NewTranscriber(whisperModel, &whisperModelMu, whisper.Options{
-		Language:       "en",
-		InitialPrompt:  makeWhisperPrompt(state),
-		RealtimeFactor: whisperRealtimeFactor,
-	})
+	var st Transcriber
+	if remote := remoteSTT(); remote != nil {
+		st = NewHTTPTranscriber(*remote, makeWhisperPrompt(state), lg)
+	} else {
+		// Wait for initial model load to complete
+		<-whisperModelDone
+		if whisperModelErr != nil {
+			return fmt.Errorf("whisper LoadModelFromBytes: %w", whisperModelErr)
+		}
+
+		st = whisper.NewTranscriber(whisperModel, &whisperModelMu, whisper.Options{
+			Language:       "en",
+			InitialPrompt:  makeWhisperPrompt(state),
+			RealtimeFactor: whisperRealtimeFactor,
+		})
+	}
 
 	c.mu.Lock()
 	c.streamingSTT = &streamingSTT{
 		transcriber: st,
 		state:       state,
 	}
+	if dir := sttCaptureDirectory(); dir != "" {
+		c.streamingSTT.capture = &sttCapture{dir: dir}
+	}
 	// Hold speech playback during recording/processing
 	c.sttActive = true
 	c.transmissions.Hold()
@@ -1017,7 +1064,10 @@ func (c *ControlClient) StopStreamingSTT(lg *log.Logger) {
 		defer lg.CatchAndReportCrash()
 
 		// Get final transcription from whisper
-		finalText, audioDuration := sttSession.transcriber.Stop()
+		finalText, audioDuration, audio := sttSession.transcriber.StopWithAudio()
+		if sttSession.capture != nil {
+			sttSession.capture.audio = audio
+		}
 		whisperDuration := time.Since(pttReleaseTime)
 
 		lg.Infof("Whisper transcription completed in %v: %q", whisperDuration, finalText)
@@ -1056,6 +1106,28 @@ func (c *ControlClient) StopStreamingSTT(lg *log.Logger) {
 		totalDuration := time.Since(pttReleaseTime)
 		timingStr := fmt.Sprintf("%.0fms", float64(totalDuration.Microseconds())/1000)
 
+		whisperModelName := sttModelName()
+
+		var callsign, command string
+		if err == nil && decoded != "" {
+			callsign, command, _ = strings.Cut(decoded, " ")
+		}
+		if capture := sttSession.capture; capture != nil {
+			capture.entry = sttCaptureEntry{
+				Time:              pttReleaseTime.Format(time.RFC3339Nano),
+				Level:             "INFO",
+				Msg:               "STT command",
+				Transcript:        finalText,
+				WhisperDurationMs: float64(totalDuration.Microseconds()) / 1000.0,
+				AudioDurationMs:   float64(audioDuration.Microseconds()) / 1000.0,
+				Processor:         sttProcessorDescription(),
+				WhisperModel:      whisperModelName,
+				STTAircraft:       aircraftCtx,
+				Logs:              debugLogs,
+			}
+			c.finishSTTCapture(capture, callsign, command)
+		}
+
 		if err != nil {
 			lg.Infof("STT decode error: %v", err)
 			c.transmissions.Unhold()
@@ -1063,16 +1135,11 @@ func (c *ControlClient) StopStreamingSTT(lg *log.Logger) {
 			return
 		}
 
-		whisperModelName := GetWhisperModelName()
-
-		var callsign, command string
 		if decoded == "" {
 			lg.Infof("STT: no command decoded from %q", finalText)
 			c.transmissions.Unhold()
 			c.postSTTEvent(finalText, decoded, timingStr)
 		} else {
-			// Parse callsign and command from decoded result
-			callsign, command, _ = strings.Cut(decoded, " ")
 			lg.Infof("STT command: %s %s", callsign, command)
 
 			c.SetLastCommand(decoded)
@@ -1125,7 +1192,7 @@ func (c *ControlClient) StopStreamingSTT(lg *log.Logger) {
 			WhisperDuration:   totalDuration,
 			AudioDuration:     audioDuration,
 			WhisperTranscript: finalText,
-			WhisperProcessor:  whisper.ProcessorDescription(),
+			WhisperProcessor:  sttProcessorDescription(),
 			WhisperModel:      whisperModelName,
 			AircraftContext:   aircraftCtx,
 			STTDebugLogs:      debugLogs,
@@ -0,0 +1,182 @@
+// client/sttcapture.go
+// Copyright(c) 2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package client
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/mmp/vice/log"
+	"github.com/mmp/vice/math"
+	"github.com/mmp/vice/platform"
+	"github.com/mmp/vice/stt"
+)
+
+// STT capture ("record and label") mode saves the audio of each PTT
+// transmission along with its transcription, the decoded commands, and
+// the aircraft context so that misrecognitions can be turned into new
+// test cases. If the controller types a command by hand shortly after a
+// voice command--presumably to correct it--the typed command is recorded
+// as the expected result.
+
+// If the controller types a command within this long after a voice
+// command is decoded, it's taken to be a correction.
+const sttCorrectionWindow = 20 * time.Second
+
+var sttCaptureDir atomic.Pointer[string]
+
+// SetSTTCaptureDirectory enables capture of PTT transmissions to the given
+// directory; capture is disabled if it is the empty string.
+func SetSTTCaptureDirectory(dir string) {
+	if dir == "" {
+		sttCaptureDir.Store(nil)
+	} else {
+		sttCaptureDir.Store(&dir)
+	}
+}
+
+func sttCaptureDirectory() string {
+	if dir := sttCaptureDir.Load(); dir != nil {
+		return *dir
+	}
+	return ""
+}
+
+// sttCapture holds a captured transmission until either the controller
+// corrects it or the correction window passes.
+type sttCapture struct {
+	dir     string
+	audio   []float32 // as returned by Transcriber.StopWithAudio
+	decoded time.Time
+	entry   sttCaptureEntry
+}
+
+// sttCaptureEntry is the JSON that is saved for each captured
+// transmission. It follows the schema of the test cases in stt/tests
+// (which in turn match the server's "STT command" log entries) so that
+// captures can be triaged with cmd/sttreview; Callsign and Command are
+// the expected result.
+type sttCaptureEntry struct {
+	Time              string                  `json:"time"`
+	Level             string                  `json:"level"`
+	Msg               string                  `json:"msg"`
+	Transcript        string                  `json:"transcript"`
+	WhisperDurationMs float64                 `json:"whisper_duration_ms"`
+	AudioDurationMs   float64                 `json:"audio_duration_ms"`
+	Processor         string                  `json:"processor"`
+	WhisperModel      string                  `json:"whisper_model"`
+	Callsign          string                  `json:"callsign"`
+	Command           string                  `json:"command"`
+	STTAircraft       map[string]stt.Aircraft `json:"stt_aircraft"`
+	Logs              []string                `json:"logs"`
+
+	Audio           string `json:"audio"`            // WAV file name, in the same directory
+	DecodedCallsign string `json:"decoded_callsign"` // What STT produced
+	DecodedCommand  string `json:"decoded_command"`
+	Corrected       bool   `json:"corrected"` // Callsign and Command were typed by the controller
+}
+
+// finishSTTCapture records a decoded transmission as awaiting a possible
+// correction. Any previous capture that was still waiting is saved as is.
+func (c *ControlClient) finishSTTCapture(capture *sttCapture, callsign, command string) {
+	capture.decoded = time.Now()
+	capture.entry.Callsign, capture.entry.Command = callsign, command
+	capture.entry.DecodedCallsign, capture.entry.DecodedCommand = callsign, command
+
+	c.mu.Lock()
+	prev := c.pendingSTTCapture
+	c.pendingSTTCapture = capture
+	c.mu.Unlock()
+
+	if prev != nil {
+		go saveSTTCapture(prev, c.lg)
+	}
+}
+
+// noteTypedCommand is called when the controller enters aircraft
+// commands by hand; if a captured voice command was recently decoded, the
+// typed command is saved as its correction. Commands to other aircraft
+// aren't corrections unless STT didn't decode a callsign at all.
+func (c *ControlClient) noteTypedCommand(callsign, commands string) {
+	c.mu.Lock()
+	capture := c.pendingSTTCapture
+	if capture == nil || time.Since(capture.decoded) > sttCorrectionWindow {
+		c.mu.Unlock()
+		return
+	}
+	if decoded := capture.entry.DecodedCallsign; decoded != "" &&
+		strings.TrimSuffix(decoded, "/T") != strings.TrimSuffix(callsign, "/T") {
+		c.mu.Unlock()
+		return
+	}
+	c.pendingSTTCapture = nil
+	c.mu.Unlock()
+
+	capture.entry.Callsign, capture.entry.Command = callsign, commands
+	capture.entry.Corrected = true
+	go saveSTTCapture(capture, c.lg)
+}
+
+// flushSTTCapture saves the pending capture if its correction window has
+// passed or if force is set, in which case it is saved synchronously.
+func (c *ControlClient) flushSTTCapture(force bool) {
+	c.mu.Lock()
+	capture := c.pendingSTTCapture
+	if capture == nil || (!force && time.Since(capture.decoded) <= sttCorrectionWindow) {
+		c.mu.Unlock()
+		return
+	}
+	c.pendingSTTCapture = nil
+	c.mu.Unlock()
+
+	if force {
+		saveSTTCapture(capture, c.lg)
+	} else {
+		go saveSTTCapture(capture, c.lg)
+	}
+}
+
+var sttCaptureFilenameRe = regexp.MustCompile(`[^a-z0-9_]`)
+
+// saveSTTCapture writes the capture's audio as a WAV file and the rest of
+// it as JSON; the file names are derived from the time and transcript.
+func saveSTTCapture(capture *sttCapture, lg *log.Logger) {
+	if err := os.MkdirAll(capture.dir, 0o755); err != nil {
+		lg.Errorf("%s: unable to create STT capture directory: %v", capture.dir, err)
+		return
+	}
+
+	name := strings.ReplaceAll(strings.ToLower(capture.entry.Transcript), " ", "_")
+	name = sttCaptureFilenameRe.ReplaceAllString(name, "")
+	if len(name) > 40 {
+		name = name[:40]
+	}
+	base := filepath.Join(capture.dir, capture.decoded.Format("20060102-150405.000")+"_"+name)
+
+	capture.entry.Audio = filepath.Base(base) + ".wav"
+	pcm := make([]int16, len(capture.audio))
+	for i, f := range capture.audio {
+		pcm[i] = int16(math.Clamp(f*32768, -32768, 32767))
+	}
+	if err := os.WriteFile(base+".wav", encodeWAV(pcm, platform.AudioInputSampleRate), 0o644); err != nil {
+		lg.Errorf("%s.wav: %v", base, err)
+		return
+	}
+
+	data, err := json.MarshalIndent(capture.entry, "", "  ")
+	if err == nil {
+		err = os.WriteFile(base+".json", data, 0o644)
+	}
+	if err != nil {
+		lg.Errorf("%s.json: %v", base, err)
+		return
+	}
+	lg.Infof("STT capture saved to %s.json (corrected: %v)", base, capture.entry.Corrected)
+}
@@ -0,0 +1,88 @@
+// client/sttcapture_test.go
+// Copyright(c) 2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package client
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mmp/vice/log"
+)
+
+func TestNoteTypedCommand(t *testing.T) {
+	c := &ControlClient{lg: log.New(false, "error", "")}
+
+	makeCapture := func(decodedCallsign string) *sttCapture {
+		return &sttCapture{
+			dir:     t.TempDir(),
+			audio:   make([]float32, 1600),
+			decoded: time.Now(),
+			entry: sttCaptureEntry{
+				Transcript:      "american one two three descend and maintain five thousand",
+				Callsign:        decodedCallsign,
+				Command:         "D50",
+				DecodedCallsign: decodedCallsign,
+				DecodedCommand:  "D50",
+			},
+		}
+	}
+
+	// saved waits for the capture to be written and returns what was saved.
+	saved := func(capture *sttCapture) sttCaptureEntry {
+		t.Helper()
+		for range 500 {
+			if files, _ := filepath.Glob(filepath.Join(capture.dir, "*.json")); len(files) == 1 {
+				if data, err := os.ReadFile(files[0]); err == nil {
+					var e sttCaptureEntry
+					if err := json.Unmarshal(data, &e); err == nil {
+						return e
+					}
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("capture wasn't saved")
+		return sttCaptureEntry{}
+	}
+
+	// A command typed for a different aircraft isn't a correction.
+	capture := makeCapture("AAL123")
+	c.pendingSTTCapture = capture
+	c.noteTypedCommand("DAL456", "D40")
+	if c.pendingSTTCapture != capture {
+		t.Fatalf("command for another aircraft taken as a correction")
+	}
+
+	// One for the aircraft that STT decoded is.
+	c.noteTypedCommand("AAL123", "D40")
+	if c.pendingSTTCapture != nil {
+		t.Fatalf("correction not taken")
+	}
+	if e := saved(capture); !e.Corrected || e.Callsign != "AAL123" || e.Command != "D40" ||
+		e.DecodedCallsign != "AAL123" || e.DecodedCommand != "D50" {
+		t.Errorf("unexpected saved capture %+v", e)
+	}
+
+	// If STT didn't decode anything, the typed command is the correction
+	// regardless of the aircraft.
+	capture = makeCapture("")
+	c.pendingSTTCapture = capture
+	c.noteTypedCommand("DAL456", "L270")
+	if e := saved(capture); !e.Corrected || e.Callsign != "DAL456" || e.Command != "L270" {
+		t.Errorf("unexpected saved capture %+v", e)
+	}
+
+	// Commands typed after the correction window has passed are ignored.
+	capture = makeCapture("AAL123")
+	capture.decoded = time.Now().Add(-2 * sttCorrectionWindow)
+	c.pendingSTTCapture = capture
+	c.noteTypedCommand("AAL123", "D40")
+	if c.pendingSTTCapture != capture || capture.entry.Corrected {
+		t.Errorf("command after the correction window taken as a correction")
+	}
+}
@@ -0,0 +1,190 @@
+// client/sttremote.go
+// Copyright(c) 2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package client
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mmp/vice/log"
+	"github.com/mmp/vice/platform"
+)
+
+// RemoteSTTConfig specifies a server with an OpenAI-compatible
+// /audio/transcriptions endpoint to use for speech recognition in place
+// of the local whisper model. This allows, for example, a single fast
+// machine on the LAN to run a large model for a whole team.
+type RemoteSTTConfig struct {
+	URL    string // Base URL, e.g. "http://10.0.0.5:8000/v1"
+	Model  string // Model to request; may be empty if the server has just one
+	APIKey string // Optional; sent as a bearer token
+}
+
+var remoteSTTConfig atomic.Pointer[RemoteSTTConfig]
+
+// SetRemoteSTT sets the remote transcription server to use for
+// subsequent PTT transmissions. If cfg is nil or doesn't specify a URL,
+// the local whisper model is used.
+func SetRemoteSTT(cfg *RemoteSTTConfig) {
+	if cfg != nil && strings.TrimSpace(cfg.URL) == "" {
+		cfg = nil
+	}
+	remoteSTTConfig.Store(cfg)
+}
+
+// remoteSTT returns the current remote transcription server configuration,
+// or nil if the local whisper model should be used.
+func remoteSTT() *RemoteSTTConfig {
+	return remoteSTTConfig.Load()
+}
+
+// HTTPTranscriber is a Transcriber that sends the recorded audio to a
+// remote server for transcription when the session is stopped.
+type HTTPTranscriber struct {
+	config RemoteSTTConfig
+	prompt string
+	client *http.Client
+	lg     *log.Logger
+
+	audio   []int16
+	audioMu sync.Mutex
+}
+
+var _ Transcriber = (*HTTPTranscriber)(nil)
+
+// NewHTTPTranscriber returns a transcriber that uses the given server; the
+// prompt is passed along to bias recognition toward expected phrases.
+func NewHTTPTranscriber(config RemoteSTTConfig, prompt string, lg *log.Logger) *HTTPTranscriber {
+	return &HTTPTranscriber{
+		config: config,
+		prompt: prompt,
+		client: &http.Client{Timeout: 10 * time.Second},
+		lg:     lg,
+	}
+}
+
+// AddSamples adds 16kHz mono audio samples to the buffer.
+func (t *HTTPTranscriber) AddSamples(samples []int16) {
+	t.audioMu.Lock()
+	t.audio = append(t.audio, samples...)
+	t.audioMu.Unlock()
+}
+
+// Stop ends the recording session and returns the transcription from the
+// server along with the duration of the recorded audio. If the request
+// fails, the error is logged and an empty transcription is returned.
+func (t *HTTPTranscriber) Stop() (string, time.Duration) {
+	text, audioDuration, _ := t.StopWithAudio()
+	return text, audioDuration
+}
+
+// StopWithAudio is like Stop but also returns the recorded audio,
+// normalized to [-1,1].
+func (t *HTTPTranscriber) StopWithAudio() (string, time.Duration, []float32) {
+	t.audioMu.Lock()
+	audio := t.audio
+	t.audio = nil
+	t.audioMu.Unlock()
+
+	if len(audio) == 0 {
+		return "", 0, nil
+	}
+	audioDuration := time.Duration(len(audio)) * time.Second / platform.AudioInputSampleRate
+	samples := make([]float32, len(audio))
+	for i, s := range audio {
+		samples[i] = float32(s) / 32768
+	}
+
+	text, err := t.transcribe(audio)
+	if err != nil {
+		t.lg.Warnf("%s: remote transcription failed: %v", t.config.URL, err)
+		return "", audioDuration, samples
+	}
+	return text, audioDuration, samples
+}
+
+func (t *HTTPTranscriber) transcribe(audio []int16) (string, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+
+	fw, err := mw.CreateFormFile("file", "audio.wav")
+	if err != nil {
+		return "", err
+	}
+	if _, err := fw.Write(encodeWAV(audio, platform.AudioInputSampleRate)); err != nil {
+		return "", err
+	}
+	for _, f := range [][2]string{{"model", t.config.Model}, {"language", "en"}, {"prompt", t.prompt},
+		{"response_format", "json"}, {"temperature", "0"}} {
+		if f[1] != "" {
+			if err := mw.WriteField(f[0], f[1]); err != nil {
+				return "", err
+			}
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return "", err
+	}
+
+	url := strings.TrimSuffix(strings.TrimSpace(t.config.URL), "/") + "/audio/transcriptions"
+	req, err := http.NewRequest(http.MethodPost, url, &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	if t.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+t.config.APIKey)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var result struct {
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("unable to decode response: %w", err)
+	}
+	return strings.TrimSpace(result.Text), nil
+}
+
+// encodeWAV returns a WAV file holding the given 16-bit mono PCM samples.
+func encodeWAV(pcm []int16, sampleRate int) []byte {
+	var buf bytes.Buffer
+	dataSize := uint32(2 * len(pcm))
+
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, 36+dataSize)
+	buf.WriteString("WAVEfmt ")
+	binary.Write(&buf, binary.LittleEndian, uint32(16))           // fmt chunk size
+	binary.Write(&buf, binary.LittleEndian, uint16(1))            // PCM
+	binary.Write(&buf, binary.LittleEndian, uint16(1))            // channels
+	binary.Write(&buf, binary.LittleEndian, uint32(sampleRate))   // sample rate
+	binary.Write(&buf, binary.LittleEndian, uint32(2*sampleRate)) // byte rate
+	binary.Write(&buf, binary.LittleEndian, uint16(2))            // block align
+	binary.Write(&buf, binary.LittleEndian, uint16(16))           // bits per sample
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, dataSize)
+	binary.Write(&buf, binary.LittleEndian, pcm)
+
+	return buf.Bytes()
+}
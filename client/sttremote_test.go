@@ -0,0 +1,58 @@
+// client/sttremote_test.go
+// Copyright(c) 2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package client
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mmp/vice/log"
+)
+
+func TestHTTPTranscriber(t *testing.T) {
+	// A stub server that validates the request and returns a fixed transcription.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/audio/transcriptions" || r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		f, _, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		wav, _ := io.ReadAll(f)
+		if string(wav[:4]) != "RIFF" || binary.LittleEndian.Uint32(wav[40:]) != 2*16000 {
+			http.Error(w, "bad wav", http.StatusBadRequest)
+			return
+		}
+		if r.FormValue("model") != "large" || r.FormValue("prompt") != "descend and maintain" {
+			http.Error(w, "bad fields", http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"text": " American 123 descend and maintain 5000 "})
+	}))
+	defer srv.Close()
+	lg := log.New(false, "error", "")
+
+	tr := NewHTTPTranscriber(RemoteSTTConfig{URL: srv.URL + "/v1/", Model: "large", APIKey: "secret"}, "descend and maintain", lg)
+	tr.AddSamples(make([]int16, 8000))
+	tr.AddSamples(make([]int16, 8000))
+	text, dur := tr.Stop()
+	if text != "American 123 descend and maintain 5000" || dur != time.Second {
+		t.Errorf("got %q, %s", text, dur)
+	}
+
+	tr = NewHTTPTranscriber(RemoteSTTConfig{URL: srv.URL + "/v1", Model: "large"}, "", lg)
+	tr.AddSamples(make([]int16, 1600))
+	if text, _ := tr.Stop(); text != "" {
+		t.Errorf("expected no transcription after error, got %q", text)
+	}
+}
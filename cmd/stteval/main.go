@@ -0,0 +1,452 @@
+// cmd/stteval runs all of the STT test cases and reports accuracy
+// statistics: the overall pass rate, callsign match accuracy, precision
+// and recall for each type of command, and the most common mis-parses.
+//
+// Usage:
+//
+//	go run ./cmd/stteval [-dir stt/tests] [-save results.json] [-baseline results.json] [-v]
+//
+// -save writes the per-case results and summary statistics to a file; a
+// subsequent run with -baseline reports the change in accuracy relative to
+// it along with the cases that newly pass or fail. The exit code is 1 if
+// any case that passed in the baseline now fails.
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/sim"
+	"github.com/mmp/vice/stt"
+)
+
+// STTTestFile matches the structure in stt/provider_test.go
+type STTTestFile struct {
+	Transcript  string                  `json:"transcript"`
+	Callsign    string                  `json:"callsign"`
+	Command     string                  `json:"command"`
+	STTAircraft map[string]stt.Aircraft `json:"stt_aircraft"`
+}
+
+// CaseResult records the outcome of a single test case.
+type CaseResult struct {
+	Transcript string `json:"transcript"`
+	Expected   string `json:"expected"`
+	Actual     string `json:"actual"`
+	Pass       bool   `json:"pass"`
+	// Callsign is the callsign returned by stt.MatchCallsign.
+	Callsign   string `json:"callsign"`
+	CallsignOK bool   `json:"callsign_ok"`
+}
+
+// TypeStats accumulates command matches for a single type of command.
+type TypeStats struct {
+	TruePositive  int `json:"tp"`
+	FalsePositive int `json:"fp"`
+	FalseNegative int `json:"fn"`
+}
+
+func (ts TypeStats) Precision() (float64, bool) {
+	n := ts.TruePositive + ts.FalsePositive
+	return float64(ts.TruePositive) / float64(n), n > 0
+}
+
+func (ts TypeStats) Recall() (float64, bool) {
+	n := ts.TruePositive + ts.FalseNegative
+	return float64(ts.TruePositive) / float64(n), n > 0
+}
+
+// Summary holds the aggregate statistics over all of the test cases.
+type Summary struct {
+	Cases           int                   `json:"cases"`
+	Passed          int                   `json:"passed"`
+	CallsignCases   int                   `json:"callsign_cases"`
+	CallsignCorrect int                   `json:"callsign_correct"`
+	CallsignMissed  int                   `json:"callsign_missed"`
+	CallsignWrong   int                   `json:"callsign_wrong"`
+	CommandTypes    map[string]*TypeStats `json:"command_types"`
+	Confusions      map[string]int        `json:"confusions"`
+}
+
+// Results is what is written by -save and read by -baseline.
+type Results struct {
+	Summary Summary               `json:"summary"`
+	Cases   map[string]CaseResult `json:"cases"`
+}
+
+func main() {
+	dir := flag.String("dir", "stt/tests", "directory of JSON test cases")
+	save := flag.String("save", "", "file to save results to, for use as a baseline")
+	baselineFile := flag.String("baseline", "", "previously saved results to compare against")
+	verbose := flag.Bool("v", false, "list all failing cases")
+	top := flag.Int("top", 20, "number of mis-parses to report")
+	flag.Parse()
+
+	// Initialize the aviation database for aircraft performance lookups
+	av.InitDB()
+
+	files, err := filepath.Glob(filepath.Join(*dir, "*.json"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", *dir, err)
+		os.Exit(1)
+	}
+	if len(files) == 0 {
+		fmt.Fprintf(os.Stderr, "%s: no JSON test files found\n", *dir)
+		os.Exit(1)
+	}
+
+	var baseline *Results
+	if *baselineFile != "" {
+		baseline = &Results{}
+		if data, err := os.ReadFile(*baselineFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading baseline: %v\n", err)
+			os.Exit(1)
+		} else if err := json.Unmarshal(data, baseline); err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing baseline: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	results := Results{
+		Summary: Summary{
+			CommandTypes: make(map[string]*TypeStats),
+			Confusions:   make(map[string]int),
+		},
+		Cases: make(map[string]CaseResult),
+	}
+
+	stt.SetQuiet(true)
+	provider := stt.NewTranscriber(nil)
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".json")
+		data, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
+			os.Exit(1)
+		}
+		var testFile STTTestFile
+		if err := json.Unmarshal(data, &testFile); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: error parsing JSON: %v\n", file, err)
+			os.Exit(1)
+		}
+
+		cr, err := evaluate(provider, testFile, &results.Summary)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", file, err)
+			os.Exit(1)
+		}
+		results.Cases[name] = cr
+	}
+
+	report(results, baseline, *verbose, *top)
+
+	if *save != "" {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err == nil {
+			err = os.WriteFile(*save, data, 0o644)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving results: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("\nSaved results to %s\n", *save)
+	}
+
+	if baseline != nil && len(regressions(results, *baseline)) > 0 {
+		os.Exit(1)
+	}
+}
+
+// evaluate runs a single test case and accumulates its statistics into
+// the summary.
+func evaluate(provider *stt.Transcriber, tf STTTestFile, summary *Summary) (CaseResult, error) {
+	// Bake /T into the callsign for type-based addressing entries,
+	// mirroring the production context initialization in provider.go.
+	aircraft := make(map[string]stt.Aircraft)
+	for key, ac := range tf.STTAircraft {
+		if ac.AddressingForm == sim.AddressingFormTypeTrailing3 && !strings.HasSuffix(ac.Callsign, "/T") {
+			ac.Callsign += "/T"
+		}
+		aircraft[key] = ac
+	}
+
+	result, err := provider.DecodeTranscript(aircraft, tf.Transcript, "")
+	if err != nil {
+		return CaseResult{}, fmt.Errorf("DecodeTranscript: %w", err)
+	}
+
+	var expected string
+	if tf.Callsign != "" || tf.Command != "" {
+		expected = strings.TrimSpace(tf.Callsign + " " + tf.Command)
+	}
+
+	cr := CaseResult{
+		Transcript: tf.Transcript,
+		Expected:   expected,
+		Actual:     result,
+		Pass:       stt.CommandsEquivalent(expected, result, aircraft),
+	}
+	summary.Cases++
+	if cr.Pass {
+		summary.Passed++
+	}
+
+	// Callsign matching is evaluated on its own, independently of
+	// whether the commands were parsed correctly.
+	match, _ := stt.MatchCallsign(stt.Tokenize(stt.NormalizeTranscript(tf.Transcript)), aircraft)
+	cr.Callsign = match.Callsign
+	if tf.Callsign != "" {
+		summary.CallsignCases++
+		want, got := strings.TrimSuffix(tf.Callsign, "/T"), strings.TrimSuffix(match.Callsign, "/T")
+		switch {
+		case got == want:
+			cr.CallsignOK = true
+			summary.CallsignCorrect++
+		case got == "":
+			summary.CallsignMissed++
+		default:
+			summary.CallsignWrong++
+		}
+	}
+
+	// Commands are compared regardless of whether the callsign was right
+	// so that command parsing accuracy can be assessed separately.
+	equivalent := func(exp, act string) bool { return exp == act }
+	for _, ac := range aircraft {
+		if strings.TrimSuffix(ac.Callsign, "/T") == strings.TrimSuffix(tf.Callsign, "/T") {
+			equivalent = func(exp, act string) bool { return stt.CommandEquivalent(exp, act, ac) }
+			break
+		}
+	}
+	var actual []string
+	if f := strings.Fields(result); len(f) > 1 {
+		actual = f[1:]
+	}
+	compareCommands(strings.Fields(tf.Command), actual, equivalent, summary)
+
+	return cr, nil
+}
+
+// compareCommands matches the parsed commands against the expected ones,
+// updating the per-type statistics and recording mis-parses.
+func compareCommands(expected, actual []string, equivalent func(exp, act string) bool, summary *Summary) {
+	stats := func(cmd string) *TypeStats {
+		ty := stt.CommandType(cmd)
+		if summary.CommandTypes[ty] == nil {
+			summary.CommandTypes[ty] = &TypeStats{}
+		}
+		return summary.CommandTypes[ty]
+	}
+
+	var missed []string
+	for _, exp := range expected {
+		if idx := slices.IndexFunc(actual, func(act string) bool { return equivalent(exp, act) }); idx != -1 {
+			stats(exp).TruePositive++
+			actual = slices.Delete(slices.Clone(actual), idx, idx+1)
+		} else {
+			stats(exp).FalseNegative++
+			missed = append(missed, exp)
+		}
+	}
+	for _, act := range actual {
+		stats(act).FalsePositive++
+	}
+
+	// Pair up the leftovers to see what was parsed in place of what was
+	// expected: first commands of the same type with the wrong value,
+	// then whatever remains in order.
+	confuse := func(exp, act string) {
+		e, a := "(none)", "(none)"
+		if exp != "" {
+			e = stt.CommandType(exp)
+		}
+		if act != "" {
+			a = stt.CommandType(act)
+		}
+		summary.Confusions[e+" -> "+a]++
+	}
+	for i := 0; i < len(missed); {
+		ty := stt.CommandType(missed[i])
+		if idx := slices.IndexFunc(actual, func(act string) bool { return stt.CommandType(act) == ty }); idx != -1 {
+			confuse(missed[i], actual[idx])
+			missed = slices.Delete(missed, i, i+1)
+			actual = slices.Delete(actual, idx, idx+1)
+		} else {
+			i++
+		}
+	}
+	for i := range max(len(missed), len(actual)) {
+		var exp, act string
+		if i < len(missed) {
+			exp = missed[i]
+		}
+		if i < len(actual) {
+			act = actual[i]
+		}
+		confuse(exp, act)
+	}
+}
+
+func report(results Results, baseline *Results, verbose bool, top int) {
+	s := &results.Summary
+	var bs *Summary
+	if baseline != nil {
+		bs = &baseline.Summary
+	}
+
+	pct := func(n, d int) float64 {
+		if d == 0 {
+			return 0
+		}
+		return 100 * float64(n) / float64(d)
+	}
+	delta := func(cur, prev float64, ok bool) string {
+		if !ok || cur == prev {
+			return ""
+		}
+		return fmt.Sprintf(" (%+.1f)", cur-prev)
+	}
+
+	passPct := pct(s.Passed, s.Cases)
+	fmt.Printf("Cases:    %d/%d passed (%.1f%%)", s.Passed, s.Cases, passPct)
+	if bs != nil {
+		fmt.Print(delta(passPct, pct(bs.Passed, bs.Cases), true))
+	}
+	fmt.Println()
+
+	csPct := pct(s.CallsignCorrect, s.CallsignCases)
+	fmt.Printf("Callsign: %d/%d matched (%.1f%%)", s.CallsignCorrect, s.CallsignCases, csPct)
+	if bs != nil {
+		fmt.Print(delta(csPct, pct(bs.CallsignCorrect, bs.CallsignCases), true))
+	}
+	fmt.Printf("; %d missed, %d wrong aircraft\n", s.CallsignMissed, s.CallsignWrong)
+
+	// Per-command-type precision and recall
+	types := make(map[string]bool)
+	for ty := range s.CommandTypes {
+		types[ty] = true
+	}
+	if bs != nil {
+		for ty := range bs.CommandTypes {
+			types[ty] = true
+		}
+	}
+	sortedTypes := make([]string, 0, len(types))
+	for ty := range types {
+		sortedTypes = append(sortedTypes, ty)
+	}
+	slices.Sort(sortedTypes)
+
+	fmt.Printf("\n%-18s %6s %6s %6s  %-16s %-16s\n", "Command type", "TP", "FP", "FN", "Precision", "Recall")
+	for _, ty := range sortedTypes {
+		ts := TypeStats{}
+		if s.CommandTypes[ty] != nil {
+			ts = *s.CommandTypes[ty]
+		}
+		var bts *TypeStats
+		if bs != nil {
+			bts = bs.CommandTypes[ty]
+			if bts == nil {
+				bts = &TypeStats{}
+			}
+		}
+
+		format := func(get func(TypeStats) (float64, bool)) string {
+			v, ok := get(ts)
+			str := "-"
+			if ok {
+				str = fmt.Sprintf("%.1f%%", 100*v)
+			}
+			if bts != nil {
+				bv, bok := get(*bts)
+				str += delta(100*v, 100*bv, ok && bok)
+			}
+			return str
+		}
+		fmt.Printf("%-18s %6d %6d %6d  %-16s %-16s\n", ty, ts.TruePositive, ts.FalsePositive, ts.FalseNegative,
+			format(TypeStats.Precision), format(TypeStats.Recall))
+	}
+
+	// Most common mis-parses
+	if len(s.Confusions) > 0 {
+		confusions := make([]string, 0, len(s.Confusions))
+		for c := range s.Confusions {
+			confusions = append(confusions, c)
+		}
+		slices.SortFunc(confusions, func(a, b string) int {
+			if c := cmp.Compare(s.Confusions[b], s.Confusions[a]); c != 0 {
+				return c
+			}
+			return strings.Compare(a, b)
+		})
+
+		fmt.Printf("\nMis-parses (expected -> parsed):\n")
+		for i, c := range confusions {
+			if i == top {
+				fmt.Printf("  ... %d more\n", len(confusions)-top)
+				break
+			}
+			fmt.Printf("  %4d  %s", s.Confusions[c], c)
+			if bs != nil {
+				if d := s.Confusions[c] - bs.Confusions[c]; d != 0 {
+					fmt.Printf(" (%+d)", d)
+				}
+			}
+			fmt.Println()
+		}
+	}
+
+	printCase := func(name string, cr CaseResult) {
+		fmt.Printf("  %s\n    transcript: %s\n    expected:   %q\n    actual:     %q\n",
+			name, cr.Transcript, cr.Expected, cr.Actual)
+	}
+
+	if baseline != nil {
+		if reg := regressions(results, *baseline); len(reg) > 0 {
+			fmt.Printf("\nNewly failing (%d):\n", len(reg))
+			for _, name := range reg {
+				printCase(name, results.Cases[name])
+			}
+		}
+		if fixed := regressions(*baseline, results); len(fixed) > 0 {
+			fmt.Printf("\nNewly passing (%d):\n", len(fixed))
+			for _, name := range fixed {
+				printCase(name, results.Cases[name])
+			}
+		}
+	}
+
+	if verbose {
+		var failing []string
+		for name, cr := range results.Cases {
+			if !cr.Pass {
+				failing = append(failing, name)
+			}
+		}
+		slices.Sort(failing)
+		fmt.Printf("\nFailing (%d):\n", len(failing))
+		for _, name := range failing {
+			printCase(name, results.Cases[name])
+		}
+	}
+}
+
+// regressions returns the names of the cases that pass in prev but fail
+// in cur. Cases that aren't present in both are ignored.
+func regressions(cur, prev Results) []string {
+	var names []string
+	for name, pcr := range prev.Cases {
+		if cr, ok := cur.Cases[name]; ok && pcr.Pass && !cr.Pass {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
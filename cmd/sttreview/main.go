@@ -36,6 +36,13 @@ type LogEntry struct {
 	Command           string                  `json:"command"`
 	STTAircraft       map[string]stt.Aircraft `json:"stt_aircraft"`
 	Logs              []string                `json:"logs,omitempty"`
+
+	// Set for entries captured by the client's STT capture mode; Callsign
+	// and Command then hold the controller's typed correction, if any.
+	Audio           string `json:"audio,omitempty"`
+	DecodedCallsign string `json:"decoded_callsign,omitempty"`
+	DecodedCommand  string `json:"decoded_command,omitempty"`
+	Corrected       bool   `json:"corrected,omitempty"`
 }
 
 // PersistedState stores the review queue and seen entries between sessions.
@@ -114,9 +121,9 @@ func main() {
 		return
 	}
 
-	// Ingest from file if provided
+	// Ingest from file (or directory of captures) if provided
 	if len(flag.Args()) > 0 {
-		entries, err := loadEntriesFromFile(flag.Args()[0])
+		entries, err := loadEntries(flag.Args()[0])
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading file: %v\n", err)
 			os.Exit(1)
@@ -361,6 +368,38 @@ func entryHash(e LogEntry) string {
 	return hex.EncodeToString(h.Sum(nil))
 }
 
+// loadEntries loads STT command entries from either a slog file or a
+// directory of JSON files such as those saved by the client's STT capture
+// mode.
+func loadEntries(path string) ([]LogEntry, error) {
+	if fi, err := os.Stat(path); err != nil {
+		return nil, err
+	} else if !fi.IsDir() {
+		return loadEntriesFromFile(path)
+	}
+
+	files, err := filepath.Glob(filepath.Join(path, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var entries []LogEntry
+	for _, file := range files {
+		fe, err := loadEntriesFromFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range fe {
+			if e.Audio != "" && !filepath.IsAbs(e.Audio) {
+				if abs, err := filepath.Abs(filepath.Join(path, e.Audio)); err == nil {
+					e.Audio = abs
+				}
+			}
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
 // loadEntriesFromFile parses a slog file and extracts STT command entries.
 func loadEntriesFromFile(path string) ([]LogEntry, error) {
 	file, err := os.Open(path)
@@ -758,6 +797,19 @@ func render(screen tcell.Screen, state *AppState) {
 				y++
 			}
 
+			// For captures the controller corrected, show what STT decoded
+			if entry.Corrected && y < maxY {
+				drawText(screen, 0, y, width, styleContext, strings.Repeat(" ", width))
+				drawText(screen, 0, y, width, styleContextLabel, fmt.Sprintf(" STT Decoded: %s %s (corrected by controller)",
+					entry.DecodedCallsign, entry.DecodedCommand))
+				y++
+			}
+			if entry.Audio != "" && y < maxY {
+				drawText(screen, 0, y, width, styleContext, strings.Repeat(" ", width))
+				drawText(screen, 0, y, width, styleContextLabel, fmt.Sprintf(" Audio: %s", entry.Audio))
+				y++
+			}
+
 			// Determine which callsign to show context for
 			contextCallsign := entryCallsign
 			if state.correction != "" && state.correction != " " {
@@ -87,6 +87,19 @@ type ConfigNoSim struct {
 	WhisperDeviceID       string  // Device identifier used for benchmarking
 	WhisperBenchmarkIndex int     // Benchmark generation; rebenchmark if code's value is higher
 	WhisperRealtimeFactor float64 // Ratio of transcription time to audio duration (for quality tuning)
+
+	// Remote transcription server; if UseRemoteSTT is set, it is used
+	// instead of the local whisper model.
+	UseRemoteSTT    bool
+	RemoteSTTURL    string
+	RemoteSTTModel  string
+	RemoteSTTAPIKey string
+
+	// STT capture: if set, PTT audio, transcriptions, and any typed
+	// corrections are saved as test cases in STTCaptureDirectory (or a
+	// default directory if it's empty).
+	CaptureSTT          bool
+	STTCaptureDirectory string
 }
 
 type ConfigSim struct {
@@ -168,6 +181,41 @@ func (c *Config) SaveIfChanged(renderer renderer.Renderer, platform platform.Pla
 	return true
 }
 
+// updateRemoteSTT passes the remote transcription server settings along
+// to the client.
+func (c *Config) updateRemoteSTT() {
+	if !c.UseRemoteSTT {
+		client.SetRemoteSTT(nil)
+		return
+	}
+	client.SetRemoteSTT(&client.RemoteSTTConfig{
+		URL:    c.RemoteSTTURL,
+		Model:  c.RemoteSTTModel,
+		APIKey: c.RemoteSTTAPIKey,
+	})
+}
+
+// updateSTTCapture passes the STT capture settings along to the client.
+func (c *Config) updateSTTCapture() {
+	if !c.CaptureSTT {
+		client.SetSTTCaptureDirectory("")
+	} else if c.STTCaptureDirectory != "" {
+		client.SetSTTCaptureDirectory(c.STTCaptureDirectory)
+	} else {
+		client.SetSTTCaptureDirectory(defaultSTTCaptureDirectory())
+	}
+}
+
+// defaultSTTCaptureDirectory returns the directory for captured STT test
+// cases, alongside the config file.
+func defaultSTTCaptureDirectory() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = "."
+	}
+	return filepath.Join(dir, "Vice", "stt-captures")
+}
+
 // ActiveRadarPane returns the STARS or ERAM pane based on the sim type.
 func (c *Config) ActiveRadarPane(isSTARSSim bool) panes.Pane {
 	if isSTARSSim {
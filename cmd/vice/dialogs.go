@@ -13,6 +13,7 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"path/filepath"
 	"runtime"
 	"runtime/debug"
 	"strings"
@@ -22,6 +23,7 @@ import (
 	"github.com/mmp/vice/log"
 	"github.com/mmp/vice/platform"
 	"github.com/mmp/vice/renderer"
+	"github.com/mmp/vice/sim"
 	"github.com/mmp/vice/util"
 
 	"github.com/AllenDang/cimgui-go/imgui"
@@ -875,3 +877,227 @@ func (r *rebenchmarkModalClient) Draw() int {
 	}
 	return -1
 }
+
+///////////////////////////////////////////////////////////////////////////
+// DebriefModalClient
+
+// DebriefModalClient presents the controller performance metrics for the
+// current sim and allows them to be exported as JSON.
+type DebriefModalClient struct {
+	score     sim.SessionScore
+	userTCPs  map[sim.TCP]bool
+	exportMsg string
+	lg        *log.Logger
+}
+
+func uiShowDebriefDialog(controlClient *client.ControlClient, p platform.Platform, lg *log.Logger) {
+	score, err := controlClient.GetSessionScore()
+	if err != nil {
+		ShowErrorDialog(p, lg, "Unable to get session score: %v", err)
+		return
+	}
+
+	d := &DebriefModalClient{
+		score:    score,
+		userTCPs: make(map[sim.TCP]bool),
+		lg:       lg,
+	}
+	for tcp := range score.Positions {
+		d.userTCPs[tcp] = controlClient.State.TCWControlsPosition(controlClient.State.UserTCW, tcp)
+	}
+	uiShowModalDialog(NewModalDialogBox(d, p), false)
+}
+
+func (d *DebriefModalClient) Title() string { return "Session Debrief" }
+func (d *DebriefModalClient) Opening()      {}
+
+func (d *DebriefModalClient) Buttons() []ModalDialogButton {
+	return []ModalDialogButton{
+		{
+			text:     "Export JSON",
+			disabled: len(d.score.Positions) == 0,
+			action: func() bool {
+				if fn, err := d.export(); err != nil {
+					d.exportMsg = "Error: " + err.Error()
+					d.lg.Errorf("%s: unable to export debrief: %v", fn, err)
+				} else {
+					d.exportMsg = "Saved to " + fn
+				}
+				return false
+			},
+		},
+		{text: "Ok", action: func() bool { return true }},
+	}
+}
+
+func (d *DebriefModalClient) export() (string, error) {
+	dir := filepath.Dir(configFilePath(d.lg))
+	fn := filepath.Join(dir, "debrief-"+d.score.End.Format("20060102-150405")+".json")
+
+	b, err := json.MarshalIndent(d.score, "", "  ")
+	if err != nil {
+		return fn, err
+	}
+	return fn, os.WriteFile(fn, b, 0o644)
+}
+
+func (d *DebriefModalClient) Draw() int {
+	if len(d.score.Positions) == 0 {
+		imgui.Text("\nNo controller activity has been recorded yet.\n\n")
+		return -1
+	}
+
+	imgui.Text(fmt.Sprintf("Session time: %s", d.score.End.Sub(d.score.Start).Round(time.Second)))
+	imgui.Text("")
+
+	latency := func(l sim.LatencyStats) string {
+		if l.Count == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%d (avg %.0fs, max %.0fs)", l.Count, l.MeanSeconds, l.MaxSeconds)
+	}
+
+	flags := imgui.TableFlagsBordersV | imgui.TableFlagsBordersOuterH | imgui.TableFlagsRowBg | imgui.TableFlagsSizingStretchProp
+	if imgui.BeginTableV("debrief", 9, flags, imgui.Vec2{}, 0) {
+		for _, col := range []string{"Position", "Handoffs Accepted", "Check-ins Answered", "Unanswered",
+			"Releases", "Spacing Go-Arounds", "Wake Encounters", "Separation Losses", "MSAW"} {
+			imgui.TableSetupColumn(col)
+		}
+		imgui.TableHeadersRow()
+
+		for tcp, ps := range util.SortedMap(d.score.Positions) {
+			imgui.TableNextRow()
+			imgui.TableNextColumn()
+			if d.userTCPs[tcp] {
+				imgui.TextColored(imgui.Vec4{X: 0.5, Y: 0.8, Z: 0.5, W: 1}, string(tcp))
+			} else {
+				imgui.Text(string(tcp))
+			}
+			imgui.TableNextColumn()
+			imgui.Text(latency(ps.HandoffAcceptLatency))
+			imgui.TableNextColumn()
+			imgui.Text(latency(ps.CheckInResponse))
+			imgui.TableNextColumn()
+			imgui.Text(fmt.Sprintf("%d of %d", ps.UnansweredCheckIns, ps.CheckIns))
+			imgui.TableNextColumn()
+			imgui.Text(latency(ps.ReleaseDelay))
+			for _, n := range []int{ps.SpacingGoArounds, ps.WakeEncounters, ps.SeparationLosses, ps.MSAWAlerts} {
+				imgui.TableNextColumn()
+				imgui.Text(fmt.Sprintf("%d", n))
+			}
+		}
+		imgui.EndTable()
+	}
+
+	if d.exportMsg != "" {
+		imgui.Text("")
+		imgui.Text(d.exportMsg)
+	}
+
+	return -1
+}
+
+///////////////////////////////////////////////////////////////////////////
+// CheckpointsModalClient
+
+// CheckpointsModalClient allows the user to save named checkpoints of the
+// sim's state and to rewind the sim to one of them.
+type CheckpointsModalClient struct {
+	controlClient *client.ControlClient
+	checkpoints   []sim.CheckpointInfo
+	name          string
+	err           error
+	restored      bool
+	lg            *log.Logger
+}
+
+func (c *CheckpointsModalClient) Title() string { return "Sim Checkpoints" }
+
+func (c *CheckpointsModalClient) Opening() {
+	c.name = ""
+	c.err = nil
+	c.restored = false
+	c.refresh()
+}
+
+func (c *CheckpointsModalClient) refresh() {
+	var err error
+	if c.checkpoints, err = c.controlClient.GetCheckpoints(); err != nil {
+		c.err = err
+		c.lg.Errorf("GetCheckpoints: %v", err)
+	}
+}
+
+func (c *CheckpointsModalClient) Buttons() []ModalDialogButton {
+	return []ModalDialogButton{{text: "Close", action: func() bool { return true }}}
+}
+
+func (c *CheckpointsModalClient) Draw() int {
+	if c.restored {
+		// Close the dialog after rewinding.
+		return 0
+	}
+
+	imgui.Text("Name:")
+	imgui.SameLine()
+	imgui.InputTextWithHint("##name", "defaults to the current time", &c.name, 0, nil)
+	imgui.SameLine()
+	if imgui.Button("Save Checkpoint") {
+		if _, c.err = c.controlClient.SaveCheckpoint(strings.TrimSpace(c.name)); c.err == nil {
+			c.name = ""
+		}
+		c.refresh()
+	}
+
+	imgui.Text("")
+
+	if len(c.checkpoints) == 0 {
+		imgui.Text("No checkpoints have been saved.")
+	} else {
+		flags := imgui.TableFlagsBordersV | imgui.TableFlagsBordersOuterH | imgui.TableFlagsRowBg | imgui.TableFlagsSizingStretchProp
+		if imgui.BeginTableV("checkpoints", 5, flags, imgui.Vec2{}, 0) {
+			imgui.TableSetupColumn("Name")
+			imgui.TableSetupColumn("Sim Time")
+			imgui.TableSetupColumn("Aircraft")
+			imgui.TableSetupColumn("Saved By")
+			imgui.TableSetupColumn("##actions")
+			imgui.TableHeadersRow()
+
+			for _, cp := range c.checkpoints {
+				imgui.PushIDStr(cp.Name)
+				imgui.TableNextRow()
+				imgui.TableNextColumn()
+				imgui.Text(cp.Name)
+				imgui.TableNextColumn()
+				imgui.Text(cp.SimTime.UTC().Format("15:04:05Z"))
+				imgui.TableNextColumn()
+				imgui.Text(fmt.Sprintf("%d", cp.NumAircraft))
+				imgui.TableNextColumn()
+				imgui.Text(string(cp.SavedByTCP))
+				imgui.TableNextColumn()
+				if imgui.Button(renderer.FontAwesomeIconHistory + " Rewind") {
+					c.controlClient.RestoreCheckpoint(cp.Name, func(err error) {
+						if err != nil {
+							c.lg.Errorf("RestoreCheckpoint: %v", err)
+						}
+					})
+					c.restored = true
+				}
+				imgui.SameLine()
+				if imgui.Button(renderer.FontAwesomeIconTrash) {
+					c.err = c.controlClient.DeleteCheckpoint(cp.Name)
+					c.refresh()
+				}
+				imgui.PopID()
+			}
+			imgui.EndTable()
+		}
+	}
+
+	if c.err != nil {
+		imgui.Text("")
+		imgui.TextColored(imgui.Vec4{X: 1, Y: 0.3, Z: 0.3, W: 1}, "Error: "+c.err.Error())
+	}
+
+	return -1
+}
@@ -32,6 +32,7 @@ type LaunchControlWindow struct {
 	arrivalsOverflights []*LaunchArrivalOverflight
 	lg                  *log.Logger
 	selectedEmergency   int
+	runwayConfiguration string // the one lc.departures were created for
 }
 
 type LaunchAircraft struct {
@@ -61,20 +62,9 @@ type LaunchArrivalOverflight struct {
 
 func MakeLaunchControlWindow(client *client.ControlClient, lg *log.Logger) *LaunchControlWindow {
 	lc := &LaunchControlWindow{client: client, lg: lg}
+	lc.initDepartures()
 
 	config := &client.State.LaunchConfig
-	for airport, runwayRates := range util.SortedMap(config.DepartureRates) {
-		for rwy, rates := range util.SortedMap(runwayRates) {
-			for category := range util.SortedMap(rates) {
-				lc.departures = append(lc.departures, &LaunchDeparture{
-					LaunchAircraft: LaunchAircraft{Airport: airport},
-					Runway:         rwy,
-					Category:       category,
-				})
-			}
-		}
-	}
-
 	for airport := range util.SortedMap(config.VFRAirportRates) {
 		rwy := client.State.VFRRunways[airport]
 		lc.vfrDepartures = append(lc.vfrDepartures, &LaunchDeparture{
@@ -107,6 +97,23 @@ func MakeLaunchControlWindow(client *client.ControlClient, lg *log.Logger) *Laun
 	return lc
 }
 
+func (lc *LaunchControlWindow) initDepartures() {
+	lc.departures = nil
+	lc.runwayConfiguration = lc.client.State.RunwayConfiguration
+
+	for airport, runwayRates := range util.SortedMap(lc.client.State.LaunchConfig.DepartureRates) {
+		for rwy, rates := range util.SortedMap(runwayRates) {
+			for category := range util.SortedMap(rates) {
+				lc.departures = append(lc.departures, &LaunchDeparture{
+					LaunchAircraft: LaunchAircraft{Airport: airport},
+					Runway:         rwy,
+					Category:       category,
+				})
+			}
+		}
+	}
+}
+
 func (lc *LaunchControlWindow) spawnIFRDeparture(dep *LaunchDeparture) {
 	lc.client.CreateDeparture(dep.Airport, string(dep.Runway), dep.Category, av.FlightRulesIFR, &dep.Aircraft,
 		func(err error) {
@@ -266,6 +273,16 @@ func (lc *LaunchControlWindow) Draw(eventStream *sim.EventStream, p platform.Pla
 	imgui.SetNextWindowSizeConstraints(imgui.Vec2{300, 100}, imgui.Vec2{-1, float32(p.WindowSize()[1]) * 19 / 20})
 	imgui.BeginV("Launch Control", &showLaunchControls, imgui.WindowFlagsAlwaysAutoResize)
 
+	if lc.client.State.RunwayConfiguration != lc.runwayConfiguration {
+		// The departure runways have changed; replace the aircraft
+		// waiting for manual launch with ones at the new runways.
+		lc.cleanupDepartures()
+		lc.initDepartures()
+		if lc.client.State.LaunchConfig.DepartureMode == sim.LaunchManual {
+			lc.spawnDepartures()
+		}
+	}
+
 	ctrl := lc.client.State.LaunchConfig.Controller
 
 	// Show launch control take/release buttons when there are multiple human controllers
@@ -337,6 +354,36 @@ func (lc *LaunchControlWindow) Draw(eventStream *sim.EventStream, p platform.Pla
 			}
 		}
 
+		// Runway configuration selector row (if the scenario has alternatives)
+		if rcs := lc.client.State.RunwayConfigurations; len(rcs) > 1 {
+			cur := lc.client.State.RunwayConfiguration
+			runwaysLabel := func(rc sim.RunwayConfiguration) string {
+				if tw := lc.client.State.MaxTailwind(rc); tw >= 0.5 {
+					return fmt.Sprintf("%s (%d kt tailwind)", rc.Name, int(tw+0.5))
+				}
+				return rc.Name
+			}
+			imgui.Text("Runways:")
+			imgui.SameLine()
+			imgui.SetNextItemWidth(250)
+			if imgui.BeginCombo("##runways", cur) {
+				for _, rc := range rcs {
+					if imgui.SelectableBoolV(runwaysLabel(rc), rc.Name == cur, 0, imgui.Vec2{}) && rc.Name != cur {
+						lc.client.ChangeRunwayConfiguration(rc.Name)
+					}
+				}
+				imgui.EndCombo()
+			}
+			imgui.SameLine()
+			if imgui.Checkbox("Automatic (TMU)", &lc.client.State.LaunchConfig.AutomaticRunwayChanges) {
+				lc.client.SetLaunchConfig(lc.client.State.LaunchConfig)
+			}
+			if tw := lc.client.State.ExcessiveTailwinds(); len(tw) > 0 {
+				imgui.TextColored(imgui.Vec4{X: 1, Y: 0.6, Z: 0.2, W: 1},
+					fmt.Sprintf("%d kt tailwind on %s %s", int(tw[0].Tailwind+0.5), tw[0].Airport, tw[0].Runway))
+			}
+		}
+
 		imgui.Separator()
 
 		flags := imgui.TableFlagsBordersH | imgui.TableFlagsBordersOuterV | imgui.TableFlagsRowBg |
@@ -9,6 +9,7 @@ package main
 // exits.
 
 import (
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
@@ -61,12 +62,17 @@ var (
 	showRoutes        = flag.String("routes", "", "display the STARS, SIDs, and approaches known for the given `airport`")
 	listMaps          = flag.String("listmaps", "", "`path` to a video map file to list maps of (e.g., resources/videomaps/ZNY-videomaps.gob.zst)")
 	listScenarios     = flag.Bool("listscenarios", false, "list all available scenarios in ARTCC/TRACON/scenario format")
-	runSim            = flag.String("runsim", "", "run specified `scenario` for 3600 update steps (format: ARTCC/TRACON/scenario)")
+	runSim            = flag.String("runsim", "", "run specified `scenario` headless (format: ARTCC/TRACON/scenario)")
+	runSimDuration    = flag.Duration("runsim-duration", time.Hour, "amount of sim `time` to run for with -runsim")
+	runSimSeed        = flag.Uint64("runsim-seed", 0, "random `seed` for -runsim (0 = pick one)")
+	runSimReport      = flag.String("runsim-report", "", "write a JSON session report for -runsim to `file`")
 	navLog            = flag.Bool("navlog", false, "enable navigation logging")
 	navLogCategories  = flag.String("navlog-categories", "all", "navigation log `categories` (comma-separated: state,waypoint,altitude,speed,heading,approach,command,route)")
 	navLogCallsign    = flag.String("navlog-callsign", "", "filter navigation logs to only show this `callsign` (empty = show all)")
 	replayMode        = flag.Bool("replay", false, "replay scenario from saved config")
 	replayDuration    = flag.String("replay-duration", "3600", "replay `duration` in seconds or 'until:CALLSIGN'")
+	recordDir         = flag.String("record", "", "record sim sessions to files in `directory`")
+	playback          = flag.String("playback", "", "play back the session recorded in `file`")
 	waypointCommands  = flag.String("waypoint-commands", "", "waypoint `commands` in format 'FIX:CMD CMD CMD, FIX:CMD ...,'")
 	starsRandoms      = flag.Bool("starsrandoms", false, "run STARS command fuzz testing with full UI (randomly picks a scenario)")
 )
@@ -250,8 +256,16 @@ func runSimulation(lg *log.Logger) error {
 		return fmt.Errorf("failed to create simulation configuration: %w", err)
 	}
 
+	seed := *runSimSeed
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	fmt.Printf("Random seed: %d\n", seed)
+	newSimConfig.Seed = seed
+
 	// Pick a random time in November 2025
 	r := rand.Make()
+	r.Seed(seed)
 	day, hour, min, sec := 1+r.Intn(30), r.Intn(24), r.Intn(60), r.Intn(60)
 	newSimConfig.StartTime = time.Date(2025, time.November, day, hour, min, sec, 0, time.UTC)
 	fmt.Printf("Simulation start time: %s\n", newSimConfig.StartTime.Format(time.RFC3339))
@@ -292,18 +306,36 @@ func runSimulation(lg *log.Logger) error {
 	// Check initial aircraft count
 	fmt.Printf("Starting simulation with %d aircraft\n", len(s.Aircraft))
 
-	// Run the sim for an hour of virtual time.
-	const totalUpdates = 3600
+	var reporter *sim.SessionReporter
+	if *runSimReport != "" {
+		reporter = sim.NewSessionReporter(s, *runSim, seed)
+	}
+
+	totalUpdates := int(runSimDuration.Seconds())
 	for range totalUpdates {
 		s.Step(time.Second)
+		if reporter != nil {
+			reporter.Update()
+		}
 	}
 
 	// Check final aircraft count
 	fmt.Printf("Simulation ended with %d aircraft\n", len(s.Aircraft))
 
+	if reporter != nil {
+		report, err := json.MarshalIndent(reporter.Report(), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode session report: %w", err)
+		}
+		if err := os.WriteFile(*runSimReport, report, 0644); err != nil {
+			return fmt.Errorf("failed to write session report: %w", err)
+		}
+		fmt.Printf("Wrote session report to %s\n", *runSimReport)
+	}
+
 	elapsed := time.Since(startTime)
 	fmt.Printf("Simulation complete: %d updates in %.2f seconds (%.1fx real-time)\n",
-		totalUpdates, elapsed.Seconds(), totalUpdates/elapsed.Seconds())
+		totalUpdates, elapsed.Seconds(), float64(totalUpdates)/elapsed.Seconds())
 	return nil
 }
 
@@ -343,6 +375,7 @@ func runServerMode(lg *log.Logger) error {
 		ExtraVideoMap: *videoMapFilename,
 		ServerAddress: *serverAddress,
 		IsLocal:       false,
+		RecordDir:     *recordDir,
 	}, lg)
 	return nil
 }
@@ -410,13 +443,18 @@ func startBackgroundModelLoading(config *Config, plat platform.Platform, lg *log
 			config.WhisperRealtimeFactor = realtimeFactor
 		})
 
+	config.updateRemoteSTT()
+	config.updateSTTCapture()
+
 	// Start loading the TTS model in the background so it's ready
 	// when pilot readbacks or contacts are needed.
 	tts.PreloadTTSModel(lg, platform.AudioSampleRate)
 
-	// Check for whisper model errors asynchronously and show dialog if CPU not supported.
+	// Check for whisper model errors asynchronously and show dialog if CPU
+	// not supported. There's no need if a remote server is being used.
+	remoteSTT := config.UseRemoteSTT && config.RemoteSTTURL != ""
 	go func() {
-		if err := client.WhisperModelError(); err != nil {
+		if err := client.WhisperModelError(); err != nil && !remoteSTT {
 			if errors.Is(err, client.ErrWindowsARM) {
 				ShowErrorDialog(plat, lg, "Speech-to-text is unavailable on this computer.\n\n"+
 					"You appear to be running vice on a Windows ARM device (e.g., Snapdragon) "+
@@ -465,6 +503,25 @@ func loadSavedSim(mgr *client.ConnectionManager, config *Config,
 	return c, activeRadarPane
 }
 
+// loadPlayback starts replaying the session recording given with the
+// -playback flag. Returns the control client and active radar pane if
+// successful, or nil for both if loading fails.
+func loadPlayback(mgr *client.ConnectionManager, config *Config,
+	plat platform.Platform, lg *log.Logger) (*client.ControlClient, panes.Pane) {
+	c, err := mgr.LoadReplay(*playback, config.ControllerInitials, lg)
+	if err != nil {
+		ShowErrorDialog(plat, lg, "Unable to play back %s: %v", *playback, err)
+		return nil, nil
+	}
+
+	isSTARSSim := av.DB.IsTRACON(c.State.Facility) || av.DB.IsATCT(c.State.Facility)
+	activeRadarPane := config.ActiveRadarPane(isSTARSSim)
+	activeRadarPane.LoadedSim(c, plat, lg)
+	uiResetControlClient(c, plat, lg)
+
+	return c, activeRadarPane
+}
+
 // setupFuzzTesting connects to a server, picks a random scenario, and
 // creates a fuzz controller for STARS command testing.
 func setupFuzzTesting(mgr *client.ConnectionManager, config *Config,
@@ -553,7 +610,7 @@ func runGUI(config *Config, configErr error, lg *log.Logger) error {
 	var errorLogger util.ErrorLogger
 	var extraScenarioErrors string
 	mgr, errorLogger, extraScenarioErrors = client.MakeServerManager(*serverAddress, *scenarioFilename,
-		*videoMapFilename, &config.DisableTextToSpeech, lg,
+		*videoMapFilename, *recordDir, &config.DisableTextToSpeech, lg,
 		func(c *client.ControlClient) { // updated client
 			if c != nil {
 				// Determine if this is a STARS or ERAM scenario
@@ -615,8 +672,14 @@ func runGUI(config *Config, configErr error, lg *log.Logger) error {
 	// This shows a progress dialog if benchmarking is still in progress.
 	WaitForWhisperBenchmark(render, plat, lg)
 
-	// Restore previously-saved simulation if available.
-	if c, arp := loadSavedSim(mgr, config, plat, lg); c != nil {
+	// Play back a recording if one was specified, otherwise restore the
+	// previously-saved simulation if available.
+	if *playback != "" {
+		if c, arp := loadPlayback(mgr, config, plat, lg); c != nil {
+			controlClient = c
+			activeRadarPane = arp
+		}
+	} else if c, arp := loadSavedSim(mgr, config, plat, lg); c != nil {
 		controlClient = c
 		activeRadarPane = arp
 	}
@@ -734,7 +797,9 @@ func runGUI(config *Config, configErr error, lg *log.Logger) error {
 				controlClient.StopStreamingSTT(lg)
 			}
 
-			saveSim := mgr.ClientIsLocal() && fuzzController == nil // Don't save fuzz sims
+			// Don't save fuzz sims or replays
+			saveSim := mgr.ClientIsLocal() && fuzzController == nil &&
+				(controlClient == nil || controlClient.State.Replay == nil)
 			config.SaveIfChanged(render, plat, controlClient, saveSim, lg)
 			mgr.Disconnect()
 			break
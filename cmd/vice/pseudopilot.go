@@ -0,0 +1,151 @@
+// pseudopilot.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/client"
+	"github.com/mmp/vice/log"
+	"github.com/mmp/vice/sim"
+	"github.com/mmp/vice/util"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+var pseudoPilot struct {
+	selected av.ADSBCallsign
+	command  string
+	text     string
+	request  bool // send the transmission as a request rather than a readback
+	message  string
+
+	events   *sim.EventsSubscription
+	received []sim.Event // most recent controller commands, oldest first
+}
+
+// drawPseudoPilotWindow draws the window that a pseudo-pilot uses to fly
+// their aircraft: a list of them, the commands that controllers have
+// issued to them, an input for commands to run for the selected one, and
+// an input for transmissions to send to its controller.
+func drawPseudoPilotWindow(c *client.ControlClient, eventStream *sim.EventStream, lg *log.Logger) {
+	pp := c.State.UserPseudoPilot()
+
+	if pseudoPilot.events == nil {
+		pseudoPilot.events = eventStream.Subscribe()
+	}
+	for _, e := range pseudoPilot.events.Get() {
+		if e.Type == sim.PseudoPilotCommandEvent && e.DestinationTCW == c.State.UserTCW {
+			pseudoPilot.received = append(pseudoPilot.received, e)
+			if len(pseudoPilot.received) > 10 {
+				pseudoPilot.received = pseudoPilot.received[1:]
+			}
+		}
+	}
+
+	imgui.BeginV(fmt.Sprintf("Pseudo-pilot: %s###PseudoPilot", c.State.UserTCW), nil, imgui.WindowFlagsAlwaysAutoResize)
+
+	var tracks []*sim.Track
+	for _, callsign := range util.SortedMapKeys(c.State.Tracks) {
+		if trk := c.State.Tracks[callsign]; pp.Flies(trk.ControllerFrequency) {
+			tracks = append(tracks, trk)
+		}
+	}
+	if _, ok := c.State.Tracks[pseudoPilot.selected]; !ok {
+		pseudoPilot.selected = ""
+	}
+
+	flags := imgui.TableFlagsBordersV | imgui.TableFlagsBordersOuterH | imgui.TableFlagsRowBg | imgui.TableFlagsSizingFixedFit |
+		imgui.TableFlagsScrollY
+	if imgui.BeginTableV("aircraft", 5, flags, imgui.Vec2{450, 250}, 0) {
+		imgui.TableSetupScrollFreeze(0, 1)
+		imgui.TableSetupColumn("Callsign")
+		imgui.TableSetupColumn("Type")
+		imgui.TableSetupColumn("Altitude")
+		imgui.TableSetupColumn("Speed")
+		imgui.TableSetupColumn("Frequency")
+		imgui.TableHeadersRow()
+
+		for _, trk := range tracks {
+			imgui.TableNextRow()
+			imgui.TableNextColumn()
+			selected := trk.ADSBCallsign == pseudoPilot.selected
+			if imgui.SelectableBoolV(string(trk.ADSBCallsign), selected, imgui.SelectableFlagsSpanAllColumns, imgui.Vec2{}) {
+				pseudoPilot.selected = trk.ADSBCallsign
+			}
+			imgui.TableNextColumn()
+			if trk.FlightPlan != nil {
+				imgui.Text(trk.FlightPlan.AircraftType)
+			}
+			imgui.TableNextColumn()
+			imgui.Text(fmt.Sprintf("%d", int(trk.TrueAltitude+50)/100*100))
+			imgui.TableNextColumn()
+			imgui.Text(fmt.Sprintf("%d", int(trk.Groundspeed)))
+			imgui.TableNextColumn()
+			imgui.Text(string(trk.ControllerFrequency))
+		}
+		imgui.EndTable()
+	}
+
+	if len(pseudoPilot.received) > 0 {
+		imgui.SeparatorText("Controller commands")
+		for i, e := range slices.Backward(pseudoPilot.received) {
+			if imgui.SelectableBool(fmt.Sprintf("%s %s: %s###ppcmd%d", e.FromController, e.ADSBCallsign, e.WrittenText, i)) {
+				pseudoPilot.selected = e.ADSBCallsign
+				pseudoPilot.command = e.WrittenText
+			}
+		}
+	}
+
+	if pseudoPilot.selected == "" {
+		imgui.Text("Select an aircraft to fly.")
+	} else {
+		callsign := pseudoPilot.selected
+
+		imgui.SetNextItemWidth(300)
+		if imgui.InputTextWithHint("Command##ppcmd", "e.g. H270 D40", &pseudoPilot.command,
+			imgui.InputTextFlagsEnterReturnsTrue|imgui.InputTextFlagsCharsUppercase, nil) {
+			if cmd := strings.TrimSpace(pseudoPilot.command); cmd != "" {
+				c.RunPseudoPilotCommands(callsign, cmd, func(message, remainingInput string) {
+					pseudoPilot.command = remainingInput
+					pseudoPilot.message = message
+				})
+			}
+		}
+
+		imgui.SetNextItemWidth(300)
+		send := imgui.InputTextWithHint("##pptext", "Transmission", &pseudoPilot.text, imgui.InputTextFlagsEnterReturnsTrue, nil)
+		imgui.SameLine()
+		send = imgui.Button("Send") || send
+		imgui.SameLine()
+		imgui.Checkbox("Request", &pseudoPilot.request)
+		if imgui.IsItemHovered() {
+			imgui.SetTooltip("Call the controller with a request rather than reading back")
+		}
+
+		if text := strings.TrimSpace(pseudoPilot.text); send && text != "" {
+			ty := util.Select[av.RadioTransmissionType](pseudoPilot.request, av.RadioTransmissionContact, av.RadioTransmissionReadback)
+			c.PseudoPilotTransmit(callsign, text, ty, func(err error) {
+				if err != nil {
+					pseudoPilot.message = err.Error()
+					lg.Warnf("PseudoPilotTransmit: %v", err)
+				}
+			})
+			pseudoPilot.text = ""
+			pseudoPilot.message = ""
+		}
+	}
+
+	if pseudoPilot.message != "" {
+		imgui.PushStyleColorVec4(imgui.ColText, imgui.Vec4{1, .5, .5, 1})
+		imgui.Text(pseudoPilot.message)
+		imgui.PopStyleColor()
+	}
+
+	imgui.End()
+}
@@ -0,0 +1,74 @@
+// replay.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/mmp/vice/client"
+	"github.com/mmp/vice/log"
+	"github.com/mmp/vice/renderer"
+	"github.com/mmp/vice/util"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+var replayRates = []float32{1, 2, 4, 8, 16}
+
+// Seeking is done when the slider is released; until then, we track its
+// position here rather than taking it from the sim time.
+var replaySeek struct {
+	active bool
+	pos    int32
+}
+
+// drawReplayWindow draws the playback controls for a sim that is replaying
+// a recorded session.
+func drawReplayWindow(c *client.ControlClient, lg *log.Logger) {
+	r := c.State.Replay
+
+	imgui.BeginV("Replay: "+filepath.Base(r.Filename)+"###Replay", nil, imgui.WindowFlagsAlwaysAutoResize)
+
+	if imgui.Button(util.Select(c.State.Paused, renderer.FontAwesomeIconPlayCircle, renderer.FontAwesomeIconPauseCircle)) {
+		c.ToggleSimPause()
+	}
+	for _, rate := range replayRates {
+		imgui.SameLine()
+		if imgui.RadioButtonBool(fmt.Sprintf("%gx", rate), c.State.SimRate == rate) {
+			c.SetSimRate(rate)
+		}
+	}
+
+	length := int32(r.End.Sub(r.Start).Seconds())
+	pos := int32(c.CurrentTime().Sub(r.Start).Seconds())
+	if replaySeek.active {
+		pos = replaySeek.pos
+	}
+	pos = max(0, min(pos, length))
+
+	imgui.PushItemWidth(400)
+	imgui.SliderIntV("##replaySeek", &pos, 0, length, formatReplayTime(pos)+" / "+formatReplayTime(length),
+		imgui.SliderFlagsAlwaysClamp)
+	imgui.PopItemWidth()
+	replaySeek.active, replaySeek.pos = imgui.IsItemActive(), pos
+
+	if imgui.IsItemDeactivatedAfterEdit() {
+		c.SeekReplay(r.Start.Add(time.Duration(pos)*time.Second), func(err error) {
+			if err != nil {
+				lg.Errorf("SeekReplay: %v", err)
+			}
+		})
+	}
+
+	imgui.Text("Sim time: " + c.CurrentTime().UTC().Format("15:04:05Z"))
+
+	imgui.End()
+}
+
+func formatReplayTime(s int32) string {
+	return fmt.Sprintf("%d:%02d:%02d", s/3600, (s/60)%60, s%60)
+}
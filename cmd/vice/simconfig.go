@@ -138,8 +138,8 @@ func loadEmergencies(e *util.ErrorLogger) []sim.Emergency {
 			e.ErrorString(`no emergency "stages" defined`)
 		}
 		for i, stage := range em.Stages {
-			// transmission is required unless request_return is true
-			if stage.Transmission == "" && !stage.RequestReturn {
+			// transmission is required unless request_return, lost_comms, or blunder is true
+			if stage.Transmission == "" && !stage.RequestReturn && !stage.LostComms && !stage.Blunder {
 				e.ErrorString(`stage %d missing required field "transmission"`, i)
 			}
 			// duration_minutes is required for all stages except the last one
@@ -397,8 +397,8 @@ func (c *NewSimConfiguration) ShowConfigurationWindow() bool {
 // on the scenario selection screen.
 func (c *NewSimConfiguration) ScenarioSelectionDisabled(config *Config) bool {
 	if c.newSimType == NewSimJoinRemote {
-		// For join, need TCW selected and initials
-		if c.selectedTCW == "" || len(config.ControllerInitials) != 2 {
+		// For join, need TCW selected (unless joining as a pseudo-pilot) and initials
+		if (c.selectedTCW == "" && !c.joinRequest.PseudoPilot) || len(config.ControllerInitials) != 2 {
 			return true
 		}
 	}
@@ -1079,8 +1079,18 @@ func (c *NewSimConfiguration) DrawScenarioSelectionUI(p platform.Platform, confi
 			return result
 		}
 
+		if imgui.Checkbox("Join as pseudo-pilot", &c.joinRequest.PseudoPilot) {
+			c.selectedTCW = ""
+			c.selectedTCPs = nil
+			c.showReliefPositions = false
+			c.joinRequest.JoiningAsRelief = false
+		}
+		if imgui.IsItemHovered() {
+			imgui.SetTooltip("Fly aircraft as a pilot rather than controlling them")
+		}
+
 		// Checkbox for showing relief positions (only if some TCWs are occupied)
-		if len(coveredPrimaryTCPs) > 0 {
+		if len(coveredPrimaryTCPs) > 0 && !c.joinRequest.PseudoPilot {
 			if imgui.Checkbox("Join as relief (show occupied positions)", &c.showReliefPositions) {
 				// Clear selection when mode changes
 				c.selectedTCW = ""
@@ -1098,66 +1108,25 @@ func (c *NewSimConfiguration) DrawScenarioSelectionUI(p platform.Platform, confi
 			imgui.TableSetupColumn("Label")
 			imgui.TableSetupColumn("Value")
 
-			// Row 1: Select TCW
-			imgui.TableNextRow()
-			imgui.TableNextColumn()
-			imgui.Text("Select TCW:")
-			imgui.TableNextColumn()
-			first := true
-			for tcw, cons := range util.SortedMap(rs.CurrentConsolidation) {
-				// Filter: relief shows only occupied, normal shows only unoccupied
-				if c.showReliefPositions != cons.IsOccupied() {
-					continue
-				}
-				// Skip internal positions
-				if len(tcw) > 0 && tcw[0] == '_' {
-					continue
-				}
-
-				if !first {
-					imgui.SameLine()
-				}
-				first = false
-
-				label := controllerDisplayLabel(controllersForGroup, av.ControlPosition(tcw))
-				selected := tcw == c.selectedTCW
-				if imgui.RadioButtonBool(fmt.Sprintf("%s##tcw-%s", label, tcw), selected) {
-					c.selectedTCW = tcw
-					c.joinRequest.JoiningAsRelief = c.showReliefPositions
-					// Initialize selected TCPs from TCW's current positions
-					if !c.showReliefPositions {
-						c.selectedTCPs = getDefaultSelectedTCPs(tcw)
-					} else {
-						c.selectedTCPs = nil
-					}
-				}
-				// Tooltip shows positions (and controller for relief mode)
-				if c.showReliefPositions && imgui.IsItemHovered() {
-					tooltip := fmtTCPs(cons)
-					if len(cons.Initials) > 0 {
-						tooltip += " (" + strings.Join(cons.Initials, ", ") + ")"
-					}
-					imgui.SetTooltip(tooltip)
-				}
-			}
-
-			// Row 2: Select positions (only for unoccupied TCW selection, not relief)
-			if c.selectedTCW != "" && !c.showReliefPositions {
+			if c.joinRequest.PseudoPilot {
 				imgui.TableNextRow()
 				imgui.TableNextColumn()
-				imgui.Text("Select positions:")
+				imgui.Text("Fly aircraft on:")
 				imgui.TableNextColumn()
 
-				// Show all available TCPs (excludes primaries at occupied TCWs)
-				availableTCPs := getAvailableTCPs()
-				for tcp := range util.SortedMap(availableTCPs) {
+				var allTCPs []sim.TCP
+				for _, cons := range rs.CurrentConsolidation {
+					allTCPs = append(allTCPs, cons.OwnedPositions()...)
+				}
+				slices.Sort(allTCPs)
+				for _, tcp := range slices.Compact(allTCPs) {
 					if len(tcp) > 0 && tcp[0] == '_' {
 						continue
 					}
 
 					isSelected := c.selectedTCPs[tcp]
 					label := controllerDisplayLabel(controllersForGroup, av.ControlPosition(tcp))
-					if imgui.Checkbox(fmt.Sprintf("%s##tcp-%s", label, tcp), &isSelected) {
+					if imgui.Checkbox(fmt.Sprintf("%s##pptcp-%s", label, tcp), &isSelected) {
 						if c.selectedTCPs == nil {
 							c.selectedTCPs = make(map[sim.TCP]bool)
 						}
@@ -1165,10 +1134,81 @@ func (c *NewSimConfiguration) DrawScenarioSelectionUI(p platform.Platform, confi
 					}
 					imgui.SameLine()
 				}
+				imgui.NewLine()
+				imgui.TextDisabled("If none are selected, all aircraft on a controller's frequency are flown.")
+			} else {
+				// Row 1: Select TCW
+				imgui.TableNextRow()
+				imgui.TableNextColumn()
+				imgui.Text("Select TCW:")
+				imgui.TableNextColumn()
+				first := true
+				for tcw, cons := range util.SortedMap(rs.CurrentConsolidation) {
+					// Filter: relief shows only occupied, normal shows only unoccupied
+					if c.showReliefPositions != cons.IsOccupied() {
+						continue
+					}
+					// Skip internal positions
+					if len(tcw) > 0 && tcw[0] == '_' {
+						continue
+					}
+
+					if !first {
+						imgui.SameLine()
+					}
+					first = false
+
+					label := controllerDisplayLabel(controllersForGroup, av.ControlPosition(tcw))
+					selected := tcw == c.selectedTCW
+					if imgui.RadioButtonBool(fmt.Sprintf("%s##tcw-%s", label, tcw), selected) {
+						c.selectedTCW = tcw
+						c.joinRequest.JoiningAsRelief = c.showReliefPositions
+						// Initialize selected TCPs from TCW's current positions
+						if !c.showReliefPositions {
+							c.selectedTCPs = getDefaultSelectedTCPs(tcw)
+						} else {
+							c.selectedTCPs = nil
+						}
+					}
+					// Tooltip shows positions (and controller for relief mode)
+					if c.showReliefPositions && imgui.IsItemHovered() {
+						tooltip := fmtTCPs(cons)
+						if len(cons.Initials) > 0 {
+							tooltip += " (" + strings.Join(cons.Initials, ", ") + ")"
+						}
+						imgui.SetTooltip(tooltip)
+					}
+				}
+
+				// Row 2: Select positions (only for unoccupied TCW selection, not relief)
+				if c.selectedTCW != "" && !c.showReliefPositions {
+					imgui.TableNextRow()
+					imgui.TableNextColumn()
+					imgui.Text("Select positions:")
+					imgui.TableNextColumn()
+
+					// Show all available TCPs (excludes primaries at occupied TCWs)
+					availableTCPs := getAvailableTCPs()
+					for tcp := range util.SortedMap(availableTCPs) {
+						if len(tcp) > 0 && tcp[0] == '_' {
+							continue
+						}
 
-				imgui.Checkbox("Instructor", &c.Privileged)
-				if imgui.IsItemHovered() {
-					imgui.SetTooltip("Allows control of any aircraft regardless of position ownership")
+						isSelected := c.selectedTCPs[tcp]
+						label := controllerDisplayLabel(controllersForGroup, av.ControlPosition(tcp))
+						if imgui.Checkbox(fmt.Sprintf("%s##tcp-%s", label, tcp), &isSelected) {
+							if c.selectedTCPs == nil {
+								c.selectedTCPs = make(map[sim.TCP]bool)
+							}
+							c.selectedTCPs[tcp] = isSelected
+						}
+						imgui.SameLine()
+					}
+
+					imgui.Checkbox("Instructor", &c.Privileged)
+					if imgui.IsItemHovered() {
+						imgui.SetTooltip("Allows control of any aircraft regardless of position ownership")
+					}
 				}
 			}
 
@@ -1311,7 +1351,9 @@ func (c *NewSimConfiguration) DrawConfigurationUI(p platform.Platform, config *C
 	c.mu.Lock(c.lg)
 	defer c.mu.Unlock(c.lg)
 
-	if c.fetchMETARError != nil {
+	if c.ScenarioSpec.ScriptedWeather {
+		imgui.Text("This scenario's weather is scripted.")
+	} else if c.fetchMETARError != nil {
 		imgui.PushStyleColorVec4(imgui.ColText, imgui.Vec4{1, .5, .5, 1})
 		imgui.Text("Error: " + c.fetchMETARError.Error())
 		imgui.PopStyleColor()
@@ -1387,6 +1429,8 @@ func (c *NewSimConfiguration) DrawConfigurationUI(p platform.Platform, config *C
 	imgui.SliderFloatV("##emergencyRate", &lc.EmergencyAircraftRate, 0, 20,
 		util.Select(lc.EmergencyAircraftRate == 0, "never", "%.1f /hr"), imgui.SliderFlagsNone)
 
+	imgui.Checkbox("Pilots deviate around heavy precipitation", &lc.WeatherDeviations)
+
 	return false
 }
 
@@ -1411,6 +1455,7 @@ func (c *NewSimConfiguration) DrawRatesUI(p platform.Platform) bool {
 	drawArrivalUI(&c.ScenarioSpec.LaunchConfig, p)
 	drawOverflightUI(&c.ScenarioSpec.LaunchConfig, p)
 	drawEmergencyAircraftUI(&c.ScenarioSpec.LaunchConfig, p)
+	imgui.Checkbox("Pilots deviate around heavy precipitation", &c.ScenarioSpec.LaunchConfig.WeatherDeviations)
 	return false
 }
 
@@ -1742,6 +1787,9 @@ func controlPositionsForGroup(server *client.Server, groupName string) map[sim.T
 
 var acknowledgedATIS = make(map[string]string)
 
+// Airports for which the full ATIS text is shown in the info window.
+var expandedATIS = make(map[string]bool)
+
 func drawScenarioInfoWindow(config *Config, c *client.ControlClient, activeRadarPane panes.Pane, p platform.Platform, lg *log.Logger) bool {
 	// Ensure that the window is wide enough to show the description
 	sz := imgui.CalcTextSize(c.State.SimDescription)
@@ -1900,6 +1948,23 @@ func drawScenarioInfoWindow(config *Config, c *client.ControlClient, activeRadar
 					raw = strings.TrimPrefix(raw, "SPECI ")
 					imgui.Text(raw)
 					imgui.PopFont()
+
+					if atis, ok := c.State.ATIS[ap]; ok {
+						imgui.SameLine()
+						if imgui.SmallButton(util.Select(expandedATIS[ap], "Hide", "Text") + "##atis_text_" + ap) {
+							expandedATIS[ap] = !expandedATIS[ap]
+						}
+						imgui.SameLine()
+						if imgui.SmallButton("Play##atis_play_" + ap) {
+							c.PlayATIS(ap)
+						}
+						if expandedATIS[ap] {
+							// Wrap to the width of the METAR so the window doesn't grow.
+							imgui.PushTextWrapPosV(imgui.CursorPosX() + max(imgui.CalcTextSize(raw).X, 300))
+							imgui.TextUnformatted(atis.Text)
+							imgui.PopTextWrapPos()
+						}
+					}
 				}
 
 				imgui.EndTable()
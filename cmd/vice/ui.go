@@ -249,6 +249,20 @@ func uiDraw(mgr *client.ConnectionManager, config *Config, p platform.Platform,
 			if imgui.IsItemHovered() {
 				imgui.SetTooltip("Toggle flight strips window")
 			}
+
+			if imgui.Button(renderer.FontAwesomeIconCheckSquare) {
+				uiShowDebriefDialog(controlClient, p, lg)
+			}
+			if imgui.IsItemHovered() {
+				imgui.SetTooltip("Show session debrief")
+			}
+
+			if imgui.Button(renderer.FontAwesomeIconHistory) {
+				uiShowModalDialog(NewModalDialogBox(&CheckpointsModalClient{controlClient: controlClient, lg: lg}, p), false)
+			}
+			if imgui.IsItemHovered() {
+				imgui.SetTooltip("Save sim checkpoints and rewind to them")
+			}
 		}
 
 		if imgui.Button(renderer.FontAwesomeIconBook) {
@@ -329,6 +343,13 @@ func uiDraw(mgr *client.ConnectionManager, config *Config, p platform.Platform,
 		if ui.showFlightStrips {
 			config.FlightStripPane.DrawWindow(&ui.showFlightStrips, controlClient, p, lg)
 		}
+
+		if controlClient.State.Replay != nil {
+			drawReplayWindow(controlClient, lg)
+		}
+		if controlClient.State.UserPseudoPilot() != nil {
+			drawPseudoPilotWindow(controlClient, eventStream, lg)
+		}
 	}
 
 	for _, event := range ui.eventsSubscription.Get() {
@@ -366,6 +387,7 @@ func uiDraw(mgr *client.ConnectionManager, config *Config, p platform.Platform,
 func uiResetControlClient(c *client.ControlClient, p platform.Platform, lg *log.Logger) {
 	ui.launchControlWindow = nil
 	clear(acknowledgedATIS)
+	clear(expandedATIS)
 }
 
 ///////////////////////////////////////////////////////////////////////////
@@ -875,8 +897,40 @@ func uiDrawSettingsWindow(c *client.ControlClient, config *Config, activeRadarPa
 			imgui.EndCombo()
 		}
 
+		// Remote transcription server
+		if imgui.Checkbox("Use remote transcription server", &config.UseRemoteSTT) {
+			config.updateRemoteSTT()
+		}
+		if config.UseRemoteSTT {
+			changed := false
+			imgui.SetNextItemWidth(300)
+			changed = imgui.InputTextWithHint("Server URL", "http://host:port/v1", &config.RemoteSTTURL, 0, nil) || changed
+			imgui.SetNextItemWidth(300)
+			changed = imgui.InputTextWithHint("Model", "(server default)", &config.RemoteSTTModel, 0, nil) || changed
+			imgui.SetNextItemWidth(300)
+			changed = imgui.InputTextWithHint("API key", "(optional)", &config.RemoteSTTAPIKey,
+				imgui.InputTextFlagsPassword, nil) || changed
+			if changed {
+				config.updateRemoteSTT()
+			}
+			imgui.TextWrapped("The server must provide an OpenAI-compatible /audio/transcriptions endpoint.")
+		}
+
+		// Capture of transmissions for STT test cases
+		if imgui.Checkbox("Save transmissions for speech recognition testing", &config.CaptureSTT) {
+			config.updateSTTCapture()
+		}
+		if config.CaptureSTT {
+			imgui.SetNextItemWidth(300)
+			if imgui.InputTextWithHint("Directory", defaultSTTCaptureDirectory(), &config.STTCaptureDirectory, 0, nil) {
+				config.updateSTTCapture()
+			}
+			imgui.TextWrapped("Each transmission's audio and transcription is saved; a command typed " +
+				"shortly afterward is recorded as its correction.")
+		}
+
 		// Whisper model selection dropdown
-		if modelName := client.GetWhisperModelName(); modelName != "" {
+		if modelName := client.GetWhisperModelName(); modelName != "" && !config.UseRemoteSTT {
 			imgui.Text("Model:")
 			imgui.SameLine()
 			// Format display name (remove ggml- prefix and .bin suffix for readability)
@@ -0,0 +1,109 @@
+// eram/acl.go
+// Copyright(c) 2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+// ERAM Aircraft List (ACL) view with the conflict probe results
+
+package eram
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mmp/vice/panes"
+	"github.com/mmp/vice/radar"
+	"github.com/mmp/vice/renderer"
+	"github.com/mmp/vice/sim"
+)
+
+// Conflicts where the aircraft are predicted to come within this distance
+// are shown in red; others are shown in yellow.
+const aclRedConflictLateral = 3
+
+var (
+	aclRedConflictColor    = renderer.RGB{R: .9, G: 0, B: 0}
+	aclYellowConflictColor = renderer.RGB{R: .9, G: .9, B: 0}
+)
+
+// aclEntry is a flight listed in the ACL along with its earliest
+// predicted conflict, if any.
+type aclEntry struct {
+	fp       *sim.NASFlightPlan
+	conflict *sim.ProbeConflict
+	other    sim.ACID
+}
+
+// aclEntries returns the flights that the user is tracking or that are
+// being handed off to the user, sorted by ACID.
+func (ep *ERAMPane) aclEntries(ctx *panes.Context) []aclEntry {
+	var entries []aclEntry
+	for _, trk := range ctx.Client.State.Tracks {
+		if !trk.IsAssociated() {
+			continue
+		}
+		fp := trk.FlightPlan
+		if !ctx.UserControlsPosition(fp.TrackingController) && !ctx.UserControlsPosition(fp.HandoffController) {
+			continue
+		}
+
+		e := aclEntry{fp: fp}
+		for i, pc := range ctx.Client.State.ProbeConflicts {
+			idx := slices.Index(pc.ACIDs[:], fp.ACID)
+			if idx == -1 {
+				continue
+			}
+			if e.conflict == nil || pc.Start.Before(e.conflict.Start) {
+				e.conflict = &ctx.Client.State.ProbeConflicts[i]
+				e.other = pc.ACIDs[1-idx]
+			}
+		}
+		entries = append(entries, e)
+	}
+
+	slices.SortFunc(entries, func(a, b aclEntry) int { return strings.Compare(string(a.fp.ACID), string(b.fp.ACID)) })
+	return entries
+}
+
+// formatProbeConflict returns the minutes until the conflict and the
+// color it should be shown in.
+func formatProbeConflict(now time.Time, pc *sim.ProbeConflict) (string, renderer.RGB) {
+	minutes := int(max(pc.Start.Sub(now), 0) / time.Minute)
+	color := aclYellowConflictColor
+	if pc.Lateral < aclRedConflictLateral {
+		color = aclRedConflictColor
+	}
+	return fmt.Sprintf("%2d", minutes), color
+}
+
+// drawACLView renders the Aircraft List, which includes a conflict column
+// giving the minutes until each flight's first predicted conflict and the
+// other aircraft involved.
+func (ep *ERAMPane) drawACLView(ctx *panes.Context, transforms radar.ScopeTransformations, cb *renderer.CommandBuffer) {
+	ps := ep.currentPrefs()
+	if !ps.ACL.Visible {
+		return
+	}
+
+	var lines []viewLine
+	for _, e := range ep.aclEntries(ctx) {
+		var l viewLine
+		if e.conflict != nil {
+			minutes, color := formatProbeConflict(ctx.Client.State.SimTime, e.conflict)
+			l = append(l, viewSpan{text: minutes, color: &color})
+		} else {
+			l = append(l, viewSpan{text: "  "})
+		}
+
+		alt := ""
+		if e.fp.AssignedAltitude != 0 {
+			alt = fmt.Sprintf("%03d", e.fp.AssignedAltitude/100)
+		}
+		l = append(l, viewSpan{text: fmt.Sprintf(" %-7s %-5s %3s %s", e.fp.ACID, e.fp.AircraftType, alt, e.other)})
+		lines = append(lines, l)
+	}
+
+	// Columns: conflict minutes, ACID, type, assigned altitude, conflicting aircraft
+	ep.drawView(ctx, transforms, cb, "ACL", &ps.ACL, "CF ACID    TYPE  ALT CONFLICT", lines)
+}
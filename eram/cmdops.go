@@ -16,6 +16,7 @@ import (
 	"github.com/mmp/vice/panes"
 	"github.com/mmp/vice/radar"
 	"github.com/mmp/vice/sim"
+	"github.com/mmp/vice/util"
 )
 
 func registerOpsCommands() {
@@ -55,6 +56,26 @@ func registerOpsCommands() {
 	// QF - Flight Plan Display
 	registerCommand(CommandModeNone, "QF [FLID]|QF[SLEW]", handleFlightPlanReadout)
 
+	// FP - Flight plan entry (keyboard only)
+	// FP [ACID] [TYPE] [BEACON] [ALT] [ROUTE] [FIX_PAIR] [RULES]; the beacon
+	// code, route, fix pair, and rules are optional. The route may start
+	// and end with the departure and arrival airports, e.g. KJFK..MERIT.J55.HFD..KBOS.
+	registerCommand(CommandModeNone, "FP [ALL_TEXT]", handleFlightPlanEntry)
+
+	// AM - Flight plan amendment
+	// Keyboard: AM [FLID] [FIELD] [DATA]
+	// Clicked: AM [FIELD] [DATA][SLEW]
+	// The field may be given by number or name: 3/TYP, 4/BCN, 8/ALT, 10/RTE.
+	registerCommand(CommandModeNone, "AM [FIELD] [ALL_TEXT]", handleAmendFlightPlan)
+	registerCommand(CommandModeNone, "AM [ALL_TEXT][SLEW]", handleAmendFlightPlanClicked)
+
+	// TP - Trial plan: probe a candidate altitude or direct-to without
+	// changing the flight plan.
+	// Keyboard: TP [ALT] [FLID] or TP [FIX] [FLID]
+	// Clicked: TP [ALT][SLEW] or TP [FIX][SLEW]
+	registerCommand(CommandModeNone, "TP [ERAM_ALT_A] [FLID]|TP [ERAM_ALT_A][SLEW]", handleTrialPlanAltitude)
+	registerCommand(CommandModeNone, "TP [FIX] [FLID]|TP [FIX][SLEW]", handleTrialPlanDirect)
+
 	// QS - HSF (Heading / Speed-Mach / Free-text) scratchpad handling
 	// Keyboard:
 	//   QS `<text> <FLID>    - set free text (backtick is clear-weather symbol)
@@ -313,6 +334,9 @@ func handleFlightPlanReadout(ep *ERAMPane, ctx *panes.Context, trk *sim.Track) C
 	zTime := ctx.Client.State.SimTime.Format("1504")
 	rte := strings.TrimPrefix(fp.Route, "/. ")
 	rte = strings.ReplaceAll(rte, " ", ".")
+	if fp.DepartureAirport != "" {
+		rte = fp.DepartureAirport + "." + rte
+	}
 	rte += fmt.Sprintf(".%v", fp.ArrivalAirport)
 	fmt.Printf("rte: %v route: %v\n", rte, fp.Route)
 	return CommandStatus{
@@ -320,6 +344,161 @@ func handleFlightPlanReadout(ep *ERAMPane, ctx *panes.Context, trk *sim.Track) C
 	}
 }
 
+///////////////////////////////////////////////////////////////////////////
+// FP - Flight Plan Entry Handler
+
+func handleFlightPlanEntry(ep *ERAMPane, ctx *panes.Context, text string) (CommandStatus, error) {
+	spec, err := parseFlightPlan("+ACID+#/AC_TYPE/EQ?BEACON+ALT_A?ROUTE?FIX_PAIR,RULES", text,
+		func(string, bool) bool { return false })
+	if err != nil {
+		return CommandStatus{}, err
+	}
+
+	// Flight plans entered at the center are IFR unless specified otherwise.
+	if !spec.Rules.IsSet {
+		spec.Rules.Set(av.FlightRulesIFR)
+	}
+	// Have the new track be acquired by the entering sector when the
+	// aircraft squawks the assigned code.
+	spec.TrackingController.Set(ctx.UserPrimaryPosition())
+	spec.CoordinationTime.Set(ctx.Now)
+
+	acid := spec.ACID.Get()
+	ctx.Client.CreateFlightPlan(spec, func(err error) {
+		if err != nil {
+			ep.bigOutput.displayError(ep.currentPrefs(), err)
+		} else if fp := ctx.Client.State.GetFlightPlanForACID(acid); fp != nil {
+			ep.bigOutput.displaySuccess(ep.currentPrefs(),
+				fmt.Sprintf("ACCEPT\nFLIGHT PLAN\n%s/%s %s", fp.ACID, fp.CID, fp.AssignedSquawk))
+		} else {
+			ep.bigOutput.displaySuccess(ep.currentPrefs(), fmt.Sprintf("ACCEPT\nFLIGHT PLAN\n%s", acid))
+		}
+	})
+
+	return CommandStatus{}, nil
+}
+
+///////////////////////////////////////////////////////////////////////////
+// AM - Flight Plan Amendment Handlers
+
+// amendmentFields maps the field references that may be given in an AM
+// message to the flight plan parse specifier for the new data.
+var amendmentFields = map[string]string{
+	"3":   "+#/AC_TYPE/EQ",
+	"03":  "+#/AC_TYPE/EQ",
+	"TYP": "+#/AC_TYPE/EQ",
+	"4":   "+BEACON",
+	"04":  "+BEACON",
+	"BCN": "+BEACON",
+	"8":   "+ALT_A",
+	"08":  "+ALT_A",
+	"ALT": "+ALT_A",
+	"10":  "+ROUTE",
+	"RTE": "+ROUTE",
+}
+
+func handleAmendFlightPlan(ep *ERAMPane, ctx *panes.Context, flid string, text string) (CommandStatus, error) {
+	if trk, ok := ctx.Client.State.GetTrackByFLID(flid); ok {
+		return ep.amendFlightPlan(ctx, trk, text)
+	}
+
+	// The flight plan may not have a track yet (e.g., it was just entered
+	// with FP), in which case it's identified by its ACID.
+	fp := ctx.Client.State.GetFlightPlanForACID(sim.ACID(flid))
+	if fp == nil {
+		return CommandStatus{}, ErrERAMIllegalACID
+	}
+
+	spec, err := parseAmendment(util.CutAtSpace(text))
+	if err != nil {
+		return CommandStatus{}, err
+	}
+
+	spec.ACID.Set(fp.ACID)
+	ctx.Client.ModifyFlightPlan(fp.ACID, spec, func(err error) {
+		if err != nil {
+			ep.bigOutput.displayError(ep.currentPrefs(), err)
+		}
+	})
+
+	return CommandStatus{
+		bigOutput: fmt.Sprintf("ACCEPT\nAMEND\n%s/%s", fp.ACID, fp.CID),
+	}, nil
+}
+
+func handleAmendFlightPlanClicked(ep *ERAMPane, ctx *panes.Context, text string, trk *sim.Track) (CommandStatus, error) {
+	return ep.amendFlightPlan(ctx, trk, text)
+}
+
+func (ep *ERAMPane) amendFlightPlan(ctx *panes.Context, trk *sim.Track, text string) (CommandStatus, error) {
+	if trk.FlightPlan == nil {
+		return CommandStatus{}, ErrERAMIllegalACID
+	}
+
+	spec, err := parseAmendment(util.CutAtSpace(text))
+	if err != nil {
+		return CommandStatus{}, err
+	}
+
+	ep.modifyFlightPlan(ctx, trk.FlightPlan.CID, spec)
+	if spec.AssignedAltitude.IsSet {
+		if state := ep.TrackState[trk.ADSBCallsign]; state != nil {
+			state.ReachedAltitude = false
+		}
+	}
+
+	return CommandStatus{
+		bigOutput: fmt.Sprintf("ACCEPT\nAMEND\n%s/%s", trk.ADSBCallsign, trk.FlightPlan.CID),
+	}, nil
+}
+
+// parseAmendment returns a FlightPlanSpecifier with the amended field set.
+func parseAmendment(field, data string) (sim.FlightPlanSpecifier, error) {
+	format, ok := amendmentFields[field]
+	if !ok {
+		return sim.FlightPlanSpecifier{}, ErrCommandFormat
+	}
+	return parseFlightPlan(format, data, func(string, bool) bool { return false })
+}
+
+///////////////////////////////////////////////////////////////////////////
+// TP - Trial Plan Handlers
+
+func handleTrialPlanAltitude(ep *ERAMPane, ctx *panes.Context, alt int, trk *sim.Track) CommandStatus {
+	ep.trialPlan(ctx, trk, alt, "")
+	return CommandStatus{}
+}
+
+func handleTrialPlanDirect(ep *ERAMPane, ctx *panes.Context, fix string, trk *sim.Track) CommandStatus {
+	ep.trialPlan(ctx, trk, 0, fix)
+	return CommandStatus{}
+}
+
+// trialPlan probes the candidate clearance and reports whether it would
+// be conflict-free.
+func (ep *ERAMPane) trialPlan(ctx *panes.Context, trk *sim.Track, alt int, fix string) {
+	fp := trk.FlightPlan
+	desc := util.Select(fix != "", "DIRECT "+fix, fmt.Sprintf("ALT %03d", alt/100))
+	client := ctx.Client
+
+	client.TrialPlan(fp.ACID, alt, fix, func(conflicts []sim.ProbeConflict, err error) {
+		if err != nil {
+			ep.bigOutput.displayError(ep.currentPrefs(), err)
+			return
+		}
+
+		msg := fmt.Sprintf("TRIAL PLAN %s\n%s/%s", desc, fp.ACID, fp.CID)
+		if len(conflicts) == 0 {
+			msg += "\nNO CONFLICT"
+		}
+		for _, pc := range conflicts {
+			minutes, _ := formatProbeConflict(client.State.SimTime, &pc)
+			msg += fmt.Sprintf("\nCONFLICT %s %s MIN", pc.ACIDs[1], strings.TrimSpace(minutes))
+		}
+		ep.bigOutput.displaySuccess(ep.currentPrefs(), msg)
+	})
+}
+
 ///////////////////////////////////////////////////////////////////////////
 // MR - Map Request Handlers
 
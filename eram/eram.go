@@ -146,6 +146,9 @@ type ERAMPane struct {
 	crrRepoStart     time.Time                                    `json:"-"`
 	crrDragOffset    [2]float32                                   `json:"-"`
 
+	// Auxiliary view state (session), indexed by view title
+	viewFrames map[string]*viewFrame `json:"-"`
+
 	commandMode       CommandMode     `json:"-"`
 	drawRouteAircraft av.ADSBCallsign `json:"-"`
 	drawRoutePoints   []math.Point2LL `json:"-"`
@@ -334,6 +337,8 @@ func (ep *ERAMPane) Draw(ctx *panes.Context, cb *renderer.CommandBuffer) {
 	ep.drawClock(ctx, transforms, cb)
 	// Draw views
 	ep.drawCRRView(ctx, transforms, cb)
+	ep.drawACLView(ctx, transforms, cb)
+	ep.drawAuxiliaryViews(ctx, transforms, cb)
 	// Draw toolbar and menus on top of the scope
 	cb.SetScissorBounds(ctx.PaneExtent, ctx.Platform.FramebufferSize()[1]/ctx.Platform.DisplaySize()[1])
 	ep.drawtoolbar(ctx, transforms, cb)
@@ -458,6 +463,22 @@ func (ep *ERAMPane) ensurePrefSetForSim(ss client.SimState) {
 	if !ep.prefSet.Current.CRR.Visible {
 		ep.prefSet.Current.CRR.Visible = def.CRR.Visible
 	}
+	// Likewise for the auxiliary views
+	cur := &ep.prefSet.Current
+	for _, v := range []struct{ cur, def *ViewPrefs }{
+		{&cur.ACL, &def.ACL}, {&cur.AltimSet, &def.AltimSet}, {&cur.WXReport, &def.WXReport},
+		{&cur.HoldList, &def.HoldList}, {&cur.DeptList, &def.DeptList}, {&cur.InbndList, &def.InbndList},
+	} {
+		if v.cur.Lines == 0 {
+			v.cur.Lines = v.def.Lines
+		}
+		if v.cur.Bright == 0 {
+			v.cur.Bright = v.def.Bright
+		}
+		if v.cur.Position == ([2]float32{}) {
+			v.cur.Position = v.def.Position
+		}
+	}
 }
 
 // Custom text characters. Some of these are not for all fonts. Size 11 has everything.
@@ -641,6 +662,7 @@ func (ep *ERAMPane) processKeyboardInput(ctx *panes.Context) {
 				ep.repositionSmallOutput = false
 				ep.repositionClock = false
 				ep.crrReposition = false
+				ep.cancelViewRepositions()
 			} else {
 				if ep.commandMode == CommandModeDrawRoute {
 					ep.commandMode = CommandModeNone
@@ -55,6 +55,7 @@ var fpParseFuncs = map[string]fpEntryParseFunc{
 	"PLUS_PLUS_ALT_R": parseFpPlus2RequestedAltitude,
 	// "PLUS_SP2":        parseFpPlusSp2,
 	"RNAV":  parseFpRNAVToggle,
+	"ROUTE": parseFpRoute,
 	"RULES": parseFpFlightRules,
 	// "SP1":             parseFpSp1,
 	"TCP":          parseFpTCP,
@@ -363,6 +364,38 @@ func parseFpFixPair(s string, checkSp func(s string, primary bool) bool, spec *s
 	return true, nil
 }
 
+// parseFpRoute parses a route in ERAM format: fixes and airways separated
+// by periods, with two periods before a fix that is flown direct (e.g.,
+// KJFK..MERIT.J55.HFD..KBOS). An airport at the start or end of the route
+// is taken to be the departure or arrival airport.
+func parseFpRoute(s string, checkSp func(s string, primary bool) bool, spec *sim.FlightPlanSpecifier) (bool, error) {
+	if !strings.Contains(s, ".") || (s[0] == '.' && len(s) <= 2) {
+		// Not a route, though it may be flight rules (e.g., ".V").
+		return false, ErrCommandFormat
+	}
+
+	elems := slices.DeleteFunc(strings.Split(s, "."), func(e string) bool { return e == "" })
+	if len(elems) == 0 {
+		return true, ErrCommandFormat
+	}
+	isAirport := func(e string) bool {
+		_, ok := av.DB.Airports[e]
+		return len(e) == 4 && ok
+	}
+	if isAirport(elems[0]) {
+		spec.DepartureAirport.Set(elems[0])
+		elems = elems[1:]
+	}
+	if n := len(elems); n > 0 && isAirport(elems[n-1]) {
+		spec.ArrivalAirport.Set(elems[n-1])
+		elems = elems[:n-1]
+	}
+	if len(elems) > 0 {
+		spec.Route.Set(strings.Join(elems, " "))
+	}
+	return true, nil
+}
+
 func parseFpRNAVToggle(s string, checkSp func(s string, primary bool) bool, spec *sim.FlightPlanSpecifier) (bool, error) {
 	if s == "R" {
 		spec.RNAVToggle.Set(true)
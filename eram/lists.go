@@ -0,0 +1,218 @@
+// eram/lists.go
+// Copyright(c) 2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+// ERAM altimeter settings and weather report views and the hold,
+// departure, and inbound lists
+
+package eram
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/panes"
+	"github.com/mmp/vice/radar"
+	"github.com/mmp/vice/renderer"
+	"github.com/mmp/vice/sim"
+	"github.com/mmp/vice/util"
+)
+
+// Weather reports longer than this are wrapped onto continuation lines.
+const wxReportWidth = 60
+
+func (ep *ERAMPane) drawAuxiliaryViews(ctx *panes.Context, transforms radar.ScopeTransformations, cb *renderer.CommandBuffer) {
+	ep.drawAltimSetView(ctx, transforms, cb)
+	ep.drawWXReportView(ctx, transforms, cb)
+	ep.drawHoldListView(ctx, transforms, cb)
+	ep.drawDeptListView(ctx, transforms, cb)
+	ep.drawInbndListView(ctx, transforms, cb)
+}
+
+// weatherAirports returns the airports whose weather is reported in the
+// altimeter settings and weather report views: those listed in the
+// facility adaptation if any are, otherwise all of the scenario's
+// airports with a METAR.
+func weatherAirports(ctx *panes.Context) []string {
+	if len(ctx.FacilityAdaptation.Altimeters) > 0 {
+		return ctx.FacilityAdaptation.Altimeters
+	}
+	return util.FilterSlice(util.SortedMapKeys(ctx.Client.State.Airports), func(icao string) bool {
+		_, ok := ctx.Client.State.METAR[icao]
+		return ok
+	})
+}
+
+func formatAltitude(alt int) string {
+	if alt == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%03d", alt/100)
+}
+
+// drawAltimSetView draws the Altimeter Settings view, which lists each
+// airport's altimeter setting and the time of the report it came from.
+func (ep *ERAMPane) drawAltimSetView(ctx *panes.Context, transforms radar.ScopeTransformations, cb *renderer.CommandBuffer) {
+	ps := ep.currentPrefs()
+	if !ps.AltimSet.Visible {
+		return
+	}
+
+	var lines []viewLine
+	for _, ap := range weatherAirports(ctx) {
+		metar, ok := ctx.Client.State.METAR[ap]
+		if !ok {
+			lines = append(lines, textLine(fmt.Sprintf("%-4s  ---- ----", ap)))
+			continue
+		}
+		lines = append(lines, textLine(fmt.Sprintf("%-4s  %s %04d %s", ap, metar.Time.UTC().Format("1504"),
+			int(metar.Altimeter_inHg()*100+0.5), ctx.Client.State.ATISLetter[ap])))
+	}
+
+	ep.drawView(ctx, transforms, cb, "ALTIM SET", &ps.AltimSet, "ARPT  TIME ALTM ATIS", lines)
+}
+
+// drawWXReportView draws the Weather Report view with the METARs for the
+// adapted airports, each followed by the airport's current ATIS.
+func (ep *ERAMPane) drawWXReportView(ctx *panes.Context, transforms radar.ScopeTransformations, cb *renderer.CommandBuffer) {
+	ps := ep.currentPrefs()
+	if !ps.WXReport.Visible {
+		return
+	}
+
+	var lines []viewLine
+	for _, ap := range weatherAirports(ctx) {
+		metar, ok := ctx.Client.State.METAR[ap]
+		if !ok {
+			continue
+		}
+		lines = append(lines, wrapWXReport(metar.Raw)...)
+		if atis, ok := ctx.Client.State.ATIS[ap]; ok {
+			lines = append(lines, wrapWXReport("ATIS "+strings.ToUpper(atis.Text))...)
+		}
+	}
+
+	ep.drawView(ctx, transforms, cb, "WX REPORT", &ps.WXReport, "", lines)
+}
+
+// wrapWXReport breaks a long report into lines at spaces, indenting the
+// continuation lines.
+func wrapWXReport(report string) []viewLine {
+	var lines []viewLine
+	line := ""
+	for _, f := range strings.Fields(report) {
+		if line != "" && len(line)+1+len(f) > wxReportWidth {
+			lines = append(lines, textLine(line))
+			line = "  " + f
+		} else if line == "" {
+			line = f
+		} else {
+			line += " " + f
+		}
+	}
+	if line != "" {
+		lines = append(lines, textLine(line))
+	}
+	return lines
+}
+
+// drawHoldListView draws the Hold view, which lists the user's flights
+// that are holding, sorted by holding fix.
+func (ep *ERAMPane) drawHoldListView(ctx *panes.Context, transforms radar.ScopeTransformations, cb *renderer.CommandBuffer) {
+	ps := ep.currentPrefs()
+	if !ps.HoldList.Visible {
+		return
+	}
+
+	var holding []*sim.Track
+	for _, trk := range ctx.Client.State.Tracks {
+		if trk.HoldFix != "" && trk.IsAssociated() && ctx.UserControlsPosition(trk.FlightPlan.TrackingController) {
+			holding = append(holding, trk)
+		}
+	}
+	slices.SortFunc(holding, func(a, b *sim.Track) int {
+		if c := strings.Compare(a.HoldFix, b.HoldFix); c != 0 {
+			return c
+		}
+		return strings.Compare(string(a.FlightPlan.ACID), string(b.FlightPlan.ACID))
+	})
+
+	var lines []viewLine
+	for _, trk := range holding {
+		fp := trk.FlightPlan
+		lines = append(lines, textLine(fmt.Sprintf("%-5s %-7s %-5s %3s %3s", trk.HoldFix, fp.ACID, fp.AircraftType,
+			formatAltitude(int(trk.TransponderAltitude+50)), formatAltitude(fp.AssignedAltitude))))
+	}
+
+	ep.drawView(ctx, transforms, cb, "HOLD", &ps.HoldList, "FIX   ACID    TYPE  ALT ASG", lines)
+}
+
+// drawDeptListView draws the Departure List, which lists the flight plans
+// for the user's departures that have not yet been acquired, similarly
+// to the STARS flight plan list.
+func (ep *ERAMPane) drawDeptListView(ctx *panes.Context, transforms radar.ScopeTransformations, cb *renderer.CommandBuffer) {
+	ps := ep.currentPrefs()
+	if !ps.DeptList.Visible {
+		return
+	}
+
+	plans := util.FilterSlice(ctx.Client.State.UnassociatedFlightPlans, func(fp *sim.NASFlightPlan) bool {
+		if fp.TypeOfFlight != av.FlightTypeDeparture || !fp.Location.IsZero() {
+			return false
+		}
+		return ctx.UserOwnsFlightPlan(fp) || ctx.UserControlsPosition(fp.TrackingController) ||
+			ctx.UserControlsPosition(fp.InboundHandoffController)
+	})
+	slices.SortFunc(plans, func(a, b *sim.NASFlightPlan) int {
+		return strings.Compare(string(a.ACID), string(b.ACID))
+	})
+
+	var lines []viewLine
+	for _, fp := range plans {
+		lines = append(lines, textLine(fmt.Sprintf("%-7s %-5s %s %3s %-4s %s", fp.ACID, fp.AircraftType,
+			fp.AssignedSquawk, formatAltitude(fp.RequestedAltitude), fp.EntryFix, fp.ExitFix)))
+	}
+
+	ep.drawView(ctx, transforms, cb, "DEPT LIST", &ps.DeptList, "ACID    TYPE  BCN  ALT DEP  EXIT", lines)
+}
+
+// drawInbndListView draws the Inbound List: flights being handed off to
+// the user, marked with an "H", and flight plans for flights that the
+// user will receive that have not yet been acquired.
+func (ep *ERAMPane) drawInbndListView(ctx *panes.Context, transforms radar.ScopeTransformations, cb *renderer.CommandBuffer) {
+	ps := ep.currentPrefs()
+	if !ps.InbndList.Visible {
+		return
+	}
+
+	type inbound struct {
+		fp      *sim.NASFlightPlan
+		alt     int
+		handoff bool
+	}
+	var entries []inbound
+	for _, trk := range ctx.Client.State.Tracks {
+		if ctx.IsHandoffToUser(trk) {
+			entries = append(entries, inbound{fp: trk.FlightPlan, alt: int(trk.TransponderAltitude + 50), handoff: true})
+		}
+	}
+	for _, fp := range ctx.Client.State.UnassociatedFlightPlans {
+		if fp.TypeOfFlight != av.FlightTypeDeparture && fp.Location.IsZero() &&
+			ctx.UserControlsPosition(fp.InboundHandoffController) {
+			entries = append(entries, inbound{fp: fp, alt: fp.AssignedAltitude})
+		}
+	}
+	slices.SortFunc(entries, func(a, b inbound) int {
+		return strings.Compare(string(a.fp.ACID), string(b.fp.ACID))
+	})
+
+	var lines []viewLine
+	for _, e := range entries {
+		lines = append(lines, textLine(fmt.Sprintf("%s %-7s %-5s %3s %-5s %s", util.Select(e.handoff, "H", " "),
+			e.fp.ACID, e.fp.AircraftType, formatAltitude(e.alt), e.fp.EntryFix, e.fp.ArrivalAirport)))
+	}
+
+	ep.drawView(ctx, transforms, cb, "INBND LIST", &ps.InbndList, "  ACID    TYPE  ALT ENTRY DEST", lines)
+}
@@ -59,6 +59,16 @@ type Preferences struct {
 		Position      [2]float32
 		DisplayFixes  bool // ATC TOOLS overlay of CRR fixes
 	}
+
+	// Aircraft List (ACL) view, which shows the conflict probe results
+	ACL ViewPrefs
+
+	// Auxiliary views
+	AltimSet  ViewPrefs
+	WXReport  ViewPrefs
+	HoldList  ViewPrefs
+	DeptList  ViewPrefs
+	InbndList ViewPrefs
 }
 
 const numSavedPreferenceSets = 10
@@ -207,6 +217,13 @@ func makeDefaultPreferences() *Preferences {
 	prefs.CRR.Position = [2]float32{10, 600}
 	prefs.CRR.DisplayFixes = false
 
+	prefs.ACL = ViewPrefs{Lines: 20, Bright: 90, Position: [2]float32{10, 350}}
+	prefs.AltimSet = ViewPrefs{Lines: 10, Bright: 90, Position: [2]float32{300, 600}}
+	prefs.WXReport = ViewPrefs{Lines: 10, Bright: 90, Position: [2]float32{300, 400}}
+	prefs.HoldList = ViewPrefs{Lines: 10, Bright: 90, Position: [2]float32{650, 600}}
+	prefs.DeptList = ViewPrefs{Lines: 15, Bright: 90, Position: [2]float32{650, 400}}
+	prefs.InbndList = ViewPrefs{Lines: 15, Bright: 90, Position: [2]float32{650, 250}}
+
 	prefs.HistoryLength = 5
 
 	return &prefs
@@ -583,8 +583,9 @@ func (ep *ERAMPane) drawToolbarMenu(ctx *panes.Context, scale float32) {
 		ep.buttonVerticalOffset(ctx)
 		toolbarDrawState.buttonCursor[1] += buttonSize(buttonFull, scale)[1] + 3
 		p0 := toolbarDrawState.buttonCursor
-		if ep.drawToolbarFullButton(ctx, "ALTIM\nSET", 0, scale, false, false) {
-			// handle ALTIM SET
+		ps := ep.currentPrefs()
+		if ep.drawToolbarFullButton(ctx, "ALTIM\nSET", 0, scale, ps.AltimSet.Visible, false) {
+			ps.AltimSet.Visible = !ps.AltimSet.Visible
 		}
 
 		if ep.drawToolbarFullButton(ctx, "AUTO HO\nINHIB", 0, scale, false, false) {
@@ -614,7 +615,6 @@ func (ep *ERAMPane) drawToolbarMenu(ctx *panes.Context, scale float32) {
 		p1 := oppositeHorizontal(toolbarDrawState.buttonCursor, buttonSize(buttonFull, scale))
 		p1 = oppositeHorizontal(p1, buttonSize(buttonTearoff, scale))
 		// p1 := toolbarDrawState.buttonCursor
-		ps := ep.currentPrefs()
 		crrActive := ps.CRR.Visible || ep.crrMenuOpen
 		if ep.drawToolbarFullButton(ctx, "CRR", 0, scale, crrActive, false) {
 			// Toggle visibility; when enabling, default to LIST mode and keep menu closed.
@@ -629,8 +629,8 @@ func (ep *ERAMPane) drawToolbarMenu(ctx *panes.Context, scale float32) {
 		}
 
 		// toolbarDrawState.offsetBottom = true
-		if ep.drawToolbarFullButton(ctx, "DEPT\nLIST", 0, scale, false, true) {
-			// handle DEPT LIST
+		if ep.drawToolbarFullButton(ctx, "DEPT\nLIST", 0, scale, ps.DeptList.Visible, true) {
+			ps.DeptList.Visible = !ps.DeptList.Visible
 		}
 
 		if ep.drawToolbarFullButton(ctx, "FLIGHT\nEVENT", 0, scale, false, false) {
@@ -639,11 +639,11 @@ func (ep *ERAMPane) drawToolbarMenu(ctx *panes.Context, scale float32) {
 		if ep.drawToolbarFullButton(ctx, "GROUP\nSUP", 0, scale, false, false) {
 			// handle GROUP SUP
 		}
-		if ep.drawToolbarFullButton(ctx, "HOLD\nLIST", 0, scale, false, false) {
-			// handle HOLD LIST
+		if ep.drawToolbarFullButton(ctx, "HOLD\nLIST", 0, scale, ps.HoldList.Visible, false) {
+			ps.HoldList.Visible = !ps.HoldList.Visible
 		}
-		if ep.drawToolbarFullButton(ctx, "INBND\nLIST", 0, scale, false, false) {
-			// handle INBND LIST
+		if ep.drawToolbarFullButton(ctx, "INBND\nLIST", 0, scale, ps.InbndList.Visible, false) {
+			ps.InbndList.Visible = !ps.InbndList.Visible
 		}
 		if ep.drawToolbarFullButton(ctx, "MRP\nLIST", 0, scale, false, false) {
 			// handle MRP LIST
@@ -654,8 +654,11 @@ func (ep *ERAMPane) drawToolbarMenu(ctx *panes.Context, scale float32) {
 		if ep.drawToolbarFullButton(ctx, "UA", 0, scale, false, false) {
 			// handle UA
 		}
-		if ep.drawToolbarFullButton(ctx, "WX\nREPORT", 0, scale, false, false) {
-			// handle WX REPORT
+		if ep.drawToolbarFullButton(ctx, "WX\nREPORT", 0, scale, ps.WXReport.Visible, false) {
+			ps.WXReport.Visible = !ps.WXReport.Visible
+		}
+		if ep.drawToolbarFullButton(ctx, "ACFT\nLIST", 0, scale, ps.ACL.Visible, false) {
+			ps.ACL.Visible = !ps.ACL.Visible
 		}
 		p2 := oppositeSide(toolbarDrawState.buttonCursor, buttonSize(buttonFull, scale))
 		p2[0] = p1[0]
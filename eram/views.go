@@ -0,0 +1,245 @@
+// eram/views.go
+// Copyright(c) 2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+// Common frame for the ERAM auxiliary views (aircraft list, altimeter
+// settings, weather reports, and the hold, departure, and inbound lists)
+
+package eram
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/mmp/vice/math"
+	"github.com/mmp/vice/panes"
+	"github.com/mmp/vice/platform"
+	"github.com/mmp/vice/radar"
+	"github.com/mmp/vice/renderer"
+)
+
+// ViewPrefs holds the preferences for one of the auxiliary views.
+type ViewPrefs struct {
+	Visible  bool
+	Position [2]float32
+	Lines    int // Maximum number of entries shown
+	Bright   int
+}
+
+// viewFrame holds the session state for a view's title bar: whether its
+// menu is open and whether it is being repositioned.
+type viewFrame struct {
+	menuOpen   bool
+	reposition bool
+	repoStart  time.Time
+	dragOffset [2]float32
+}
+
+// viewSpan is a run of text in a line of a view. If color is nil, the
+// view's text color is used.
+type viewSpan struct {
+	text  string
+	color *renderer.RGB
+}
+
+// viewLine is a line of a view; its spans are drawn left to right.
+type viewLine []viewSpan
+
+func textLine(s string) viewLine {
+	return viewLine{{text: s}}
+}
+
+func (l viewLine) len() int {
+	n := 0
+	for _, sp := range l {
+		n += len(sp.text)
+	}
+	return n
+}
+
+func (ep *ERAMPane) getViewFrame(title string) *viewFrame {
+	if ep.viewFrames == nil {
+		ep.viewFrames = make(map[string]*viewFrame)
+	}
+	vf, ok := ep.viewFrames[title]
+	if !ok {
+		vf = &viewFrame{}
+		ep.viewFrames[title] = vf
+	}
+	return vf
+}
+
+// cancelViewRepositions stops any in-progress view moves.
+func (ep *ERAMPane) cancelViewRepositions() {
+	for _, vf := range ep.viewFrames {
+		vf.reposition = false
+	}
+}
+
+// drawView draws one of the auxiliary views: a title bar with the menu
+// button, the title (which is dragged to move the view), and the minimize
+// button, followed by an optional column header and the view's lines, of
+// which at most vp.Lines are shown. The view's menu allows the number of
+// lines and the brightness to be changed.
+func (ep *ERAMPane) drawView(ctx *panes.Context, transforms radar.ScopeTransformations, cb *renderer.CommandBuffer,
+	title string, vp *ViewPrefs, header string, lines []viewLine) {
+	if !vp.Visible {
+		return
+	}
+	vf := ep.getViewFrame(title)
+	ps := ep.currentPrefs()
+
+	font := ep.ERAMFont(2)
+	textColor := radar.Brightness(vp.Bright).ScaleRGB(renderer.RGB{R: .85, G: .85, B: .85})
+	style := renderer.TextStyle{Font: font, Color: textColor}
+
+	cw, ch := font.BoundText("0", 0)
+	charW, lineH := float32(cw), float32(ch+2)
+
+	maxLines := int(math.Clamp(float32(vp.Lines), 1, 100))
+	more := max(len(lines)-maxLines, 0)
+	lines = lines[:min(len(lines), maxLines)]
+	if more > 0 {
+		lines = append(lines, textLine("MORE "+strconv.Itoa(more)))
+	}
+	if header != "" {
+		lines = append([]viewLine{textLine(header)}, lines...)
+	}
+
+	// Size the view to fit the title and its buttons as well as the
+	// longest line.
+	cols := len(title) + 6
+	for _, l := range lines {
+		cols = max(cols, l.len())
+	}
+	width := charW*float32(cols) + 8
+	titleH := lineH + 4
+	height := titleH + lineH*float32(max(len(lines), 1)) + 6
+
+	p0 := vp.Position
+	p1 := math.Add2f(p0, [2]float32{width, 0})
+	p2 := math.Add2f(p1, [2]float32{0, -height})
+	p3 := math.Add2f(p0, [2]float32{0, -height})
+
+	trid := renderer.GetColoredTrianglesDrawBuilder()
+	defer renderer.ReturnColoredTrianglesDrawBuilder(trid)
+	ld := renderer.GetColoredLinesDrawBuilder()
+	defer renderer.ReturnColoredLinesDrawBuilder(ld)
+	td := renderer.GetTextDrawBuilder()
+	defer renderer.ReturnTextDrawBuilder(td)
+
+	trid.AddQuad(p0, p1, p2, p3, renderer.RGB{})
+	borderColor := ps.Brightness.Border.ScaleRGB(renderer.RGB{R: .914, G: .914, B: .914})
+	ld.AddLineLoop(borderColor, [][2]float32{p0, p1, p2, p3})
+
+	// Title bar: M button, title, and minimize button
+	titleBottom := p0[1] - titleH
+	trid.AddQuad(p0, p1, [2]float32{p1[0], titleBottom}, [2]float32{p0[0], titleBottom}, renderer.RGB{R: .6, G: .6, B: .6})
+	mRect := math.Extent2D{P0: [2]float32{p0[0], titleBottom}, P1: [2]float32{p0[0] + charW + 4, p0[1]}}
+	minRect := math.Extent2D{P0: [2]float32{p1[0] - charW - 4, titleBottom}, P1: p1}
+	titleRect := math.Extent2D{P0: [2]float32{mRect.P1[0], titleBottom}, P1: [2]float32{minRect.P0[0], p0[1]}}
+
+	mouse := ctx.Mouse
+	for _, r := range []math.Extent2D{mRect, minRect, titleRect} {
+		color := toolbarOutlineColor
+		if mouse != nil && r.Inside(mouse.Pos) {
+			color = toolbarHoveredOutlineColor
+		}
+		ld.AddLineLoop(color, [][2]float32{r.P0, {r.P1[0], r.P0[1]}, r.P1, {r.P0[0], r.P1[1]}})
+	}
+	titleStyle := renderer.TextStyle{Font: font, Color: ps.Brightness.Text.ScaleRGB(renderer.RGB{R: .85, G: .85, B: .85})}
+	tw, _ := font.BoundText(title, 0)
+	td.AddText(title, [2]float32{p0[0] + (width-float32(tw))/2, p0[1] - 2}, titleStyle)
+	td.AddText("M", [2]float32{mRect.P0[0] + 2, p0[1] - 2}, titleStyle)
+	td.AddText("-", [2]float32{minRect.P0[0] + 2, p0[1] - 2}, titleStyle)
+
+	if ep.mousePrimaryClicked(mouse) || ep.mouseTertiaryClicked(mouse) {
+		switch {
+		case mRect.Inside(mouse.Pos):
+			vf.menuOpen = !vf.menuOpen
+			// Consume the click so that the menu doesn't see it.
+			mouse.Clicked = [platform.MouseButtonCount]bool{}
+		case minRect.Inside(mouse.Pos):
+			vp.Visible = false
+			vf.menuOpen = false
+		case titleRect.Inside(mouse.Pos) && !vf.reposition:
+			vf.reposition = true
+			vf.repoStart = time.Now()
+			vf.dragOffset = math.Sub2f(mouse.Pos, p0)
+			ctx.Platform.StartCaptureMouse(ctx.PaneExtent)
+		case vf.reposition && time.Since(vf.repoStart) > 100*time.Millisecond:
+			vp.Position = math.Sub2f(mouse.Pos, vf.dragOffset)
+			vf.reposition = false
+			ctx.Platform.EndCaptureMouse()
+		}
+	}
+	if vf.reposition && mouse != nil {
+		q0 := math.Sub2f(mouse.Pos, vf.dragOffset)
+		q1 := math.Add2f(q0, [2]float32{width, 0})
+		q2 := math.Add2f(q1, [2]float32{0, -height})
+		q3 := math.Add2f(q0, [2]float32{0, -height})
+		ld.AddLineLoop(toolbarHoveredOutlineColor, [][2]float32{q0, q1, q2, q3})
+	}
+
+	pt := [2]float32{p0[0] + 4, titleBottom - 2}
+	for _, l := range lines {
+		var text []string
+		var styles []renderer.TextStyle
+		for _, sp := range l {
+			text = append(text, sp.text)
+			if sp.color != nil {
+				styles = append(styles, renderer.TextStyle{Font: font, Color: radar.Brightness(vp.Bright).ScaleRGB(*sp.color)})
+			} else {
+				styles = append(styles, style)
+			}
+		}
+		td.AddTextMulti(text, pt, styles)
+		pt[1] -= lineH
+	}
+
+	transforms.LoadWindowViewingMatrices(cb)
+	trid.GenerateCommands(cb)
+	ld.GenerateCommands(cb)
+	td.GenerateCommands(cb)
+
+	if vf.menuOpen {
+		ep.drawViewMenu(ctx, transforms, cb, title, vp, vf, p1)
+	}
+}
+
+// drawViewMenu draws a view's menu, which is used to set its number of
+// lines and brightness.
+func (ep *ERAMPane) drawViewMenu(ctx *panes.Context, transforms radar.ScopeTransformations, cb *renderer.CommandBuffer,
+	title string, vp *ViewPrefs, vf *viewFrame, origin [2]float32) {
+	textColor := renderer.RGB{R: 1, G: 1, B: 1}
+	greenBg := CRRGreen.BrightRGB(radar.Brightness(100))
+
+	rows := []ERAMMenuItem{
+		{Label: "LINES " + strconv.Itoa(vp.Lines), BgColor: greenBg, Color: textColor, OnClick: func(ct ERAMMenuClickType) bool {
+			if ct == MenuClickPrimary {
+				vp.Lines = int(math.Clamp(float32(vp.Lines-1), 1, 100))
+			} else {
+				vp.Lines = int(math.Clamp(float32(vp.Lines+1), 1, 100))
+			}
+			return false
+		}},
+		{Label: "BRIGHT " + strconv.Itoa(vp.Bright), BgColor: greenBg, Color: textColor, OnClick: func(ct ERAMMenuClickType) bool {
+			if ct == MenuClickPrimary {
+				vp.Bright = int(math.Clamp(float32(vp.Bright-1), 0, 100))
+			} else {
+				vp.Bright = int(math.Clamp(float32(vp.Bright+1), 0, 100))
+			}
+			return false
+		}},
+	}
+
+	ep.DrawERAMMenu(ctx, transforms, cb, origin, ERAMMenuConfig{
+		Title:                 title,
+		OnClose:               func() { vf.menuOpen = false },
+		Width:                 120,
+		ShowBorder:            true,
+		BorderColor:           renderer.RGB{R: 1, G: 1, B: 1},
+		DismissOnClickOutside: true,
+		Rows:                  rows,
+	})
+}
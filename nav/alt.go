@@ -237,6 +237,11 @@ func mbToPressureAltitude(mb float32) float32 {
 }
 
 func (nav *Nav) TargetAltitude() (float32, float32) {
+	// A TCAS RA overrides everything until clear of conflict.
+	if nav.TCAS != nil {
+		return nav.TCAS.Altitude, tcasRARate
+	}
+
 	if nav.Airwork != nil {
 		return nav.Airwork.TargetAltitude()
 	}
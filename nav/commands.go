@@ -26,7 +26,9 @@ func (nav *Nav) GoAroundWithProcedure(altitude float32, runwayEndWP av.Waypoint)
 }
 
 func (nav *Nav) AssignAltitude(alt float32, afterSpeed bool) av.CommandIntent {
-	if alt > nav.Perf.Ceiling {
+	if nav.TCAS != nil {
+		return av.MakeUnableIntent("unable, TCAS resolution advisory")
+	} else if alt > nav.Perf.Ceiling {
 		return av.MakeUnableIntent("unable. That altitude is above our ceiling.")
 	}
 
@@ -688,6 +690,18 @@ func (nav *Nav) DistanceAlongRoute(fix string) (float32, error) {
 	}
 }
 
+// DeviateForWeather has the aircraft leave its route and fly the given
+// heading to avoid weather until EndDeviation is called.
+func (nav *Nav) DeviateForWeather(hdg float32) {
+	nav.Heading.Deviation = &hdg
+}
+
+// EndDeviation ends a weather deviation; the aircraft then proceeds
+// direct to the next fix on its route.
+func (nav *Nav) EndDeviation() {
+	nav.Heading.Deviation = nil
+}
+
 func (nav *Nav) ResumeOwnNavigation() av.CommandIntent {
 	if nav.Heading.Assigned == nil {
 		// This is a weird response but keeping the original behavior
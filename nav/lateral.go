@@ -187,6 +187,8 @@ func (nav *Nav) TargetHeading(callsign string, wxs wx.Sample, simTime time.Time)
 		if nav.Heading.Turn != nil {
 			turn = *nav.Heading.Turn
 		}
+	} else if nav.Heading.Deviation != nil {
+		heading = *nav.Heading.Deviation
 	} else if arc := nav.Heading.Arc; arc != nil && nav.Heading.JoiningArc {
 		heading = nav.Heading.Arc.InitialHeading
 		if math.HeadingDifference(nav.FlightState.Heading, heading) < 1 {
@@ -45,6 +45,7 @@ type Nav struct {
 	Heading     NavHeading
 	Approach    NavApproach
 	Airwork     *NavAirwork
+	TCAS        *NavTCAS
 	Prespawn    bool
 
 	FixAssignments map[string]NavFixAssignment
@@ -185,6 +186,9 @@ type NavHeading struct {
 	RacetrackPT  *FlyRacetrackPT
 	Standard45PT *FlyStandard45PT
 	Hold         *FlyHold
+	// Heading flown while deviating around weather; any new heading or
+	// route clearance ends the deviation.
+	Deviation *float32
 }
 
 type NavApproach struct {
@@ -242,10 +246,11 @@ const (
 )
 
 func MakeArrivalNav(callsign av.ADSBCallsign, arr *av.Arrival, fp av.FlightPlan, perf av.AircraftPerformance,
-	nmPerLongitude float32, magneticVariation float32, model *wx.Model, simTime time.Time, lg *log.Logger) *Nav {
+	nmPerLongitude float32, magneticVariation float32, r *rand.Rand, model *wx.Model, simTime time.Time,
+	lg *log.Logger) *Nav {
 	randomizeAltitudeRange := fp.Rules == av.FlightRulesVFR
 	if nav := makeNav(callsign, fp, perf, arr.Waypoints, randomizeAltitudeRange, nmPerLongitude,
-		magneticVariation, model, simTime, lg); nav != nil {
+		magneticVariation, r, model, simTime, lg); nav != nil {
 		spd := arr.SpeedRestriction
 		nav.Speed.Restriction = util.Select(spd != 0, &spd, nil)
 		if arr.AssignedAltitude > 0 {
@@ -270,9 +275,10 @@ func MakeArrivalNav(callsign av.ADSBCallsign, arr *av.Arrival, fp av.FlightPlan,
 
 func MakeDepartureNav(callsign av.ADSBCallsign, fp av.FlightPlan, perf av.AircraftPerformance,
 	assignedAlt, clearedAlt, speedRestriction int, wp []av.Waypoint, randomizeAltitudeRange bool,
-	nmPerLongitude float32, magneticVariation float32, model *wx.Model, simTime time.Time, lg *log.Logger) *Nav {
+	nmPerLongitude float32, magneticVariation float32, r *rand.Rand, model *wx.Model, simTime time.Time,
+	lg *log.Logger) *Nav {
 	if nav := makeNav(callsign, fp, perf, wp, randomizeAltitudeRange, nmPerLongitude, magneticVariation,
-		model, simTime, lg); nav != nil {
+		r, model, simTime, lg); nav != nil {
 		if assignedAlt != 0 {
 			alt := float32(min(assignedAlt, fp.Altitude))
 			nav.Altitude.Assigned = &alt
@@ -292,10 +298,11 @@ func MakeDepartureNav(callsign av.ADSBCallsign, fp av.FlightPlan, perf av.Aircra
 }
 
 func MakeOverflightNav(callsign av.ADSBCallsign, of *av.Overflight, fp av.FlightPlan, perf av.AircraftPerformance,
-	nmPerLongitude float32, magneticVariation float32, model *wx.Model, simTime time.Time, lg *log.Logger) *Nav {
+	nmPerLongitude float32, magneticVariation float32, r *rand.Rand, model *wx.Model, simTime time.Time,
+	lg *log.Logger) *Nav {
 	randomizeAltitudeRange := fp.Rules == av.FlightRulesVFR
 	if nav := makeNav(callsign, fp, perf, of.Waypoints, randomizeAltitudeRange, nmPerLongitude,
-		magneticVariation, model, simTime, lg); nav != nil {
+		magneticVariation, r, model, simTime, lg); nav != nil {
 		spd := of.SpeedRestriction
 		nav.Speed.Restriction = util.Select(spd != 0, &spd, nil)
 		if of.AssignedAltitude > 0 {
@@ -319,14 +326,17 @@ func MakeOverflightNav(callsign av.ADSBCallsign, of *av.Overflight, fp av.Flight
 	return nil
 }
 
+// makeNav returns a Nav that uses the provided random number generator,
+// both for its own randomization of the route and subsequently; callers
+// should seed it deterministically so that runs are repeatable.
 func makeNav(callsign av.ADSBCallsign, fp av.FlightPlan, perf av.AircraftPerformance, wp []av.Waypoint,
-	randomizeAltitudeRange bool, nmPerLongitude float32, magneticVariation float32, model *wx.Model,
-	simTime time.Time, lg *log.Logger) *Nav {
+	randomizeAltitudeRange bool, nmPerLongitude float32, magneticVariation float32, r *rand.Rand,
+	model *wx.Model, simTime time.Time, lg *log.Logger) *Nav {
 	nav := &Nav{
 		Perf:           perf,
 		FinalAltitude:  float32(fp.Altitude),
 		FixAssignments: make(map[string]NavFixAssignment),
-		Rand:           rand.Make(),
+		Rand:           r,
 	}
 
 	// Copy the provided waypoints so that any local modifications we make don't pollute the
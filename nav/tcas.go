@@ -0,0 +1,186 @@
+// nav/tcas.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package nav
+
+import (
+	"github.com/mmp/vice/math"
+	"github.com/mmp/vice/util"
+)
+
+// TCASAdvisory is the level of advisory that TCAS issues for an intruder.
+type TCASAdvisory int
+
+const (
+	TCASNone TCASAdvisory = iota
+	TCASTrafficAdvisory
+	TCASResolutionAdvisory
+)
+
+// NavTCAS records a TCAS resolution advisory that the aircraft is
+// following; while it is set, it overrides all other altitude targets.
+type NavTCAS struct {
+	Climb bool // otherwise descend
+	// Altitude the aircraft climbs or descends to; it's set when the RA
+	// is issued so that the aircraft levels off there if the RA
+	// continues.
+	Altitude float32
+}
+
+// TCASThreat summarizes the threat that an intruder poses, as evaluated
+// by CheckTCAS.
+type TCASThreat struct {
+	Advisory TCASAdvisory
+	// For RAs, whether climbing (vs. descending) resolves the conflict.
+	Climb bool
+	// Whether the range to the intruder is decreasing.
+	Closing bool
+}
+
+const (
+	// Vertical rate flown in response to a climb or descend RA, ft/minute.
+	tcasRARate = 1500
+	// Altitude change flown in response to a climb or descend RA, feet.
+	tcasRAAltitudeChange = 1000
+	// Time it takes the pilot to respond to an RA, in seconds.
+	tcasRAResponseTime = 5
+	// Descend RAs are inhibited below this height above ground.
+	tcasDescendInhibitAGL = 1100
+)
+
+type tcasSensitivity struct {
+	TauTA, TauRA   float32 // seconds
+	DMODTA, DMODRA float32 // nm
+	ZTHRTA, ZTHRRA float32 // feet
+	ALIM           float32 // feet
+	RAInhibited    bool
+}
+
+// tcasSensitivity returns the TCAS II (v7.1) sensitivity level
+// thresholds for the aircraft's current altitude.
+func (fs *FlightState) tcasSensitivity() tcasSensitivity {
+	agl, alt := fs.approximateAGL(), fs.Altitude
+	switch {
+	case agl < 1000:
+		return tcasSensitivity{TauTA: 20, DMODTA: 0.30, ZTHRTA: 850, RAInhibited: true}
+	case agl < 2350:
+		return tcasSensitivity{TauTA: 25, DMODTA: 0.33, ZTHRTA: 850, TauRA: 15, DMODRA: 0.20, ZTHRRA: 600, ALIM: 300}
+	case alt < 5000:
+		return tcasSensitivity{TauTA: 30, DMODTA: 0.48, ZTHRTA: 850, TauRA: 20, DMODRA: 0.35, ZTHRRA: 600, ALIM: 300}
+	case alt < 10000:
+		return tcasSensitivity{TauTA: 40, DMODTA: 0.75, ZTHRTA: 850, TauRA: 25, DMODRA: 0.55, ZTHRRA: 600, ALIM: 350}
+	case alt < 20000:
+		return tcasSensitivity{TauTA: 45, DMODTA: 1.0, ZTHRTA: 850, TauRA: 30, DMODRA: 0.80, ZTHRRA: 600, ALIM: 400}
+	case alt < 42000:
+		return tcasSensitivity{TauTA: 48, DMODTA: 1.3, ZTHRTA: 850, TauRA: 35, DMODRA: 1.1, ZTHRRA: 700, ALIM: 600}
+	default:
+		return tcasSensitivity{TauTA: 48, DMODTA: 1.3, ZTHRTA: 1200, TauRA: 35, DMODRA: 1.1, ZTHRRA: 800, ALIM: 700}
+	}
+}
+
+// approximateAGL returns the aircraft's height above the elevation of the
+// closer of its departure and arrival airports; we don't have terrain
+// elevation to do better.
+func (fs *FlightState) approximateAGL() float32 {
+	elev := fs.ArrivalAirportElevation
+	if !fs.DepartureAirportLocation.IsZero() && (fs.ArrivalAirportLocation.IsZero() ||
+		math.NMDistance2LL(fs.Position, fs.DepartureAirportLocation) < math.NMDistance2LL(fs.Position, fs.ArrivalAirportLocation)) {
+		elev = fs.DepartureAirportElevation
+	}
+	return fs.Altitude - elev
+}
+
+// groundVelocity returns the aircraft's velocity in nm per second,
+// ignoring the effect of wind on its track.
+func (fs *FlightState) groundVelocity() [2]float32 {
+	hdg := fs.Heading - fs.MagneticVariation
+	return math.Scale2f(math.SinCos(math.Radians(hdg)), fs.GS/3600)
+}
+
+// CheckTCAS evaluates the threat that the intruder poses to the own
+// aircraft using a simplified version of the TCAS II collision avoidance
+// logic: the range test uses modified tau and the vertical test uses the
+// current altitude separation and the vertical time to co-altitude. An
+// RA is only issued if the projected vertical miss distance at the
+// closest point of approach is less than ALIM.
+func CheckTCAS(own, intruder *FlightState) TCASThreat {
+	sl := own.tcasSensitivity()
+
+	p := math.Sub2f(math.LL2NM(intruder.Position, own.NmPerLongitude), math.LL2NM(own.Position, own.NmPerLongitude))
+	v := math.Sub2f(intruder.groundVelocity(), own.groundVelocity())
+	r := math.Length2f(p)
+	var rdot float32 // nm/s; negative if closing
+	if r > 0 {
+		rdot = math.Dot(p, v) / r
+	}
+
+	h := intruder.Altitude - own.Altitude
+	hdot := (intruder.AltitudeRate - own.AltitudeRate) / 60 // ft/s
+
+	threat := TCASThreat{Closing: rdot < 0}
+
+	isThreat := func(tau, dmod, zthr float32) bool {
+		if r >= dmod && (rdot >= 0 || (dmod*dmod-r*r)/(r*rdot) >= tau) {
+			return false
+		}
+		return math.Abs(h) < zthr || (h*hdot < 0 && -h/hdot < tau)
+	}
+
+	if !isThreat(sl.TauTA, sl.DMODTA, sl.ZTHRTA) {
+		return threat
+	}
+	threat.Advisory = TCASTrafficAdvisory
+
+	if sl.RAInhibited || !isThreat(sl.TauRA, sl.DMODRA, sl.ZTHRRA) {
+		return threat
+	}
+
+	// Project altitudes to the closest point of approach.
+	var t float32
+	if rdot < 0 {
+		t = min(-r/rdot, sl.TauRA)
+	}
+	intruderAlt := intruder.Altitude + intruder.AltitudeRate/60*t
+	ownAlt := func(rate float32) float32 {
+		// Continue at the current rate until the pilot responds.
+		dt := min(t, tcasRAResponseTime)
+		return own.Altitude + own.AltitudeRate/60*dt + rate/60*(t-dt)
+	}
+
+	if math.Abs(ownAlt(own.AltitudeRate)-intruderAlt) >= sl.ALIM {
+		// Sufficient vertical separation as things are.
+		return threat
+	}
+	threat.Advisory = TCASResolutionAdvisory
+
+	// Prefer the sense that doesn't cross the intruder's altitude if it
+	// gives ALIM; otherwise take the one that gives more separation.
+	climbSep, descendSep := ownAlt(tcasRARate)-intruderAlt, intruderAlt-ownAlt(-tcasRARate)
+	threat.Climb = own.Altitude >= intruder.Altitude
+	if util.Select(threat.Climb, climbSep, descendSep) < sl.ALIM {
+		threat.Climb = climbSep > descendSep
+	}
+	if !threat.Climb && own.approximateAGL() < tcasDescendInhibitAGL {
+		threat.Climb = true
+	}
+
+	return threat
+}
+
+// FollowRA has the aircraft start to follow a TCAS resolution advisory,
+// climbing or descending tcasRAAltitudeChange feet from its current
+// altitude.
+func (nav *Nav) FollowRA(climb bool) {
+	alt := nav.FlightState.Altitude
+	nav.TCAS = &NavTCAS{
+		Climb:    climb,
+		Altitude: util.Select(climb, alt+tcasRAAltitudeChange, alt-tcasRAAltitudeChange),
+	}
+}
+
+// ClearOfConflict ends the aircraft's TCAS resolution advisory; it then
+// returns to whatever altitude it was otherwise flying to.
+func (nav *Nav) ClearOfConflict() {
+	nav.TCAS = nil
+}
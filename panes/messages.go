@@ -212,6 +212,20 @@ func (mp *MessagesPane) processEvents(c *client.ControlClient, p platform.Platfo
 				mp.shouldAutoScroll = true
 			}
 
+		case sim.LossOfSeparationEvent:
+			if l := event.SeparationLoss; l != nil {
+				if !c.State.UserControlsPosition(l.TCPs[0]) && !c.State.UserControlsPosition(l.TCPs[1]) &&
+					!c.State.TCWIsPrivileged(c.State.UserTCW) {
+					break
+				}
+				mp.messages = append(mp.messages,
+					Message{
+						contents: event.WrittenText,
+						error:    true,
+					})
+				mp.shouldAutoScroll = true
+			}
+
 		case sim.ErrorMessageEvent:
 			mp.messages = append(mp.messages,
 				Message{
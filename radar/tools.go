@@ -30,12 +30,25 @@ type ScopeTransformations struct {
 // angle.
 func GetScopeTransformations(paneExtent math.Extent2D, magneticVariation float32, nmPerLongitude float32,
 	center math.Point2LL, rangenm float32, rotationAngle float32) ScopeTransformations {
+	return GetStretchedScopeTransformations(paneExtent, magneticVariation, nmPerLongitude, center, rangenm,
+		rotationAngle, 1)
+}
+
+// GetStretchedScopeTransformations is similar to GetScopeTransformations
+// but additionally scales the horizontal axis of the scope by the given
+// stretch factor after rotation; this is used for displays like the STARS
+// final monitor aid that exaggerate lateral deviations from a final
+// approach course.
+func GetStretchedScopeTransformations(paneExtent math.Extent2D, magneticVariation float32, nmPerLongitude float32,
+	center math.Point2LL, rangenm float32, rotationAngle float32, stretch float32) ScopeTransformations {
 	width, height := paneExtent.Width(), paneExtent.Height()
 	aspect := width / height
 	ndcFromLatLong := math.Identity3x3().
 		// Final orthographic projection including the effect of the
 		// window's aspect ratio.
 		Ortho(-aspect, aspect, -1, 1).
+		// Horizontal stretch, if any.
+		Scale(stretch, 1).
 		// Account for magnetic variation and any user-specified rotation
 		Rotate(-math.Radians(rotationAngle+magneticVariation)).
 		// Scale based on range and nm per latitude / longitude
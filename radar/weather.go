@@ -1,9 +1,7 @@
 package radar
 
 import (
-	"fmt"
 	"math/bits"
-	"net/http"
 	"time"
 
 	"github.com/mmp/vice/log"
@@ -43,6 +41,16 @@ func (w *WeatherRadar) fetchPrecipitation(ctx *panes.Context) {
 		w.errCh = make(chan error)
 	}
 
+	if sw := ctx.Client.State.ScriptedWeather; sw != nil {
+		// Scripted weather is generated locally rather than fetched; use
+		// the same times as the server does so that the scope shows the
+		// precipitation that pilots are deviating around.
+		t, next := sw.UpdateTimes(ctx.Client.State.SimTime)
+		w.nextFetchTime = next
+		go func() { w.precipCh <- sw.PrecipAt(t) }()
+		return
+	}
+
 	ctx.Client.GetPrecipURL(ctx.Client.State.SimTime, func(url string, nextTime time.Time, err error) {
 		w.mu.Lock(ctx.Lg)
 		defer w.mu.Unlock(ctx.Lg)
@@ -61,19 +69,7 @@ func (w *WeatherRadar) fetchPrecipitation(ctx *panes.Context) {
 }
 
 func (w *WeatherRadar) fetchPrecip(url string, lg *log.Logger) {
-	resp, err := http.Get(url)
-	if err != nil {
-		w.errCh <- err
-		return
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		w.errCh <- fmt.Errorf("HTTP error: %d", resp.StatusCode)
-		return
-	}
-
-	precip, err := wx.DecodePrecip(resp.Body)
+	precip, err := wx.FetchPrecip(url)
 	if err != nil {
 		w.errCh <- err
 	} else {
@@ -89,24 +85,6 @@ func (w *WeatherRadar) HaveWeather() [NumWxLevels]bool {
 	return r
 }
 
-func dbzToLevel(dbz byte) int {
-	// Map the dBZ value to a STARS WX level.
-	if dbz > 55 {
-		return 6
-	} else if dbz > 50 {
-		return 5
-	} else if dbz > 45 {
-		return 4
-	} else if dbz > 40 {
-		return 3
-	} else if dbz > 30 {
-		return 2
-	} else if dbz > 20 {
-		return 1
-	}
-	return 0
-}
-
 func makeWeatherCommandBuffers(precip *wx.Precip) [NumWxLevels]*renderer.CommandBuffer {
 	nx, ny := precip.Resolution, precip.Resolution
 	bounds := precip.BoundsLL()
@@ -128,7 +106,7 @@ func makeWeatherCommandBuffers(precip *wx.Precip) [NumWxLevels]*renderer.Command
 					continue
 				}
 
-				dbzLevel := dbzToLevel(precip.DBZ[idx])
+				dbzLevel := wx.DBZToLevel(precip.DBZ[idx])
 				if dbzLevel != level {
 					continue
 				}
@@ -136,7 +114,7 @@ func makeWeatherCommandBuffers(precip *wx.Precip) [NumWxLevels]*renderer.Command
 
 				// Find the span of consecutive pixels at this level
 				x0 := x
-				for x < nx && x+y*nx < len(precip.DBZ) && dbzToLevel(precip.DBZ[x+y*nx]) == level {
+				for x < nx && x+y*nx < len(precip.DBZ) && wx.DBZToLevel(precip.DBZ[x+y*nx]) == level {
 					x++
 				}
 
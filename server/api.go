@@ -0,0 +1,312 @@
+// server/api.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mmp/vice/sim"
+	"github.com/mmp/vice/util"
+)
+
+// The JSON API makes it possible for tools written in other languages to
+// interact with running sims without implementing vice's msgpack-based
+// RPC protocol. It is served by the same HTTP server as the status page;
+// all requests and responses are JSON. Errors are returned with a non-2xx
+// status code and a body of the form {"Error": "message"}.
+//
+// Requests that act on a sim must include the controller token returned
+// when joining it, either in an "Authorization: Bearer <token>" header or
+// in the ControllerToken field of the request body. As with vice clients,
+// connections that haven't fetched /state or /aircraft for 15 seconds are
+// signed off when running on a multi-controller server.
+//
+//	GET  /api/v1/sims
+//	    The running sims, as a map from sim name to RunningSim.
+//	POST /api/v1/sims/{name}/join
+//	    Join the named sim. The body is a JoinSimRequest (the SimName field
+//	    is ignored); set Observer to true to follow the sim without signing
+//	    on at a TCW or PseudoPilot to sign on as a pseudo-pilot. Returns
+//	    {"ControllerToken": ..., "TCW": ...}.
+//	POST /api/v1/signoff
+//	    Sign off from the sim.
+//	GET  /api/v1/state
+//	    A SimStateUpdate with the sim's current state along with the
+//	    events since the last call to /state. Events have an additional
+//	    TypeName field with the name of their type.
+//	GET  /api/v1/aircraft
+//	    {"SimTime": ..., "Tracks": [...]} with the current radar tracks,
+//	    sorted by callsign.
+//	GET  /api/v1/rpc
+//	    The available commands, each with its name and an example of its
+//	    arguments with zero values.
+//	POST /api/v1/rpc/{method}
+//	    Run a command; method is one of the ones in apiMethods (e.g.,
+//	    "RunAircraftCommands" or "HandoffTrack"). The body holds its
+//	    arguments, or is omitted if the only argument is the controller
+//	    token. Returns the command's result, which is often a
+//	    SimStateUpdate.
+//	GET  /api/v1/feed
+//	    A WebSocket stream of the sim's events and radar tracks; see feed.go.
+
+// All API requests are limited to this size.
+const maxAPIRequestBytes = 1 << 20
+
+type apiError struct {
+	Error string
+}
+
+type apiJoinResult struct {
+	ControllerToken string
+	TCW             sim.TCW
+}
+
+type apiAircraftResult struct {
+	SimTime time.Time
+	Tracks  []*sim.Track
+}
+
+type apiEvent struct {
+	sim.Event
+	TypeName string
+}
+
+type apiStateResult struct {
+	SimStateUpdate
+	Events []apiEvent
+}
+
+type apiMethod struct {
+	Method string
+	Args   any
+}
+
+// apiMethods are the dispatcher methods that may be called via the API:
+// the ones that controllers and pseudo-pilots use to work traffic. The
+// ones that manage the sim itself (pausing it, changing its launch
+// configuration, checkpoints, and so forth) are only available to vice.
+var apiMethods = []string{
+	"AcceptHandoff",
+	"AcceptRedirectedHandoff",
+	"AcknowledgePointOut",
+	"ActivateFlightPlan",
+	"AssociateFlightPlan",
+	"CancelHandoff",
+	"CreateFlightPlan",
+	"DeleteFlightPlan",
+	"ForceQL",
+	"GetAircraftDisplayState",
+	"GetSessionScore",
+	"HandoffTrack",
+	"ModifyFlightPlan",
+	"PointOut",
+	"PseudoPilotTransmit",
+	"RecallPointOut",
+	"RedirectHandoff",
+	"RejectPointOut",
+	"ReleaseDeparture",
+	"RepositionTrack",
+	"RequestContactTransmission",
+	"RunAircraftCommands",
+	"RunPseudoPilotCommands",
+}
+
+// lookupAPIMethod returns the dispatcher method with the given name if it
+// may be called via the API.
+func lookupAPIMethod(sm *SimManager, name string) (reflect.Value, bool) {
+	if !slices.Contains(apiMethods, name) {
+		return reflect.Value{}, false
+	}
+	return lookupDispatcherMethod(sm, "Sim."+name)
+}
+
+func (sm *SimManager) registerAPIHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v1/sims", sm.apiHandler(sm.apiGetSims))
+	mux.HandleFunc("POST /api/v1/sims/{name}/join", sm.apiHandler(sm.apiJoinSim))
+	mux.HandleFunc("POST /api/v1/signoff", sm.apiHandler(sm.apiSignOff))
+	mux.HandleFunc("GET /api/v1/state", sm.apiHandler(sm.apiGetState))
+	mux.HandleFunc("GET /api/v1/aircraft", sm.apiHandler(sm.apiGetAircraft))
+	mux.HandleFunc("GET /api/v1/rpc", sm.apiHandler(sm.apiListMethods))
+	mux.HandleFunc("POST /api/v1/rpc/{method}", sm.apiHandler(sm.apiCall))
+	mux.HandleFunc("GET /api/v1/feed", sm.handleFeed)
+}
+
+// apiHandler wraps the given function, which returns the value to encode
+// in the response, to handle the details of HTTP and JSON.
+func (sm *SimManager) apiHandler(f func(r *http.Request) (any, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer sm.lg.CatchAndReportCrash()
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxAPIRequestBytes)
+
+		result, err := f(r)
+		sm.writeAPIResponse(w, r, result, err)
+	}
+}
+
+// writeAPIResponse encodes the result as JSON or, if err is non-nil,
+// returns the error with an appropriate status code.
+func (sm *SimManager) writeAPIResponse(w http.ResponseWriter, r *http.Request, result any, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		w.WriteHeader(apiStatusForError(err))
+		result = apiError{Error: err.Error()}
+	}
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		sm.lg.Errorf("%s: error encoding API response: %v", r.URL.Path, err)
+	}
+}
+
+var errAPIUnknownMethod = errors.New("Unknown method")
+
+func apiStatusForError(err error) int {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, ErrNoSimForControllerToken), errors.Is(err, ErrInvalidControllerToken),
+		errors.Is(err, ErrInvalidPassword):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrNoNamedSim), errors.Is(err, errAPIUnknownMethod):
+		return http.StatusNotFound
+	case errors.Is(err, ErrTCWAlreadyOccupied), errors.Is(err, ErrReplayInProgress):
+		return http.StatusConflict
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		return http.StatusBadRequest
+	default:
+		// Most errors from commands are due to invalid input or the
+		// current state of the sim.
+		return http.StatusUnprocessableEntity
+	}
+}
+
+// apiToken returns the controller token from the request's Authorization
+// header, if present.
+func apiToken(r *http.Request) string {
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return strings.TrimSpace(token)
+}
+
+// decodeAPIBody decodes the request's JSON body into v; an empty body is
+// allowed and leaves v unchanged.
+func decodeAPIBody(r *http.Request, v any) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+func (sm *SimManager) apiGetSims(r *http.Request) (any, error) {
+	var running map[string]*RunningSim
+	err := sm.GetRunningSims(0, &running)
+	return running, err
+}
+
+func (sm *SimManager) apiJoinSim(r *http.Request) (any, error) {
+	var req JoinSimRequest
+	if err := decodeAPIBody(r, &req); err != nil {
+		return nil, err
+	}
+	req.SimName = r.PathValue("name")
+
+	var result NewSimResult
+	if err := sm.ConnectToSim(&req, &result); err != nil {
+		return nil, err
+	}
+
+	sm.lg.Infof("%s: %s (%s) joined via API", req.SimName, req.TCW, req.Initials)
+
+	return apiJoinResult{ControllerToken: result.ControllerToken, TCW: result.SimState.UserTCW}, nil
+}
+
+func (sm *SimManager) apiSignOff(r *http.Request) (any, error) {
+	return struct{}{}, sm.SignOff(apiToken(r))
+}
+
+func (sm *SimManager) apiGetState(r *http.Request) (any, error) {
+	update, err := sm.GetStateUpdate(apiToken(r))
+	if err != nil {
+		return nil, err
+	} else if update == nil {
+		return nil, ErrNoSimForControllerToken
+	}
+
+	return apiStateResult{
+		SimStateUpdate: *update,
+		Events:         makeAPIEvents(update.Events),
+	}, nil
+}
+
+func makeAPIEvents(events []sim.Event) []apiEvent {
+	return util.MapSlice(events, func(e sim.Event) apiEvent {
+		return apiEvent{Event: e, TypeName: e.Type.String()}
+	})
+}
+
+func (sm *SimManager) apiGetAircraft(r *http.Request) (any, error) {
+	token := apiToken(r)
+	c := sm.LookupController(token)
+	if c == nil {
+		return nil, ErrNoSimForControllerToken
+	}
+
+	if _, ok := c.session.touchConnection(token); !ok {
+		return nil, ErrNoSimForControllerToken
+	}
+
+	simTime, tracks := c.sim.GetTracks()
+	return apiAircraftResult{SimTime: simTime, Tracks: tracks}, nil
+}
+
+func (sm *SimManager) apiListMethods(r *http.Request) (any, error) {
+	var methods []apiMethod
+	for _, name := range apiMethods {
+		m, ok := lookupAPIMethod(sm, name)
+		if !ok {
+			sm.lg.Errorf("%s: API method not found in dispatcher", name)
+			continue
+		}
+
+		// Follow pointers so that the example arguments are a JSON object
+		// rather than null.
+		args := reflect.New(m.Type().In(0)).Elem()
+		for args.Kind() == reflect.Pointer {
+			args.Set(reflect.New(args.Type().Elem()))
+			args = args.Elem()
+		}
+		methods = append(methods, apiMethod{Method: name, Args: args.Interface()})
+	}
+	return methods, nil
+}
+
+func (sm *SimManager) apiCall(r *http.Request) (any, error) {
+	name := r.PathValue("method")
+	m, ok := lookupAPIMethod(sm, name)
+	if !ok {
+		return nil, errAPIUnknownMethod
+	}
+
+	args := reflect.New(m.Type().In(0))
+	if err := decodeAPIBody(r, args.Interface()); err != nil {
+		return nil, err
+	}
+	if f := controllerTokenField(args); f.CanSet() && f.String() == "" {
+		f.SetString(apiToken(r))
+	}
+
+	reply, err := sm.callRPC("Sim."+name, m, args)
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
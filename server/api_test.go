@@ -0,0 +1,116 @@
+// server/api_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPI(t *testing.T) {
+	sm := makeTestSimManager("")
+	session := makeSimSession("test", "", "", "", makeTestSim(sm.lg), sm.lg)
+	var result NewSimResult
+	if err := sm.Add(session, &result, "1A", "AB", false, false); err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	sm.registerAPIHandlers(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	request := func(method, path, token, body string, result any) int {
+		t.Helper()
+
+		req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		if result != nil {
+			if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+				t.Fatalf("%s %s: %v", method, path, err)
+			}
+		}
+		return resp.StatusCode
+	}
+
+	// Join the sim at 1B.
+	var join apiJoinResult
+	if code := request("POST", "/api/v1/sims/test/join", "", `{"TCW": "1B", "Initials": "CD", "SelectedTCPs": ["1B"]}`,
+		&join); code != http.StatusOK {
+		t.Fatalf("join: status %d", code)
+	}
+	if join.TCW != "1B" || join.ControllerToken == "" {
+		t.Fatalf("unexpected join result %+v", join)
+	}
+	if code := request("POST", "/api/v1/sims/nope/join", "", `{"TCW": "1C"}`, nil); code != http.StatusNotFound {
+		t.Errorf("join of unknown sim: expected status %d, got %d", http.StatusNotFound, code)
+	}
+
+	// Only the allowed methods are listed and may be called.
+	var methods []apiMethod
+	if code := request("GET", "/api/v1/rpc", "", "", &methods); code != http.StatusOK {
+		t.Fatalf("rpc list: status %d", code)
+	}
+	if len(methods) != len(apiMethods) {
+		t.Errorf("expected %d methods, got %d", len(apiMethods), len(methods))
+	}
+	for _, m := range methods {
+		if m.Method == "TogglePause" {
+			t.Errorf("TogglePause listed as an API method")
+		}
+	}
+	for _, method := range []string{"TogglePause", "SetSimRate", "RestoreCheckpoint", "DeleteAllAircraft", "NotAMethod"} {
+		if code := request("POST", "/api/v1/rpc/"+method, join.ControllerToken, "", nil); code != http.StatusNotFound {
+			t.Errorf("%s: expected status %d, got %d", method, http.StatusNotFound, code)
+		}
+	}
+
+	// Commands go to the aircraft on 1B's frequency.
+	var cmdResult AircraftCommandsResult
+	if code := request("POST", "/api/v1/rpc/RunAircraftCommands", join.ControllerToken,
+		`{"Callsign": "DAL2", "Commands": "D40"}`, &cmdResult); code != http.StatusOK {
+		t.Fatalf("RunAircraftCommands: status %d", code)
+	}
+	if cmdResult.ErrorMessage != "" {
+		t.Errorf("RunAircraftCommands: %s", cmdResult.ErrorMessage)
+	}
+	if code := request("POST", "/api/v1/rpc/RunAircraftCommands", "", `{"Callsign": "DAL2", "Commands": "D40"}`,
+		nil); code != http.StatusUnauthorized {
+		t.Errorf("RunAircraftCommands without a token: expected status %d, got %d", http.StatusUnauthorized, code)
+	}
+	if code := request("POST", "/api/v1/rpc/RunAircraftCommands", join.ControllerToken, `{"Callsign": 5}`,
+		nil); code != http.StatusBadRequest {
+		t.Errorf("RunAircraftCommands with bad arguments: expected status %d, got %d", http.StatusBadRequest, code)
+	}
+
+	var aircraft apiAircraftResult
+	if code := request("GET", "/api/v1/aircraft", join.ControllerToken, "", &aircraft); code != http.StatusOK {
+		t.Fatalf("aircraft: status %d", code)
+	}
+	if len(aircraft.Tracks) != 2 || aircraft.Tracks[0].ADSBCallsign != "AAL1" || aircraft.Tracks[1].ADSBCallsign != "DAL2" {
+		t.Errorf("unexpected tracks %+v", aircraft.Tracks)
+	}
+
+	if code := request("POST", "/api/v1/signoff", join.ControllerToken, "", nil); code != http.StatusOK {
+		t.Errorf("signoff: status %d", code)
+	}
+	if code := request("GET", "/api/v1/state", join.ControllerToken, "", nil); code != http.StatusUnauthorized {
+		t.Errorf("state after signoff: expected status %d, got %d", http.StatusUnauthorized, code)
+	}
+}
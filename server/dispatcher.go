@@ -7,6 +7,8 @@ package server
 import (
 	"fmt"
 	"log/slog"
+	"reflect"
+	"strings"
 	"time"
 
 	av "github.com/mmp/vice/aviation"
@@ -20,6 +22,36 @@ type dispatcher struct {
 	sm *SimManager
 }
 
+// lookupDispatcherMethod returns the dispatcher method for the given RPC
+// (e.g., "Sim.HandoffTrack"), if there is one. This allows RPCs to be
+// made other than via net/rpc.
+func lookupDispatcherMethod(sm *SimManager, rpc string) (reflect.Value, bool) {
+	name, ok := strings.CutPrefix(rpc, "Sim.")
+	if !ok {
+		return reflect.Value{}, false
+	}
+	m := reflect.ValueOf(&dispatcher{sm: sm}).MethodByName(name)
+	if !m.IsValid() || !isDispatcherMethodType(m.Type()) {
+		return reflect.Value{}, false
+	}
+	return m, true
+}
+
+// isDispatcherMethodType checks that t has the form required by net/rpc:
+// func(args T, reply *R) error.
+func isDispatcherMethodType(t reflect.Type) bool {
+	return t.NumIn() == 2 && t.In(1).Kind() == reflect.Pointer && t.NumOut() == 1 &&
+		t.Out(0) == reflect.TypeFor[error]()
+}
+
+// callDispatcherMethod calls a method returned by lookupDispatcherMethod,
+// returning a pointer to its reply.
+func callDispatcherMethod(m reflect.Value, args reflect.Value) (any, error) {
+	reply := reflect.New(m.Type().In(1).Elem())
+	err, _ := m.Call([]reflect.Value{args, reply})[0].Interface().(error)
+	return reply.Interface(), err
+}
+
 const GetStateUpdateRPC = "Sim.GetStateUpdate"
 
 func (sd *dispatcher) GetStateUpdate(token string, update *SimStateUpdate) error {
@@ -140,6 +172,23 @@ func (sd *dispatcher) TriggerEmergency(args *TriggerEmergencyArgs, _ *struct{})
 	return nil
 }
 
+type ChangeRunwayConfigurationArgs struct {
+	ControllerToken string
+	Configuration   string
+}
+
+const ChangeRunwayConfigurationRPC = "Sim.ChangeRunwayConfiguration"
+
+func (sd *dispatcher) ChangeRunwayConfiguration(args *ChangeRunwayConfigurationArgs, _ *struct{}) error {
+	defer sd.sm.lg.CatchAndReportCrash()
+
+	c := sd.sm.LookupController(args.ControllerToken)
+	if c == nil {
+		return ErrNoSimForControllerToken
+	}
+	return c.sim.ChangeRunwayConfiguration(c.tcw, args.Configuration)
+}
+
 const FastForwardRPC = "Sim.FastForward"
 
 func (sd *dispatcher) FastForward(token string, update *SimStateUpdate) error {
@@ -609,11 +658,11 @@ type AircraftCommandsArgs struct {
 // If an RPC call returns an error, then the result argument is not returned(!?).
 // So we don't use the error type for syntax errors...
 type AircraftCommandsResult struct {
-	ErrorMessage      string
-	RemainingInput    string
-	ReadbackText      string          // Text for client to synthesize
-	ReadbackVoiceName string          // Voice name for synthesis (e.g., "am_adam")
-	ReadbackCallsign  av.ADSBCallsign // Callsign for the readback
+	ErrorMessage     string
+	RemainingInput   string
+	ReadbackText     string          // Text for client to synthesize
+	ReadbackVoice    sim.Voice       // Voice for synthesis
+	ReadbackCallsign av.ADSBCallsign // Callsign for the readback
 }
 
 const RunAircraftCommandsRPC = "Sim.RunAircraftCommands"
@@ -639,7 +688,12 @@ func (sd *dispatcher) RunAircraftCommands(cmds *AircraftCommandsArgs, result *Ai
 	setReadback := func(spokenText string) {
 		if cmds.EnableTTS && spokenText != "" {
 			result.ReadbackText = spokenText
-			result.ReadbackVoiceName = c.sim.VoiceAssigner.GetVoice(callsign, c.sim.Rand)
+			voice, err := c.sim.VoiceAssigner.GetVoice(callsign)
+			if err != nil {
+				// Fall back to the TTS engine's default voice.
+				sd.sm.lg.Errorf("%s: %v", callsign, err)
+			}
+			result.ReadbackVoice = voice
 			result.ReadbackCallsign = callsign
 		}
 	}
@@ -684,6 +738,51 @@ func (sd *dispatcher) RunAircraftCommands(cmds *AircraftCommandsArgs, result *Ai
 	return nil
 }
 
+type PseudoPilotCommandsArgs struct {
+	ControllerToken string
+	Callsign        av.ADSBCallsign
+	Commands        string
+}
+
+const RunPseudoPilotCommandsRPC = "Sim.RunPseudoPilotCommands"
+
+func (sd *dispatcher) RunPseudoPilotCommands(cmds *PseudoPilotCommandsArgs, result *AircraftCommandsResult) error {
+	defer sd.sm.lg.CatchAndReportCrash()
+
+	c := sd.sm.LookupController(cmds.ControllerToken)
+	if c == nil {
+		return ErrNoSimForControllerToken
+	}
+
+	// As with RunAircraftCommands, command errors are returned in the
+	// result.
+	execResult := c.sim.RunPseudoPilotCommands(c.tcw, cmds.Callsign, cmds.Commands)
+	result.RemainingInput = execResult.RemainingInput
+	if execResult.Error != nil {
+		result.ErrorMessage = execResult.Error.Error()
+	}
+	return nil
+}
+
+type PseudoPilotTransmitArgs struct {
+	ControllerToken string
+	Callsign        av.ADSBCallsign
+	Text            string
+	Type            av.RadioTransmissionType
+}
+
+const PseudoPilotTransmitRPC = "Sim.PseudoPilotTransmit"
+
+func (sd *dispatcher) PseudoPilotTransmit(args *PseudoPilotTransmitArgs, _ *struct{}) error {
+	defer sd.sm.lg.CatchAndReportCrash()
+
+	c := sd.sm.LookupController(args.ControllerToken)
+	if c == nil {
+		return ErrNoSimForControllerToken
+	}
+	return c.sim.PseudoPilotTransmit(c.tcw, args.Callsign, args.Text, args.Type)
+}
+
 type SetWaypointCommandsArgs struct {
 	ControllerToken string
 	Commands        string
@@ -886,6 +985,102 @@ func (sd *dispatcher) GetAircraftDisplayState(as *AircraftSpecifier, state *sim.
 	return err
 }
 
+const GetSessionScoreRPC = "Sim.GetSessionScore"
+
+func (sd *dispatcher) GetSessionScore(token string, score *sim.SessionScore) error {
+	defer sd.sm.lg.CatchAndReportCrash()
+
+	c := sd.sm.LookupController(token)
+	if c == nil {
+		return ErrNoSimForControllerToken
+	}
+	*score = c.sim.GetSessionScore()
+	return nil
+}
+
+type CheckpointArgs struct {
+	ControllerToken string
+	Name            string
+}
+
+const SaveCheckpointRPC = "Sim.SaveCheckpoint"
+
+func (sd *dispatcher) SaveCheckpoint(args *CheckpointArgs, info *sim.CheckpointInfo) error {
+	defer sd.sm.lg.CatchAndReportCrash()
+
+	c := sd.sm.LookupController(args.ControllerToken)
+	if c == nil {
+		return ErrNoSimForControllerToken
+	}
+	var err error
+	*info, err = c.sim.SaveCheckpoint(c.tcw, args.Name)
+	return err
+}
+
+const GetCheckpointsRPC = "Sim.GetCheckpoints"
+
+func (sd *dispatcher) GetCheckpoints(token string, info *[]sim.CheckpointInfo) error {
+	defer sd.sm.lg.CatchAndReportCrash()
+
+	c := sd.sm.LookupController(token)
+	if c == nil {
+		return ErrNoSimForControllerToken
+	}
+	*info = c.sim.GetCheckpoints()
+	return nil
+}
+
+const DeleteCheckpointRPC = "Sim.DeleteCheckpoint"
+
+func (sd *dispatcher) DeleteCheckpoint(args *CheckpointArgs, _ *struct{}) error {
+	defer sd.sm.lg.CatchAndReportCrash()
+
+	c := sd.sm.LookupController(args.ControllerToken)
+	if c == nil {
+		return ErrNoSimForControllerToken
+	}
+	return c.sim.DeleteCheckpoint(args.Name)
+}
+
+const RestoreCheckpointRPC = "Sim.RestoreCheckpoint"
+
+func (sd *dispatcher) RestoreCheckpoint(args *CheckpointArgs, update *SimStateUpdate) error {
+	defer sd.sm.lg.CatchAndReportCrash()
+
+	c := sd.sm.LookupController(args.ControllerToken)
+	if c == nil {
+		return ErrNoSimForControllerToken
+	}
+	if err := c.sim.RestoreCheckpoint(args.Name); err != nil {
+		return err
+	}
+	c.sim.GlobalMessage(c.tcw, fmt.Sprintf("%s (%s) has rewound the sim to checkpoint %q", c.tcw, c.initials, args.Name))
+	*update = c.GetStateUpdate()
+	return nil
+}
+
+type SeekReplayArgs struct {
+	ControllerToken string
+	SimTime         time.Time
+}
+
+const SeekReplayRPC = "Sim.SeekReplay"
+
+func (sd *dispatcher) SeekReplay(args *SeekReplayArgs, update *SimStateUpdate) error {
+	defer sd.sm.lg.CatchAndReportCrash()
+
+	c := sd.sm.LookupController(args.ControllerToken)
+	if c == nil {
+		return ErrNoSimForControllerToken
+	}
+	if c.session.replay == nil {
+		return ErrNotReplay
+	}
+	c.session.replay.Seek(args.SimTime)
+	*update = c.GetStateUpdate()
+	return nil
+}
+
 type ConsolidateTCPArgs struct {
 	ControllerToken string
 	ReceivingTCW    sim.TCW
@@ -959,6 +1154,36 @@ func (sd *dispatcher) ConfigureATPA(args *ATPAConfigArgs, result *ATPAConfigResu
 	return err
 }
 
+type TrialPlanArgs struct {
+	ControllerToken string
+	ACID            sim.ACID
+	Altitude        int
+	Fix             string
+}
+
+type TrialPlanResult struct {
+	SimStateUpdate
+	Conflicts []sim.ProbeConflict
+}
+
+const TrialPlanRPC = "Sim.TrialPlan"
+
+func (sd *dispatcher) TrialPlan(args *TrialPlanArgs, result *TrialPlanResult) error {
+	defer sd.sm.lg.CatchAndReportCrash()
+
+	c := sd.sm.LookupController(args.ControllerToken)
+	if c == nil {
+		return ErrNoSimForControllerToken
+	}
+
+	var err error
+	result.Conflicts, err = c.sim.TrialPlan(c.tcw, args.ACID, args.Altitude, args.Fix)
+	if err == nil {
+		result.SimStateUpdate = c.GetStateUpdate()
+	}
+	return err
+}
+
 type FDAMConfigArgs struct {
 	ControllerToken string
 	Op              sim.FDAMConfigOp
@@ -993,10 +1218,10 @@ type RequestContactArgs struct {
 }
 
 type RequestContactResult struct {
-	ContactText      string          // Text to synthesize
-	ContactVoiceName string          // Voice name for synthesis (e.g., "am_adam")
-	ContactCallsign  av.ADSBCallsign // Callsign of the aircraft
-	ContactType      av.RadioTransmissionType
+	ContactText     string          // Text to synthesize
+	ContactVoice    sim.Voice       // Voice for synthesis
+	ContactCallsign av.ADSBCallsign // Callsign of the aircraft
+	ContactType     av.RadioTransmissionType
 }
 
 const RequestContactTransmissionRPC = "Sim.RequestContactTransmission"
@@ -1009,8 +1234,8 @@ func (sd *dispatcher) RequestContactTransmission(args *RequestContactArgs, resul
 		return ErrNoSimForControllerToken
 	}
 
-	// Request a contact from the session - returns text and voice name for client-side synthesis
-	result.ContactText, result.ContactVoiceName, result.ContactCallsign, result.ContactType = c.session.RequestContact(c.tcw)
+	// Request a contact from the session - returns text and voice for client-side synthesis
+	result.ContactText, result.ContactVoice, result.ContactCallsign, result.ContactType = c.session.RequestContact(c.tcw)
 	return nil
 }
 
@@ -21,6 +21,9 @@ var (
 	ErrInvalidSimConfiguration   = errors.New("Invalid SimConfiguration")
 	ErrNoNamedSim                = errors.New("No Sim with that name")
 	ErrNoSimForControllerToken   = errors.New("No Sim running for controller token")
+	ErrNotReplay                 = errors.New("Sim is not replaying a recording")
+	ErrReplayInProgress          = errors.New("Sim is replaying a recording")
+	ErrReplayNotLocal            = errors.New("Recordings can only be replayed locally")
 	ErrRPCTimeout                = errors.New("RPC call timed out")
 	ErrRPCVersionMismatch        = errors.New("Client and server RPC versions don't match")
 	ErrServerDisconnected        = errors.New("Server disconnected")
@@ -81,13 +84,18 @@ var errorStringToError = map[string]error{
 	sim.ErrInvalidVolumeId.Error():                 sim.ErrInvalidVolumeId,
 	sim.ErrNoMatchingFlight.Error():                sim.ErrNoMatchingFlight,
 	sim.ErrNoMatchingFlightPlan.Error():            sim.ErrNoMatchingFlightPlan,
+	sim.ErrNoNamedCheckpoint.Error():               sim.ErrNoNamedCheckpoint,
 	sim.ErrNoVFRAircraftForFlightFollowing.Error(): sim.ErrNoVFRAircraftForFlightFollowing,
+	sim.ErrNotInstructor.Error():                   sim.ErrNotInstructor,
 	sim.ErrNotLaunchController.Error():             sim.ErrNotLaunchController,
+	sim.ErrNotPseudoPilot.Error():                  sim.ErrNotPseudoPilot,
+	sim.ErrNotPseudoPilotAircraft.Error():          sim.ErrNotPseudoPilotAircraft,
 	sim.ErrTCPAlreadyConsolidated.Error():          sim.ErrTCPAlreadyConsolidated,
 	sim.ErrTCPNotConsolidated.Error():              sim.ErrTCPNotConsolidated,
 	sim.ErrTCWIsConsolidated.Error():               sim.ErrTCWIsConsolidated,
 	sim.ErrTCWNotFound.Error():                     sim.ErrTCWNotFound,
 	sim.ErrTCWNotVacant.Error():                    sim.ErrTCWNotVacant,
+	sim.ErrTooManyCheckpoints.Error():              sim.ErrTooManyCheckpoints,
 	sim.ErrTooManyRestrictionAreas.Error():         sim.ErrTooManyRestrictionAreas,
 	sim.ErrTrackIsActive.Error():                   sim.ErrTrackIsActive,
 	sim.ErrTrackIsBeingHandedOff.Error():           sim.ErrTrackIsBeingHandedOff,
@@ -95,6 +103,7 @@ var errorStringToError = map[string]error{
 	sim.ErrUnknownAircraftType.Error():             sim.ErrUnknownAircraftType,
 	sim.ErrUnknownController.Error():               sim.ErrUnknownController,
 	sim.ErrUnknownControllerFacility.Error():       sim.ErrUnknownControllerFacility,
+	sim.ErrUnknownRunwayConfiguration.Error():      sim.ErrUnknownRunwayConfiguration,
 	sim.ErrVFRSimTookTooLong.Error():               sim.ErrVFRSimTookTooLong,
 	sim.ErrViolatedAirspace.Error():                sim.ErrViolatedAirspace,
 	sim.ErrVolumeDisabled.Error():                  sim.ErrVolumeDisabled,
@@ -108,6 +117,9 @@ var errorStringToError = map[string]error{
 	ErrInvalidSimConfiguration.Error():   ErrInvalidSimConfiguration,
 	ErrNoNamedSim.Error():                ErrNoNamedSim,
 	ErrNoSimForControllerToken.Error():   ErrNoSimForControllerToken,
+	ErrNotReplay.Error():                 ErrNotReplay,
+	ErrReplayInProgress.Error():          ErrReplayInProgress,
+	ErrReplayNotLocal.Error():            ErrReplayNotLocal,
 	ErrRPCTimeout.Error():                ErrRPCTimeout,
 	ErrRPCVersionMismatch.Error():        ErrRPCVersionMismatch,
 	ErrServerDisconnected.Error():        ErrServerDisconnected,
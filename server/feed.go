@@ -0,0 +1,162 @@
+// server/feed.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package server
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mmp/vice/sim"
+
+	"golang.org/x/net/websocket"
+)
+
+// The event feed lets external programs (traffic displays on a second
+// monitor, instructor dashboards, etc.) follow a running sim without
+// signing on to it. Clients connect with a WebSocket to
+//
+//	GET /api/v1/feed?sim=<name>&password=<password>&interval=<seconds>
+//
+// sim is the name of the sim to follow; it may be omitted to follow the
+// local sim when connecting to a local server. password is only needed
+// for sims that require one. interval gives the time between position
+// reports in seconds and defaults to 1.
+//
+// The server then sends a JSON-encoded feedMessage in each WebSocket
+// message: "events" messages carry the sim's events (radio transmissions,
+// handoffs, point-outs, ...) as they happen and "positions" messages carry
+// all of the radar tracks at the requested interval. An "end" message is
+// sent if the sim terminates. Anything sent by the client is ignored.
+
+const (
+	defaultFeedInterval = time.Second
+	minFeedInterval     = 250 * time.Millisecond
+	feedEventInterval   = 250 * time.Millisecond
+	feedWriteTimeout    = 10 * time.Second
+)
+
+var errInvalidFeedInterval = errors.New("Invalid feed interval")
+
+type feedMessage struct {
+	Type    string // "events", "positions", or "end"
+	SimTime time.Time
+	Events  []apiEvent   `json:",omitempty"`
+	Tracks  []*sim.Track `json:",omitempty"`
+}
+
+func (sm *SimManager) handleFeed(w http.ResponseWriter, r *http.Request) {
+	defer sm.lg.CatchAndReportCrash()
+
+	q := r.URL.Query()
+
+	sm.mu.Lock(sm.lg)
+	session, ok := sm.sessionsByName[q.Get("sim")]
+	sm.mu.Unlock(sm.lg)
+
+	if !ok {
+		sm.writeAPIResponse(w, r, nil, ErrNoNamedSim)
+		return
+	}
+	if session.password != "" && q.Get("password") != session.password {
+		sm.writeAPIResponse(w, r, nil, ErrInvalidPassword)
+		return
+	}
+
+	interval := defaultFeedInterval
+	if s := q.Get("interval"); s != "" {
+		sec, err := strconv.ParseFloat(s, 64)
+		if err != nil || time.Duration(sec*float64(time.Second)) < minFeedInterval {
+			sm.writeAPIResponse(w, r, nil, errInvalidFeedInterval)
+			return
+		}
+		interval = time.Duration(sec * float64(time.Second))
+	}
+
+	// Use websocket.Server rather than websocket.Handler so that clients
+	// that don't send an Origin header (i.e., most things that aren't a
+	// browser) are accepted.
+	websocket.Server{
+		Handler: func(ws *websocket.Conn) { sm.runFeed(ws, session, interval) },
+	}.ServeHTTP(w, r)
+}
+
+func (sm *SimManager) runFeed(ws *websocket.Conn, session *simSession, interval time.Duration) {
+	defer sm.lg.CatchAndReportCrash()
+	defer ws.Close()
+
+	lg := session.lg.With(slog.String("remote_addr", ws.Request().RemoteAddr))
+	lg.Infof("event feed connected")
+
+	sub := session.sim.Subscribe()
+	defer sub.Unsubscribe()
+
+	// Nothing is expected from the client, but reading is necessary to
+	// notice when it closes the connection.
+	closed := make(chan struct{})
+	go func() {
+		defer sm.lg.CatchAndReportCrash()
+		defer close(closed)
+
+		var msg []byte
+		for websocket.Message.Receive(ws, &msg) == nil {
+		}
+	}()
+
+	send := func(msg feedMessage) bool {
+		ws.SetWriteDeadline(time.Now().Add(feedWriteTimeout))
+		if err := websocket.JSON.Send(ws, msg); err != nil {
+			lg.Infof("event feed: %v", err)
+			return false
+		}
+		return true
+	}
+
+	eventTick := time.NewTicker(feedEventInterval)
+	defer eventTick.Stop()
+	positionTick := time.NewTicker(interval)
+	defer positionTick.Stop()
+
+	for {
+		select {
+		case <-closed:
+			lg.Infof("event feed disconnected")
+			return
+
+		case <-eventTick.C:
+			if !sm.sessionRunning(session) {
+				send(feedMessage{Type: "end", SimTime: session.sim.SimTime()})
+				return
+			}
+
+			if events := sub.Get(); len(events) > 0 {
+				msg := feedMessage{
+					Type:    "events",
+					SimTime: session.sim.SimTime(),
+					Events:  makeAPIEvents(session.sim.PrepareAllRadioTransmissions(events)),
+				}
+				if !send(msg) {
+					return
+				}
+			}
+
+		case <-positionTick.C:
+			simTime, tracks := session.sim.GetTracks()
+			if !send(feedMessage{Type: "positions", SimTime: simTime, Tracks: tracks}) {
+				return
+			}
+		}
+	}
+}
+
+// sessionRunning returns true if the given session hasn't been terminated.
+func (sm *SimManager) sessionRunning(session *simSession) bool {
+	sm.mu.Lock(sm.lg)
+	defer sm.mu.Unlock(sm.lg)
+
+	return sm.sessionsByName[session.name] == session
+}
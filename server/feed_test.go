@@ -0,0 +1,78 @@
+// server/feed_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mmp/vice/sim"
+
+	"golang.org/x/net/websocket"
+)
+
+func TestFeed(t *testing.T) {
+	sm := makeTestSimManager("")
+	session := makeSimSession("test", "", "", "", makeTestSim(sm.lg), sm.lg)
+	var result NewSimResult
+	if err := sm.Add(session, &result, "1A", "AB", false, false); err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	sm.registerAPIHandlers(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/api/v1/feed?sim=test&interval=0.25"
+	ws, err := websocket.Dial(url, "", srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+
+	receive := func(typ string) feedMessage {
+		t.Helper()
+
+		ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+		for {
+			var msg feedMessage
+			if err := websocket.JSON.Receive(ws, &msg); err != nil {
+				t.Fatalf("waiting for %q message: %v", typ, err)
+			}
+			if msg.Type == typ {
+				return msg
+			}
+		}
+	}
+
+	// Positions are sent once the feed has subscribed to the sim's
+	// events, so anything that happens after the first one is seen.
+	pos := receive("positions")
+	if len(pos.Tracks) != 2 || pos.Tracks[0].ADSBCallsign != "AAL1" || pos.Tracks[1].ADSBCallsign != "DAL2" {
+		t.Errorf("unexpected tracks %+v", pos.Tracks)
+	}
+
+	call(t, sm, RunAircraftCommandsRPC, &AircraftCommandsArgs{ControllerToken: result.ControllerToken,
+		Callsign: "DAL2", Commands: "D40"})
+
+	for {
+		msg := receive("events")
+		for _, e := range msg.Events {
+			if e.Type != sim.RadioTransmissionEvent {
+				continue
+			}
+			// The readback should be sent as the controller would hear
+			// it, with the callsign at the end.
+			if !strings.Contains(e.WrittenText, "Delta 2") {
+				t.Errorf("readback %q is missing the callsign", e.WrittenText)
+			}
+			return
+		}
+	}
+}
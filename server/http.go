@@ -47,6 +47,8 @@ func (sm *SimManager) launchHTTPServer() {
 		sm.lg.Infof("%s: served stats request", r.URL.String())
 	})
 
+	sm.registerAPIHandlers(mux)
+
 	mux.HandleFunc("/debug/pprof/", pprof.Index)
 	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
 	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
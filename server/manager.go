@@ -46,6 +46,7 @@ type SimManager struct {
 	startTime time.Time
 	httpPort  int
 	local     bool
+	recordDir string // if set, sessions are recorded to files here
 }
 
 // Client-side info about the available scenarios.
@@ -64,6 +65,7 @@ type ScenarioSpec struct {
 	PrimaryAirport          string
 	MagneticVariation       float32
 	WindSpecifier           *wx.WindSpecifier
+	ScriptedWeather         bool
 
 	LaunchConfig sim.LaunchConfig
 
@@ -86,7 +88,8 @@ func (s *ScenarioSpec) AllAirports() []string {
 // Constructor and Initialization
 
 func NewSimManager(scenarioGroups map[string]map[string]*scenarioGroup, scenarioCatalogs map[string]map[string]*ScenarioCatalog,
-	mapManifests map[string]*sim.VideoMapManifest, serverAddress string, isLocal bool, lg *log.Logger) *SimManager {
+	mapManifests map[string]*sim.VideoMapManifest, serverAddress string, isLocal bool, recordDir string,
+	lg *log.Logger) *SimManager {
 	sm := &SimManager{
 		scenarioGroups:   scenarioGroups,
 		scenarioCatalogs: scenarioCatalogs,
@@ -95,6 +98,7 @@ func NewSimManager(scenarioGroups map[string]map[string]*scenarioGroup, scenario
 		mapManifests:     mapManifests,
 		startTime:        time.Now(),
 		local:            isLocal,
+		recordDir:        recordDir,
 		providersReady:   make(chan struct{}),
 		lg:               lg,
 	}
@@ -125,6 +129,17 @@ func (sm *SimManager) getWXProvider() wx.Provider {
 	return sm.wxProvider
 }
 
+// sessionWXProvider returns the weather provider to use for a new session.
+// If sessions are being recorded, it is wrapped so that the weather that
+// the sim uses is saved along with the recording.
+func (sm *SimManager) sessionWXProvider() wx.Provider {
+	wxp := sm.getWXProvider()
+	if sm.recordDir != "" && wxp != nil {
+		return &recordingWXProvider{Provider: wxp}
+	}
+	return wxp
+}
+
 ///////////////////////////////////////////////////////////////////////////
 // Session Management - Creating and Connecting to Sims
 
@@ -176,6 +191,8 @@ type SimState struct {
 
 	UserIsPrivileged bool // Whether this user has elevated privileges (can control any aircraft)
 
+	Replay *ReplayInfo // non-nil if the sim is playing back a recording
+
 	FlightStripACIDs []sim.ACID
 }
 
@@ -194,6 +211,7 @@ func (sm *SimManager) NewSim(req *NewSimRequest, result *NewSimResult) error {
 		manifest := sm.mapManifests[nsc.FacilityAdaptation.VideoMapFile]
 		s := sim.NewSim(*nsc, manifest, lg)
 		session := makeSimSession(req.NewSimName, req.GroupName, req.ScenarioName, req.Password, s, sm.lg)
+		session.wxProvider = nsc.WXProvider
 		pos := s.ScenarioRootPosition()
 		return sm.Add(session, result, pos, req.Initials, req.Privileged, true)
 	} else {
@@ -220,7 +238,7 @@ func (sm *SimManager) makeSimConfiguration(req *NewSimRequest, lg *log.Logger) *
 
 	description := util.Select(sm.local, " "+req.ScenarioName, "@"+req.NewSimName+": "+req.ScenarioName)
 
-	wxp := sm.getWXProvider()
+	wxp := sm.sessionWXProvider()
 
 	nsc := sim.NewSimConfiguration{
 		TFRs:                        req.TFRs,
@@ -231,12 +249,16 @@ func (sm *SimManager) makeSimConfiguration(req *NewSimRequest, lg *log.Logger) *
 		PilotErrorInterval:          req.PilotErrorInterval,
 		DepartureRunways:            sc.DepartureRunways,
 		ArrivalRunways:              sc.ArrivalRunways,
+		RunwayConfigurations:        sg.runwayConfigurations(sc.ControllerConfiguration),
+		RunwayConfiguration:         req.ScenarioName,
 		VFRReportingPoints:          sg.VFRReportingPoints,
 		ReportingPoints:             sg.ReportingPoints,
 		Description:                 description,
 		MagneticVariation:           sg.MagneticVariation,
 		NmPerLongitude:              sg.NmPerLongitude,
 		WindSpecifier:               sc.WindSpecifier,
+		ScriptedWeather:             sc.ScriptedWeather,
+		ATISRemarks:                 sc.ATISRemarks,
 		Airports:                    sg.Airports,
 		Fixes:                       sg.Fixes,
 		PrimaryAirport:              sg.PrimaryAirport,
@@ -268,11 +290,16 @@ type JoinSimRequest struct {
 	Password        string
 	Privileged      bool
 	JoiningAsRelief bool
+	Observer        bool // Join without signing on to a TCW
+	PseudoPilot     bool // Join as a pseudo-pilot, flying the aircraft on SelectedTCPs' frequencies
 }
 
 const ConnectToSimRPC = "SimManager.ConnectToSim"
 
 func (sm *SimManager) ConnectToSim(req *JoinSimRequest, result *NewSimResult) error {
+	// Sign-ons are recorded along with the session's RPCs.
+	defer sm.lockSessionRecorder(func() *simSession { return sm.sessionsByName[req.SimName] })()
+
 	sm.mu.Lock(sm.lg)
 	defer sm.mu.Unlock(sm.lg)
 
@@ -289,7 +316,19 @@ func (sm *SimManager) ConnectToSim(req *JoinSimRequest, result *NewSimResult) er
 
 	var token string
 	var eventSub *sim.EventsSubscription
-	if req.JoiningAsRelief {
+	if req.Observer {
+		// Observers aren't at a TCW; they just get a token so that they
+		// can follow the sim's state and events.
+		tcw = ""
+		token = sm.makeControllerToken()
+		eventSub = session.sim.Subscribe()
+	} else if req.PseudoPilot {
+		var err error
+		token, tcw, eventSub, err = sm.signOnPseudoPilot(session, req)
+		if err != nil {
+			return err
+		}
+	} else if req.JoiningAsRelief {
 		// Relief mode: don't call sim.SignOn (position already signed in)
 		// Just generate a token for this user
 		token = sm.makeControllerToken()
@@ -313,6 +352,16 @@ func (sm *SimManager) ConnectToSim(req *JoinSimRequest, result *NewSimResult) er
 	session.AddHumanController(token, tcw, req.Initials, eventSub)
 	sm.sessionsByToken[token] = session
 
+	if rec := session.Recorder(); rec != nil && !req.Observer && !req.JoiningAsRelief {
+		rec.recordSignOn(session.sim, RecordedSignOn{
+			TCW:          tcw,
+			Initials:     req.Initials,
+			SelectedTCPs: req.SelectedTCPs,
+			Privileged:   req.Privileged,
+			PseudoPilot:  req.PseudoPilot,
+		})
+	}
+
 	*result = *sm.buildNewSimResult(session, tcw, token)
 
 	return nil
@@ -346,7 +395,7 @@ func (sm *SimManager) checkTCWAvailable(ss *simSession, tcw sim.TCW) error {
 func (sm *SimManager) buildNewSimResult(session *simSession, tcw sim.TCW, token string) *NewSimResult {
 	videoMaps, defaultMaps, beaconCodes := session.sim.GetControllerVideoMaps(tcw)
 
-	return &NewSimResult{
+	result := &NewSimResult{
 		SimState: &SimState{
 			UserState:                           *session.sim.GetUserState(),
 			UserTCW:                             tcw,
@@ -358,6 +407,10 @@ func (sm *SimManager) buildNewSimResult(session *simSession, tcw sim.TCW, token
 		},
 		ControllerToken: token,
 	}
+	if session.replay != nil {
+		result.SimState.Replay = session.replay.Info()
+	}
+	return result
 }
 
 const AddLocalRPC = "SimManager.AddLocal"
@@ -369,6 +422,7 @@ type AddLocalRequest struct {
 
 func (sm *SimManager) AddLocal(req *AddLocalRequest, result *NewSimResult) error {
 	session := makeLocalSimSession(req.Sim, sm.lg)
+	session.wxProvider = sm.sessionWXProvider()
 	if !sm.local {
 		sm.lg.Errorf("Called AddLocal with sm.local == false")
 	}
@@ -377,16 +431,23 @@ func (sm *SimManager) AddLocal(req *AddLocalRequest, result *NewSimResult) error
 
 func (sm *SimManager) Add(session *simSession, result *NewSimResult, initialTCP sim.ControlPosition, initials string, instructor bool,
 	prespawn bool) error {
-	wxp := sm.getWXProvider()
+	wxp := session.wxProvider
+	if wxp == nil {
+		wxp = sm.getWXProvider()
+	}
 	session.sim.Activate(session.lg, wxp)
 
 	sm.mu.Lock(sm.lg)
 
 	// Empty sim name is just a local sim, so no problem with replacing it...
-	if _, ok := sm.sessionsByName[session.name]; ok && session.name != "" {
+	prev, ok := sm.sessionsByName[session.name]
+	if ok && session.name != "" {
 		sm.mu.Unlock(sm.lg)
 		return ErrDuplicateSimName
 	}
+	if ok {
+		prev.stopRecording()
+	}
 
 	sm.lg.Infof("%s: adding sim", session.name)
 	sm.sessionsByName[session.name] = session
@@ -413,6 +474,15 @@ func (sm *SimManager) Add(session *simSession, result *NewSimResult, initialTCP
 		session.sim.Prespawn()
 	}
 
+	// Similarly, start recording once the sim is ready to go.
+	if sm.recordDir != "" && session.replay == nil {
+		if rec, err := startRecording(sm.recordDir, session, tcw, initials, sm.lg); err != nil {
+			sm.lg.Errorf("unable to start session recording: %v", err)
+		} else {
+			session.setRecorder(rec)
+		}
+	}
+
 	go sm.runSimUpdateLoop(session)
 
 	// Get the state after prespawn (if any) has completed.
@@ -433,13 +503,20 @@ func (sm *SimManager) runSimUpdateLoop(session *simSession) {
 			session.CullIdleControllers(sm)
 		}
 
-		session.sim.Update()
+		if session.replay != nil {
+			session.replay.Update()
+		} else if rec := session.Recorder(); rec != nil {
+			rec.updateSim(session.sim)
+		} else {
+			session.sim.Update()
+		}
 
 		time.Sleep(100 * time.Millisecond)
 	}
 
 	sm.lg.Infof("%s: terminating sim after %s idle", session.name, session.sim.IdleTime())
 
+	session.stopRecording()
 	session.sim.Destroy()
 
 	sm.mu.Lock(sm.lg)
@@ -457,6 +534,9 @@ func (sm *SimManager) runSimUpdateLoop(session *simSession) {
 // Session Management - Sign On/Off
 
 func (sm *SimManager) SignOff(token string) error {
+	// Sign-offs are recorded along with the session's RPCs.
+	defer sm.lockSessionRecorder(func() *simSession { return sm.sessionsByToken[token] })()
+
 	sm.mu.Lock(sm.lg)
 	defer sm.mu.Unlock(sm.lg)
 
@@ -476,39 +556,53 @@ func (sm *SimManager) signOff(token string) error {
 		return ErrNoSimForControllerToken
 	}
 
-	// If this was the last user at the TCW, post messages and clear privileges
-	if result.UsersAtTCW == 0 {
-		// Get positions for the uncovered message
-		uncoveredPositions := session.sim.GetPositionsForTCW(result.TCW)
+	// If this was the last user at the TCW, release it. (Observers don't
+	// have a TCW.)
+	if result.UsersAtTCW == 0 && result.TCW != "" {
+		sm.releaseTCW(session, result.TCW, result.Initials)
+
+		if rec := session.Recorder(); rec != nil {
+			rec.recordSignOn(session.sim, RecordedSignOn{TCW: result.TCW, Initials: result.Initials, SignOff: true})
+		}
+	}
+
+	return nil
+}
+
+// releaseTCW clears the privileges of a TCW that the last controller has
+// signed off from and posts messages about it.
+func (sm *SimManager) releaseTCW(session *simSession, tcw sim.TCW, initials string) {
+	// Get positions for the uncovered message
+	uncoveredPositions := session.sim.GetPositionsForTCW(tcw)
 
-		// Clear privileged status
-		session.sim.SetPrivilegedTCW(result.TCW, false)
+	// Clear privileged status
+	session.sim.SetPrivilegedTCW(tcw, false)
+	if session.sim.IsPseudoPilot(tcw) {
+		session.sim.SignOffPseudoPilot(tcw)
+	}
+
+	msg := string(tcw)
+	if initials != "" {
+		msg += " (" + initials + ")"
+	}
+	msg += " has signed off."
+	session.sim.PostEvent(sim.Event{
+		Type:        sim.StatusMessageEvent,
+		WrittenText: msg,
+	})
 
-		msg := string(result.TCW)
-		if result.Initials != "" {
-			msg += " (" + result.Initials + ")"
+	// If there are uncovered positions, post an error message
+	if len(uncoveredPositions) > 0 {
+		tcpStrs := make([]string, len(uncoveredPositions))
+		for i, tcp := range uncoveredPositions {
+			tcpStrs[i] = string(tcp)
 		}
-		msg += " has signed off."
+		slices.Sort(tcpStrs)
 		session.sim.PostEvent(sim.Event{
-			Type:        sim.StatusMessageEvent,
-			WrittenText: msg,
+			Type:        sim.ErrorMessageEvent,
+			WrittenText: "Uncovered positions: " + strings.Join(tcpStrs, ", "),
 		})
-
-		// If there are uncovered positions, post an error message
-		if len(uncoveredPositions) > 0 {
-			tcpStrs := make([]string, len(uncoveredPositions))
-			for i, tcp := range uncoveredPositions {
-				tcpStrs[i] = string(tcp)
-			}
-			slices.Sort(tcpStrs)
-			session.sim.PostEvent(sim.Event{
-				Type:        sim.ErrorMessageEvent,
-				WrittenText: "Uncovered positions: " + strings.Join(tcpStrs, ", "),
-			})
-		}
 	}
-
-	return nil
 }
 
 // assume SimManager lock is held
@@ -536,6 +630,27 @@ func (sm *SimManager) signOn(ss *simSession, req *JoinSimRequest) (string, *sim.
 	return sm.makeControllerToken(), eventSub, nil
 }
 
+// signOnPseudoPilot signs on a pseudo-pilot; their TCW is assigned by the
+// sim rather than taken from the request.
+func (sm *SimManager) signOnPseudoPilot(ss *simSession, req *JoinSimRequest) (string, sim.TCW, *sim.EventsSubscription, error) {
+	tcw, eventSub, err := ss.sim.SignOnPseudoPilot(req.Initials, req.SelectedTCPs)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	msg := string(tcw) + " (" + req.Initials + ") has signed on as a pseudo-pilot"
+	if len(req.SelectedTCPs) > 0 {
+		msg += " for aircraft on " + strings.Join(util.MapSlice(req.SelectedTCPs, func(p sim.TCP) string { return string(p) }), ", ")
+	}
+	msg += "."
+	ss.sim.PostEvent(sim.Event{
+		Type:        sim.StatusMessageEvent,
+		WrittenText: msg,
+	})
+
+	return sm.makeControllerToken(), tcw, eventSub, nil
+}
+
 ///////////////////////////////////////////////////////////////////////////
 // Controller Lookup and State Updates
 
@@ -613,6 +728,7 @@ type controllerContext struct {
 	sim      *sim.Sim
 	eventSub *sim.EventsSubscription
 	session  *simSession
+	conn     *connectionState
 }
 
 func (sm *SimManager) LookupController(token string) *controllerContext {
@@ -658,6 +774,11 @@ func (su *SimStateUpdate) Apply(state *SimState, eventStream *sim.EventStream) {
 		state.DynamicState = su.DynamicState
 		state.DerivedState = su.DerivedState
 	}
+	// The ATIS is only sent when it changes, so it's applied even if the
+	// rest of the update is stale.
+	if su.ATIS != nil {
+		state.ATISState = *su.ATIS
+	}
 
 	state.ActiveTCWs = su.ActiveTCWs
 	state.FlightStripACIDs = su.FlightStripACIDs
@@ -672,11 +793,7 @@ func (su *SimStateUpdate) Apply(state *SimState, eventStream *sim.EventStream) {
 
 // GetStateUpdate fills in a server.SimStateUpdate with both sim state and human controllers.
 func (c *controllerContext) GetStateUpdate() SimStateUpdate {
-	return SimStateUpdate{
-		StateUpdate: c.sim.GetStateUpdate(c.tcw),
-		ActiveTCWs:  c.session.GetActiveTCWs(),
-		Events:      c.sim.PrepareRadioTransmissionsForTCW(c.tcw, c.eventSub.Get()),
-	}
+	return c.session.makeStateUpdate(c.conn)
 }
 
 const GetSerializeSimRPC = "SimManager.GetSerializeSim"
@@ -0,0 +1,916 @@
+// server/recording.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package server
+
+import (
+	"compress/flate"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/rpc"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mmp/vice/log"
+	"github.com/mmp/vice/sim"
+	"github.com/mmp/vice/util"
+	"github.com/mmp/vice/wx"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Session recordings are a flate-compressed msgpack stream: a
+// RecordingHeader followed by RecordedEntry items until the end of the
+// file. The header holds a full copy of the sim at the start of the
+// recording, which includes the state of its random number generator;
+// the entries are the RPCs that controllers issued, controllers signing
+// on and off, and the atmospheric data that the weather model fetched.
+// Recorded calls are run one at a time between sim updates and the sim's
+// randomness all comes from its own generator, so applying the entries in
+// order at the sim times they were recorded at reproduces the session.
+
+// RecordingHeader is stored at the start of a session recording.
+type RecordingHeader struct {
+	Version  int
+	Created  time.Time
+	TCW      sim.TCW
+	Initials string
+	Sim      *sim.Sim
+	Atmos    []RecordedAtmos // fetched before the recording started
+}
+
+// RecordedEntry is a single item in a recording after the header. Entries
+// with neither a call nor atmospheric data are written periodically to
+// mark the passage of sim time.
+type RecordedEntry struct {
+	SimTime time.Time
+	Call    *RecordedCall
+	SignOn  *RecordedSignOn
+	Atmos   *RecordedAtmos
+}
+
+type RecordedCall struct {
+	Method string // e.g., "Sim.RunAircraftCommands"
+	TCW    sim.TCW
+	Args   []byte // msgpack-encoded arguments
+	Error  string // error returned when the call was made, if any
+}
+
+// RecordedSignOn records a controller signing on to a TCW or, if SignOff
+// is set, the last controller at a TCW signing off. Observers and relief
+// controllers don't change the sim's state, so they aren't recorded.
+type RecordedSignOn struct {
+	TCW          sim.TCW // assigned by the sim for pseudo-pilots
+	Initials     string
+	SelectedTCPs []sim.TCP
+	Privileged   bool
+	PseudoPilot  bool
+	SignOff      bool
+}
+
+type RecordedAtmos struct {
+	Facility    string
+	RequestTime time.Time
+	Atmos       *wx.AtmosByPointSOA
+	Time        time.Time
+	NextTime    time.Time
+}
+
+// How often a time marker is written to the recording when no calls are
+// being made.
+const recordingTickInterval = time.Minute
+
+///////////////////////////////////////////////////////////////////////////
+// Recording
+
+type recorder struct {
+	// callMu is held while a recorded RPC executes and its entry is
+	// written, while a sign-on or sign-off is handled, and while the sim
+	// is updated. Thus, the entries are written in the order in which
+	// they took effect, each with the sim time at which it did. It must
+	// be acquired before SimManager.mu.
+	callMu sync.Mutex
+
+	mu       sync.Mutex
+	filename string
+	f        *os.File
+	fw       *flate.Writer
+	enc      *msgpack.Encoder
+	lastTick time.Time
+	lg       *log.Logger
+}
+
+// startRecording creates a new recording file in dir and writes its header
+// with the current state of the session's sim.
+func startRecording(dir string, session *simSession, tcw sim.TCW, initials string, lg *log.Logger) (*recorder, error) {
+	s := session.sim.Snapshot()
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	fn := fmt.Sprintf("vice-%s-%s.rec", s.State.Facility, time.Now().Format("20060102-150405"))
+	fn = filepath.Join(dir, fn)
+	f, err := os.Create(fn)
+	if err != nil {
+		return nil, err
+	}
+	fw, err := flate.NewWriter(f, flate.BestSpeed)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	r := &recorder{
+		filename: fn,
+		f:        f,
+		fw:       fw,
+		enc:      msgpack.NewEncoder(fw),
+		lastTick: s.State.SimTime,
+		lg:       lg,
+	}
+
+	// Hold the lock while the header is written so that atmospheric data
+	// that arrives in the meantime comes after it.
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hdr := RecordingHeader{
+		Version:  ViceSerializeVersion,
+		Created:  time.Now(),
+		TCW:      tcw,
+		Initials: initials,
+		Sim:      s,
+	}
+	if rwx, ok := session.wxProvider.(*recordingWXProvider); ok {
+		hdr.Atmos = rwx.attach(r)
+	}
+
+	if err := r.enc.Encode(hdr); err != nil {
+		r.closeFile()
+		return nil, err
+	}
+	if err := r.fw.Flush(); err != nil {
+		r.closeFile()
+		return nil, err
+	}
+
+	lg.Info("started session recording", slog.String("filename", fn))
+
+	return r, nil
+}
+
+func (r *recorder) write(e RecordedEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.enc == nil {
+		return
+	}
+
+	// Flush after each entry so that as much as possible is saved if we
+	// crash.
+	err := r.enc.Encode(e)
+	if err == nil {
+		err = r.fw.Flush()
+	}
+	if err != nil {
+		r.lg.Errorf("%s: error writing recording: %v", r.filename, err)
+		r.closeFile()
+	}
+}
+
+// updateSim updates the sim, writing a time marker to the recording if
+// it's been a while since the last one.
+func (r *recorder) updateSim(s *sim.Sim) {
+	r.callMu.Lock()
+	defer r.callMu.Unlock()
+
+	s.Update()
+
+	if t := s.SimTime(); t.Sub(r.lastTick) >= recordingTickInterval {
+		r.write(RecordedEntry{SimTime: t})
+		r.lastTick = t
+	}
+}
+
+// recordSignOn records a controller signing on or off; callMu must be
+// held.
+func (r *recorder) recordSignOn(s *sim.Sim, so RecordedSignOn) {
+	r.write(RecordedEntry{SimTime: s.SimTime(), SignOn: &so})
+}
+
+// close finishes the recording; simTime is recorded as its end time.
+func (r *recorder) close(simTime time.Time) {
+	r.write(RecordedEntry{SimTime: simTime})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closeFile()
+}
+
+func (r *recorder) closeFile() {
+	if r.enc == nil {
+		return
+	}
+	if err := r.fw.Close(); err != nil {
+		r.lg.Errorf("%s: %v", r.filename, err)
+	}
+	if err := r.f.Close(); err != nil {
+		r.lg.Errorf("%s: %v", r.filename, err)
+	}
+	r.enc = nil
+}
+
+// RPCs that aren't recorded, in addition to ones that start with "Get":
+// they either don't change the sim's state or they control the flow of
+// time, which the replay viewer manages itself.
+var unrecordedRPCs = []string{SignOffRPC, TogglePauseRPC, SetSimRateRPC, SeekReplayRPC}
+
+func isRecordedRPC(method string) bool {
+	name, ok := strings.CutPrefix(method, "Sim.")
+	return ok && !strings.HasPrefix(name, "Get") && !slices.Contains(unrecordedRPCs, method)
+}
+
+// callRPC calls the given dispatcher method with the arguments, which
+// are given by a pointer to them. If the RPC modifies the state of a sim
+// that is being recorded, the call is recorded; if the sim is replaying a
+// recording, ErrReplayInProgress is returned instead.
+func (sm *SimManager) callRPC(method string, m reflect.Value, args reflect.Value) (any, error) {
+	if !isRecordedRPC(method) {
+		return callDispatcherMethod(m, args.Elem())
+	}
+
+	ctrl := sm.LookupController(controllerTokenForArgs(args))
+	if ctrl == nil {
+		return callDispatcherMethod(m, args.Elem())
+	}
+	if ctrl.session.replay != nil {
+		return nil, ErrReplayInProgress
+	}
+	rec := ctrl.session.Recorder()
+	if rec == nil {
+		return callDispatcherMethod(m, args.Elem())
+	}
+
+	b, err := msgpack.Marshal(args.Interface())
+	if err != nil {
+		sm.lg.Errorf("%s: unable to encode arguments for recording: %v", method, err)
+		return callDispatcherMethod(m, args.Elem())
+	}
+
+	rec.callMu.Lock()
+	defer rec.callMu.Unlock()
+
+	entry := RecordedEntry{
+		SimTime: ctrl.sim.SimTime(),
+		Call:    &RecordedCall{Method: method, TCW: ctrl.tcw, Args: b},
+	}
+
+	reply, err := callDispatcherMethod(m, args.Elem())
+
+	// Most contact requests come back empty; there's no need to record
+	// those.
+	if cr, ok := reply.(*RequestContactResult); !ok || cr.ContactText != "" {
+		if err != nil {
+			entry.Call.Error = err.Error()
+		}
+		rec.write(entry)
+	}
+
+	return reply, err
+}
+
+// lockSessionRecorder acquires the call lock of the recorder of the
+// session returned by lookup, which is called with sm.mu held, if it is
+// being recorded. It returns a function that releases the lock.
+func (sm *SimManager) lockSessionRecorder(lookup func() *simSession) func() {
+	sm.mu.Lock(sm.lg)
+	session := lookup()
+	sm.mu.Unlock(sm.lg)
+
+	if session != nil {
+		if rec := session.Recorder(); rec != nil {
+			rec.callMu.Lock()
+			return rec.callMu.Unlock
+		}
+	}
+	return func() {}
+}
+
+// RecordedRPCCall is the RPC that recordingServerCodec routes the RPCs
+// that may be recorded to so that they are run via SimManager.callRPC.
+const RecordedRPCCall = "RecordedRPC.Call"
+
+// recordedRPCs is registered with net/rpc as the "RecordedRPC" service.
+type recordedRPCs struct {
+	sm *SimManager
+}
+
+// RecordedRPCArgs holds a recorded RPC's dispatcher method and its
+// decoded arguments.
+type RecordedRPCArgs struct {
+	method string
+	m      reflect.Value
+	args   reflect.Value
+}
+
+func (r *recordedRPCs) Call(call *RecordedRPCArgs, reply *any) error {
+	defer r.sm.lg.CatchAndReportCrash()
+
+	if !call.m.IsValid() {
+		// The client called it directly rather than via the codec.
+		return fmt.Errorf("%s: can't be called directly", RecordedRPCCall)
+	}
+
+	var err error
+	*reply, err = r.sm.callRPC(call.method, call.m, call.args)
+	return err
+}
+
+// recordingServerCodec wraps a ServerCodec and routes the RPCs that may
+// be recorded to RecordedRPCCall; the response is sent as if the RPC had
+// been called directly.
+type recordingServerCodec struct {
+	rpc.ServerCodec
+	sm     *SimManager
+	method string        // recorded RPC whose body is being read, if any
+	m      reflect.Value // its dispatcher method
+
+	mu      sync.Mutex
+	methods map[uint64]string // original ServiceMethod of routed RPCs
+}
+
+func makeRecordingServerCodec(c rpc.ServerCodec, sm *SimManager) *recordingServerCodec {
+	return &recordingServerCodec{
+		ServerCodec: c,
+		sm:          sm,
+		methods:     make(map[uint64]string),
+	}
+}
+
+func (c *recordingServerCodec) ReadRequestHeader(r *rpc.Request) error {
+	err := c.ServerCodec.ReadRequestHeader(r)
+
+	c.method, c.m = "", reflect.Value{}
+	if err == nil && isRecordedRPC(r.ServiceMethod) {
+		if m, ok := lookupDispatcherMethod(c.sm, r.ServiceMethod); ok {
+			c.method, c.m = r.ServiceMethod, m
+
+			c.mu.Lock()
+			c.methods[r.Seq] = r.ServiceMethod
+			c.mu.Unlock()
+
+			r.ServiceMethod = RecordedRPCCall
+		}
+	}
+	return err
+}
+
+func (c *recordingServerCodec) ReadRequestBody(body any) error {
+	call, ok := body.(*RecordedRPCArgs)
+	if c.method == "" || !ok {
+		return c.ServerCodec.ReadRequestBody(body)
+	}
+
+	args := reflect.New(c.m.Type().In(0))
+	if err := c.ServerCodec.ReadRequestBody(args.Interface()); err != nil {
+		return err
+	}
+	*call = RecordedRPCArgs{method: c.method, m: c.m, args: args}
+	return nil
+}
+
+func (c *recordingServerCodec) WriteResponse(r *rpc.Response, body any) error {
+	c.mu.Lock()
+	if method, ok := c.methods[r.Seq]; ok {
+		r.ServiceMethod = method
+		delete(c.methods, r.Seq)
+	}
+	c.mu.Unlock()
+
+	return c.ServerCodec.WriteResponse(r, body)
+}
+
+// controllerTokenForArgs returns the controller token from an RPC's
+// arguments, which are either the token itself or a struct with a
+// ControllerToken field.
+func controllerTokenForArgs(v reflect.Value) string {
+	if f := controllerTokenField(v); f.IsValid() {
+		return f.String()
+	}
+	return ""
+}
+
+func controllerTokenField(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Struct {
+		v = v.FieldByName("ControllerToken")
+	}
+	if v.IsValid() && v.Kind() == reflect.String {
+		return v
+	}
+	return reflect.Value{}
+}
+
+// recordingWXProvider records the atmospheric data that is returned by
+// the provider it wraps.
+type recordingWXProvider struct {
+	wx.Provider
+
+	mu    sync.Mutex
+	atmos []RecordedAtmos // buffered until the recorder is attached
+	rec   *recorder
+}
+
+func (p *recordingWXProvider) GetAtmosGrid(facility string, t time.Time, primaryAirport string) (*wx.AtmosByPointSOA, time.Time, time.Time, error) {
+	atmos, atmosTime, nextTime, err := p.Provider.GetAtmosGrid(facility, t, primaryAirport)
+	if err != nil || atmos == nil {
+		return atmos, atmosTime, nextTime, err
+	}
+
+	ra := RecordedAtmos{
+		Facility:    facility,
+		RequestTime: t,
+		Atmos:       atmos,
+		Time:        atmosTime,
+		NextTime:    nextTime,
+	}
+
+	p.mu.Lock()
+	rec := p.rec
+	if rec == nil {
+		p.atmos = append(p.atmos, ra)
+	}
+	p.mu.Unlock()
+
+	if rec != nil {
+		rec.write(RecordedEntry{Atmos: &ra})
+	}
+
+	return atmos, atmosTime, nextTime, err
+}
+
+// attach causes subsequent atmospheric data to be written to the given
+// recorder; it returns the data that was fetched before then.
+func (p *recordingWXProvider) attach(r *recorder) []RecordedAtmos {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.rec = r
+	atmos := p.atmos
+	p.atmos = nil
+	return atmos
+}
+
+///////////////////////////////////////////////////////////////////////////
+// Replay
+
+// replayWXProvider returns the atmospheric data from a recording,
+// falling back to the given provider if there isn't any.
+type replayWXProvider struct {
+	fallback wx.Provider
+	atmos    []RecordedAtmos // sorted by Time
+}
+
+func (p *replayWXProvider) GetPrecipURL(facility string, t time.Time) (string, time.Time, error) {
+	if p.fallback == nil {
+		return "", time.Time{}, ErrWeatherUnavailable
+	}
+	return p.fallback.GetPrecipURL(facility, t)
+}
+
+func (p *replayWXProvider) GetAtmosGrid(facility string, t time.Time, primaryAirport string) (*wx.AtmosByPointSOA, time.Time, time.Time, error) {
+	// Prefer the result of the same request, then the latest that's
+	// before the requested time, then the earliest.
+	var best *RecordedAtmos
+	for i, ra := range p.atmos {
+		if ra.Facility != facility {
+			continue
+		}
+		if ra.RequestTime.Equal(t) {
+			best = &p.atmos[i]
+			break
+		}
+		if best == nil || !ra.Time.After(t) {
+			best = &p.atmos[i]
+		}
+	}
+
+	if best != nil {
+		return best.Atmos, best.Time, best.NextTime, nil
+	} else if p.fallback != nil {
+		return p.fallback.GetAtmosGrid(facility, t, primaryAirport)
+	}
+	return nil, time.Time{}, time.Time{}, ErrWeatherUnavailable
+}
+
+// IsLocal reports whether the recording has atmospheric data, in which
+// case the Model can use it without waiting for a fetch.
+func (p *replayWXProvider) IsLocal() bool {
+	return len(p.atmos) > 0
+}
+
+// ReplayInfo describes the recording that a replay sim is playing back.
+type ReplayInfo struct {
+	Filename string
+	Start    time.Time
+	End      time.Time
+}
+
+// Snapshots of the sim are taken at this interval during replay so that
+// seeking backward doesn't require replaying from the start.
+const replaySnapshotInterval = 5 * time.Minute
+
+// replayDriver runs a sim that is playing back a recording, applying the
+// recorded calls and sign-ons at the sim times at which they originally
+// happened.
+type replayDriver struct {
+	sm      *SimManager
+	session *simSession
+	info    ReplayInfo
+	tcw     sim.TCW         // TCW of the controller viewing the replay
+	calls   []RecordedEntry // calls and sign-ons
+
+	mu          sync.Mutex
+	token       string // viewer's controller token
+	connections map[sim.TCW]replayConnection
+	next        int // index of the next entry to apply
+	snapshots   []replaySnapshot
+	lastUpdate  time.Time
+	slop        time.Duration
+	warnedTCWs  map[sim.TCW]bool
+	warnedSync  bool
+	lg          *log.Logger
+}
+
+// replayConnection represents a controller other than the viewer who was
+// signed on to the sim in the recording; their calls are applied using
+// its token. No one consumes the events from its subscription, so they
+// are discarded as the replay runs.
+type replayConnection struct {
+	token    string
+	initials string
+	eventSub *sim.EventsSubscription
+}
+
+type replaySnapshot struct {
+	simTime     time.Time
+	next        int
+	sim         *sim.Sim
+	connections map[sim.TCW]replayConnection
+}
+
+// loadRecording reads the recording in the given file. If the file is
+// truncated (e.g., because vice crashed while recording), the entries
+// before that point are returned.
+func loadRecording(filename string, lg *log.Logger) (*RecordingHeader, []RecordedEntry, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	fr := flate.NewReader(f)
+	defer fr.Close()
+	dec := msgpack.NewDecoder(fr)
+
+	var hdr RecordingHeader
+	if err := dec.Decode(&hdr); err != nil {
+		return nil, nil, err
+	}
+	if hdr.Version != ViceSerializeVersion {
+		return nil, nil, fmt.Errorf("%s: recording is from an incompatible version of vice", filename)
+	}
+	if hdr.Sim == nil {
+		return nil, nil, fmt.Errorf("%s: recording doesn't have a sim", filename)
+	}
+
+	var entries []RecordedEntry
+	for {
+		var e RecordedEntry
+		if err := dec.Decode(&e); err != nil {
+			if !errors.Is(err, io.EOF) {
+				lg.Warnf("%s: recording truncated: %v", filename, err)
+			}
+			break
+		}
+		entries = append(entries, e)
+	}
+
+	return &hdr, entries, nil
+}
+
+func makeReplayDriver(sm *SimManager, session *simSession, filename string, hdr *RecordingHeader,
+	entries []RecordedEntry, lg *log.Logger) *replayDriver {
+	r := &replayDriver{
+		sm:          sm,
+		session:     session,
+		tcw:         hdr.TCW,
+		connections: make(map[sim.TCW]replayConnection),
+		warnedTCWs:  make(map[sim.TCW]bool),
+		lastUpdate:  time.Now(),
+		lg:          lg,
+	}
+
+	r.info = ReplayInfo{
+		Filename: filename,
+		Start:    hdr.Sim.State.SimTime,
+		End:      hdr.Sim.State.SimTime,
+	}
+	for _, e := range entries {
+		if e.Atmos != nil {
+			continue
+		}
+		if e.Call != nil || e.SignOn != nil {
+			r.calls = append(r.calls, e)
+		}
+		r.info.End = e.SimTime
+	}
+
+	return r
+}
+
+func (r *replayDriver) Info() *ReplayInfo {
+	info := r.info
+	return &info
+}
+
+// setToken sets the controller token used to apply recorded calls; the
+// replay doesn't start until it's been set.
+func (r *replayDriver) setToken(token string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.token = token
+	r.lastUpdate = time.Now()
+}
+
+// Update advances the replay according to the sim rate and the wallclock
+// time that has passed since it was last called.
+func (r *replayDriver) Update() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(r.lastUpdate)
+	r.lastUpdate = now
+
+	paused, rate := r.session.sim.RunState()
+	if paused || r.token == "" {
+		r.slop = 0
+		return
+	}
+
+	elapsed = time.Duration(rate*float32(elapsed)) + r.slop
+	ns := int(elapsed / time.Second)
+	r.slop = elapsed - time.Duration(ns)*time.Second
+	for range ns {
+		if !r.step() {
+			r.slop = 0
+			break
+		}
+	}
+}
+
+// Seek moves the replay to the given sim time by restoring the nearest
+// earlier snapshot and then running forward from it. Note that if the
+// sim was rewound to a checkpoint during the recording, sim time isn't
+// monotonic over the recording; seeking then goes to the first time the
+// sim was at the given time.
+func (r *replayDriver) Seek(t time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if t.Before(r.info.Start) {
+		t = r.info.Start
+	} else if t.After(r.info.End) {
+		t = r.info.End
+	}
+
+	r.maybeSnapshot()
+	idx := 0
+	for i, snap := range r.snapshots {
+		if !snap.simTime.After(t) {
+			idx = i
+		}
+	}
+	snap := r.snapshots[idx]
+	r.session.sim.RestoreSnapshot(snap.sim)
+	r.next = snap.next
+
+	r.sm.mu.Lock(r.sm.lg)
+	for tcw, c := range util.SortedMap(r.connections) {
+		r.disconnect(tcw, c)
+	}
+	for tcw, c := range util.SortedMap(snap.connections) {
+		r.connect(tcw, c.initials, r.sm.makeControllerToken(), r.session.sim.Subscribe())
+	}
+	r.sm.mu.Unlock(r.sm.lg)
+
+	for r.session.sim.SimTime().Before(t) && r.step() {
+	}
+
+	r.lastUpdate = time.Now()
+	r.slop = 0
+}
+
+// step applies the entries for the current sim time and then advances
+// the sim by a second. It returns false if the end of the recording has
+// been reached.
+func (r *replayDriver) step() bool {
+	r.maybeSnapshot()
+
+	// Recheck the time after each entry since a call may have restored a
+	// checkpoint.
+	for r.next < len(r.calls) {
+		e := r.calls[r.next]
+		if now := r.session.sim.SimTime(); e.SimTime.After(now) {
+			break
+		} else if e.SimTime.Before(now) && !r.warnedSync {
+			// The sim times of the entries are all reached exactly
+			// unless the replay has diverged from the recording.
+			r.lg.Warn("replay out of sync with recording", slog.Time("entry_time", e.SimTime),
+				slog.Time("sim_time", now))
+			r.warnedSync = true
+		}
+
+		r.next++
+		if e.SignOn != nil {
+			if err := r.applySignOn(e.SignOn); err != nil {
+				r.lg.Warn("replayed sign-on failed", slog.String("tcw", string(e.SignOn.TCW)), slog.Any("error", err))
+			}
+		} else if err := r.apply(e.Call); err != nil && e.Call.Error == "" {
+			r.lg.Warn("replayed call failed", slog.String("method", e.Call.Method), slog.Any("error", err))
+		}
+	}
+	for _, c := range r.connections {
+		c.eventSub.Get()
+	}
+
+	if r.next == len(r.calls) && !r.session.sim.SimTime().Before(r.info.End) {
+		return false
+	}
+
+	r.session.sim.Advance(time.Second)
+	return true
+}
+
+func (r *replayDriver) maybeSnapshot() {
+	t := r.session.sim.SimTime()
+	if n := len(r.snapshots); n == 0 || t.Sub(r.snapshots[n-1].simTime) >= replaySnapshotInterval {
+		r.snapshots = append(r.snapshots, replaySnapshot{
+			simTime:     t,
+			next:        r.next,
+			sim:         r.session.sim.Snapshot(),
+			connections: maps.Clone(r.connections),
+		})
+	}
+}
+
+// apply makes a recorded call using the dispatcher method for the RPC.
+func (r *replayDriver) apply(call *RecordedCall) error {
+	token := r.token
+	if call.TCW != r.tcw {
+		c, ok := r.connections[call.TCW]
+		if !ok {
+			if !r.warnedTCWs[call.TCW] {
+				r.lg.Warnf("%s: skipping calls from TCW that isn't signed on in replay", call.TCW)
+				r.warnedTCWs[call.TCW] = true
+			}
+			return nil
+		}
+		token = c.token
+	}
+
+	m, ok := lookupDispatcherMethod(r.sm, call.Method)
+	if !ok {
+		return fmt.Errorf("%s: unknown RPC", call.Method)
+	}
+
+	args := reflect.New(m.Type().In(0))
+	if err := msgpack.Unmarshal(call.Args, args.Interface()); err != nil {
+		return err
+	}
+	if f := controllerTokenField(args); f.IsValid() && f.CanSet() {
+		f.SetString(token)
+	}
+
+	_, err := callDispatcherMethod(m, args.Elem())
+	return err
+}
+
+// applySignOn signs a controller on or off as was done in the recording.
+func (r *replayDriver) applySignOn(so *RecordedSignOn) error {
+	sm := r.sm
+	sm.mu.Lock(sm.lg)
+	defer sm.mu.Unlock(sm.lg)
+
+	if so.SignOff {
+		if c, ok := r.connections[so.TCW]; ok {
+			r.disconnect(so.TCW, c)
+		}
+		sm.releaseTCW(r.session, so.TCW, so.Initials)
+		return nil
+	}
+
+	req := &JoinSimRequest{
+		TCW:          so.TCW,
+		Initials:     so.Initials,
+		SelectedTCPs: so.SelectedTCPs,
+		Privileged:   so.Privileged,
+	}
+	var token string
+	var eventSub *sim.EventsSubscription
+	var err error
+	tcw := so.TCW
+	if so.PseudoPilot {
+		token, tcw, eventSub, err = sm.signOnPseudoPilot(r.session, req)
+	} else {
+		token, eventSub, err = sm.signOn(r.session, req)
+	}
+	if err != nil {
+		return err
+	}
+
+	if tcw != r.tcw {
+		r.connect(tcw, so.Initials, token, eventSub)
+	} else {
+		eventSub.Unsubscribe()
+	}
+	if tcw != so.TCW {
+		return fmt.Errorf("%s: pseudo-pilot signed on as %s", so.TCW, tcw)
+	}
+	return nil
+}
+
+// connect and disconnect add and remove the session connections for
+// controllers other than the viewer; sm.mu must be held.
+func (r *replayDriver) connect(tcw sim.TCW, initials string, token string, eventSub *sim.EventsSubscription) {
+	r.session.AddHumanController(token, tcw, initials, eventSub)
+	r.sm.sessionsByToken[token] = r.session
+	r.connections[tcw] = replayConnection{token: token, initials: initials, eventSub: eventSub}
+}
+
+func (r *replayDriver) disconnect(tcw sim.TCW, c replayConnection) {
+	r.session.SignOff(c.token)
+	delete(r.sm.sessionsByToken, c.token)
+	delete(r.connections, tcw)
+}
+
+const LoadReplayRPC = "SimManager.LoadReplay"
+
+type LoadReplayRequest struct {
+	Filename string
+	Initials string
+}
+
+// LoadReplay starts a local sim that plays back the given recording.
+func (sm *SimManager) LoadReplay(req *LoadReplayRequest, result *NewSimResult) error {
+	if !sm.local {
+		return ErrReplayNotLocal
+	}
+
+	hdr, entries, err := loadRecording(req.Filename, sm.lg)
+	if err != nil {
+		return err
+	}
+
+	var atmos []RecordedAtmos
+	atmos = append(atmos, hdr.Atmos...)
+	for _, e := range entries {
+		if e.Atmos != nil {
+			atmos = append(atmos, *e.Atmos)
+		}
+	}
+	slices.SortStableFunc(atmos, func(a, b RecordedAtmos) int { return a.Time.Compare(b.Time) })
+
+	session := makeLocalSimSession(hdr.Sim, sm.lg)
+	session.wxProvider = &replayWXProvider{fallback: sm.getWXProvider(), atmos: atmos}
+	session.replay = makeReplayDriver(sm, session, req.Filename, hdr, entries, sm.lg)
+
+	initials := util.Select(req.Initials != "", req.Initials, hdr.Initials)
+	if err := sm.Add(session, result, sim.ControlPosition(hdr.TCW), initials, false, false); err != nil {
+		return err
+	}
+	session.replay.setToken(result.ControllerToken)
+
+	sm.lg.Info("replaying recording", slog.String("filename", req.Filename),
+		slog.Int("entries", len(session.replay.calls)))
+
+	return nil
+}
@@ -0,0 +1,236 @@
+// server/recording_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package server
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/log"
+	"github.com/mmp/vice/math"
+	"github.com/mmp/vice/nav"
+	"github.com/mmp/vice/rand"
+	"github.com/mmp/vice/sim"
+	"github.com/mmp/vice/util"
+	"github.com/mmp/vice/wx"
+)
+
+func makeTestSimManager(recordDir string) *SimManager {
+	providersReady := make(chan struct{})
+	close(providersReady)
+	return &SimManager{
+		sessionsByName:  make(map[string]*simSession),
+		sessionsByToken: make(map[string]*simSession),
+		local:           true,
+		recordDir:       recordDir,
+		providersReady:  providersReady,
+		lg:              log.New(false, "error", ""),
+	}
+}
+
+// makeTestSim returns a sim with two controllers, 1A and 1B, and an
+// aircraft on each of their frequencies. If any overflights are given,
+// they are launched automatically.
+func makeTestSim(lg *log.Logger, overflights ...av.Overflight) *sim.Sim {
+	if av.DB == nil {
+		av.DB = &av.StaticDatabase{}
+	}
+	if av.DB.Callsigns == nil {
+		av.DB.Callsigns = map[string]string{"AAL": "American", "DAL": "Delta"}
+	}
+	if av.DB.Airlines == nil {
+		av.DB.Airlines = map[string]av.Airline{"AAL": {ICAO: "AAL"}, "DAL": {ICAO: "DAL"}}
+	}
+	if av.DB.AircraftPerformance == nil {
+		av.DB.AircraftPerformance = map[string]av.AircraftPerformance{"B738": makeTestPerformance()}
+	}
+	if av.DB.Airports == nil {
+		av.DB.Airports = map[string]av.FAAAirport{
+			"KATL": {Id: "KATL", Location: math.Point2LL{-84.428, 33.637}},
+			"KCLT": {Id: "KCLT", Location: math.Point2LL{-80.943, 35.214}},
+		}
+	}
+
+	var launch sim.LaunchConfig
+	var flows map[string]*av.InboundFlow
+	if len(overflights) > 0 {
+		flows = map[string]*av.InboundFlow{"test": {Overflights: overflights}}
+		launch = sim.LaunchConfig{
+			OverflightMode:       sim.LaunchAutomatic,
+			InboundFlowRates:     map[string]map[string]float32{"test": {"overflights": 120}},
+			InboundFlowRateScale: 1,
+		}
+	}
+
+	start := time.Date(2025, time.November, 1, 12, 0, 0, 0, time.UTC)
+	s := sim.NewSim(sim.NewSimConfiguration{
+		Facility:     "TST",
+		InboundFlows: flows,
+		LaunchConfig: launch,
+		ControlPositions: map[sim.TCP]*av.Controller{
+			"1A": {Position: "1A", Frequency: 120000, RadioName: "Test Approach"},
+			"1B": {Position: "1B", Frequency: 125000, RadioName: "Test Approach"},
+		},
+		ControllerConfiguration: &sim.ControllerConfiguration{
+			DefaultConsolidation: sim.PositionConsolidation{"1A": {"1B"}},
+		},
+		ScriptedWeather: &wx.ScriptedWeather{},
+		StartTime:       start,
+		NmPerLongitude:  45,
+		Center:          math.Point2LL{-84, 34},
+		Range:           50,
+	}, nil, lg)
+
+	for i, tcp := range []sim.ControlPosition{"1A", "1B"} {
+		callsign := av.ADSBCallsign(util.Select(i == 0, "AAL1", "DAL2"))
+		ac := &sim.Aircraft{
+			ADSBCallsign:        callsign,
+			Mode:                av.TransponderModeAltitude,
+			ControllerFrequency: tcp,
+			FlightPlan:          av.FlightPlan{Rules: av.FlightRulesIFR, AircraftType: "B738"},
+			Nav: nav.Nav{
+				Rand: rand.Make(),
+				Perf: makeTestPerformance(),
+				FlightState: nav.FlightState{
+					Position:       math.Point2LL{-84, 34 + float32(i)/10},
+					Altitude:       8000,
+					Heading:        90,
+					IAS:            250,
+					GS:             250,
+					NmPerLongitude: 45,
+				},
+			},
+		}
+		ac.Nav.Rand.Seed(uint64(i + 1))
+		s.Aircraft[callsign] = ac
+	}
+
+	return s
+}
+
+func makeTestPerformance() av.AircraftPerformance {
+	var perf av.AircraftPerformance
+	perf.Ceiling = 41000
+	perf.Rate.Climb, perf.Rate.Descent = 2500, 2000
+	perf.Rate.Accelerate, perf.Rate.Decelerate = 5, 5
+	perf.Speed.Min, perf.Speed.Landing = 120, 140
+	perf.Speed.CruiseTAS, perf.Speed.MaxTAS = 450, 500
+	perf.Turn.MaxBankAngle, perf.Turn.MaxBankRate = 25, 3
+	return perf
+}
+
+// call makes an RPC as the HTTP API does, so that it is recorded if the
+// sim is being recorded.
+func call(t *testing.T, sm *SimManager, method string, args any) {
+	t.Helper()
+
+	m, ok := lookupDispatcherMethod(sm, method)
+	if !ok {
+		t.Fatalf("%s: unknown RPC", method)
+	}
+	v := reflect.New(m.Type().In(0))
+	v.Elem().Set(reflect.ValueOf(args))
+	if _, err := sm.callRPC(method, m, v); err != nil {
+		t.Fatalf("%s: %v", method, err)
+	}
+}
+
+func TestRecordingReplay(t *testing.T) {
+	dir := t.TempDir()
+	sm := makeTestSimManager(dir)
+
+	// Overflights are launched along with the two aircraft the sim starts
+	// with; their routes and altitudes are randomized.
+	entry := av.Waypoint{Fix: "ENTRY", Location: math.Point2LL{-84.5, 34}}
+	entry.InitExtra().Radius = 5
+	of := av.Overflight{
+		Waypoints:         av.WaypointArray{entry, {Fix: "EXIT", Location: math.Point2LL{-83.5, 34}}},
+		InitialAltitudes:  []int{11000, 13000, 15000, 17000},
+		CruiseAltitude:    17000,
+		InitialSpeed:      280,
+		InitialController: "1A",
+		Airlines: []av.OverflightAirline{{
+			AirlineSpecifier: av.AirlineSpecifier{ICAO: "AAL", AircraftTypes: []string{"B738"}},
+			DepartureAirport: "KATL",
+			ArrivalAirport:   "KCLT",
+		}},
+	}
+
+	session := makeLocalSimSession(makeTestSim(sm.lg, of), sm.lg)
+	var result NewSimResult
+	if err := sm.Add(session, &result, "1A", "AB", false, false); err != nil {
+		t.Fatal(err)
+	}
+	token1A := result.ControllerToken
+
+	// Keep the sim from advancing with the wallclock so that the only
+	// time that passes is from fast-forwarding.
+	call(t, sm, TogglePauseRPC, token1A)
+
+	// A second controller signs on at 1B and the two of them issue
+	// instructions to the aircraft on their frequencies.
+	if err := sm.ConnectToSim(&JoinSimRequest{TCW: "1B", Initials: "CD", SelectedTCPs: []sim.TCP{"1B"}}, &result); err != nil {
+		t.Fatal(err)
+	}
+	token1B := result.ControllerToken
+
+	call(t, sm, RunAircraftCommandsRPC, &AircraftCommandsArgs{ControllerToken: token1A, Callsign: "AAL1", Commands: "L180"})
+	call(t, sm, FastForwardRPC, token1A)
+	call(t, sm, RunAircraftCommandsRPC, &AircraftCommandsArgs{ControllerToken: token1B, Callsign: "DAL2", Commands: "D40"})
+	call(t, sm, FastForwardRPC, token1A)
+	call(t, sm, FastForwardRPC, token1B)
+	if err := sm.SignOff(token1B); err != nil {
+		t.Fatal(err)
+	}
+	call(t, sm, FastForwardRPC, token1A)
+
+	session.stopRecording()
+	want := session.sim.Snapshot()
+
+	if want.Aircraft["DAL2"].Nav.FlightState.Altitude >= 8000 {
+		t.Fatalf("DAL2 didn't descend")
+	}
+	if len(want.Aircraft) < 3 {
+		t.Fatalf("no overflights were launched")
+	}
+
+	// Replay the recording to its end.
+	files, err := filepath.Glob(filepath.Join(dir, "*.rec"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected one recording, got %v (%v)", files, err)
+	}
+
+	rsm := makeTestSimManager("")
+	if err := rsm.LoadReplay(&LoadReplayRequest{Filename: files[0]}, &result); err != nil {
+		t.Fatal(err)
+	}
+	replay := rsm.sessionsByToken[result.ControllerToken]
+	replay.replay.Seek(result.SimState.Replay.End)
+	got := replay.sim.Snapshot()
+
+	if !got.State.SimTime.Equal(want.State.SimTime) {
+		t.Errorf("replay ended at %s; expected %s", got.State.SimTime, want.State.SimTime)
+	}
+	for callsign, ac := range want.Aircraft {
+		rac, ok := got.Aircraft[callsign]
+		if !ok {
+			t.Errorf("%s: missing from replay", callsign)
+		} else if !reflect.DeepEqual(ac.Nav.FlightState, rac.Nav.FlightState) {
+			t.Errorf("%s: replayed flight state %+v doesn't match recorded %+v", callsign,
+				rac.Nav.FlightState, ac.Nav.FlightState)
+		} else if !reflect.DeepEqual(ac.Nav.Waypoints, rac.Nav.Waypoints) ||
+			!reflect.DeepEqual(ac.Nav.Altitude, rac.Nav.Altitude) {
+			t.Errorf("%s: replayed route %+v and altitude %+v don't match recorded %+v and %+v", callsign,
+				rac.Nav.Waypoints, rac.Nav.Altitude, ac.Nav.Waypoints, ac.Nav.Altitude)
+		}
+	}
+	if !reflect.DeepEqual(got.State.CurrentConsolidation, want.State.CurrentConsolidation) {
+		t.Errorf("replayed consolidation %+v doesn't match recorded %+v", got.State.CurrentConsolidation,
+			want.State.CurrentConsolidation)
+	}
+}
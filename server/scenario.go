@@ -74,6 +74,14 @@ type scenario struct {
 
 	WindSpecifier *wx.WindSpecifier `json:"wind,omitempty"`
 
+	// ScriptedWeather, if given, replaces real-world weather with
+	// synthetic weather.
+	ScriptedWeather *wx.ScriptedWeather `json:"scripted_weather,omitempty"`
+
+	// ATISRemarks gives NOTAM-style remarks (closed taxiways, bird
+	// activity, etc.) to include in airports' ATIS broadcasts.
+	ATISRemarks map[string][]string `json:"atis_remarks,omitempty"`
+
 	// Map from inbound flow names to a map from airport name to default rate,
 	// with "overflights" a special case to denote overflights
 	InboundFlowDefaultRates map[string]map[string]int `json:"inbound_rates"`
@@ -103,6 +111,32 @@ func (s *scenario) PostDeserialize(sg *scenarioGroup, e *util.ErrorLogger, manif
 		e.Pop()
 	}
 
+	if s.ScriptedWeather != nil {
+		e.Push(`"scripted_weather"`)
+		if s.WindSpecifier != nil {
+			e.ErrorString(`"wind" cannot be specified along with "scripted_weather"`)
+		}
+		if err := s.ScriptedWeather.Validate(); err != nil {
+			e.Error(err)
+		}
+		e.Pop()
+	}
+
+	for ap, remarks := range util.SortedMap(s.ATISRemarks) {
+		e.Push(`"atis_remarks"`)
+		if _, ok := sg.Airports[ap]; !ok {
+			e.ErrorString("airport %q not found in scenario group \"airports\"", ap)
+		}
+		for _, rmk := range remarks {
+			// Remarks are passed through to the radio transmission
+			// formatting code, so don't allow its special characters.
+			if strings.ContainsAny(rmk, "[]{}") || strings.TrimSpace(rmk) == "" {
+				e.ErrorString("%q: invalid remark", rmk)
+			}
+		}
+		e.Pop()
+	}
+
 	// Validate configuration
 	if s.ControllerConfiguration == nil {
 		e.ErrorString(`"configuration" is required`)
@@ -735,6 +769,23 @@ func (sg *scenarioGroup) resolveControllerRefs() {
 	}
 }
 
+// runwayConfigurations returns the runway configurations of the
+// group's scenarios that share the given controller configuration; a
+// running sim may switch among them, e.g. if the wind changes.
+func (sg *scenarioGroup) runwayConfigurations(cc *sim.ControllerConfiguration) []sim.RunwayConfiguration {
+	var rc []sim.RunwayConfiguration
+	for name, sc := range util.SortedMap(sg.Scenarios) {
+		if cc != nil && sc.ControllerConfiguration != nil && sc.ControllerConfiguration.ConfigId == cc.ConfigId {
+			rc = append(rc, sim.RunwayConfiguration{
+				Name:             name,
+				DepartureRunways: sc.DepartureRunways,
+				ArrivalRunways:   sc.ArrivalRunways,
+			})
+		}
+	}
+	return rc
+}
+
 func (sg *scenarioGroup) Similar(fix string) []string {
 	d1, d2 := util.SelectInTwoEdits(fix, maps.Keys(sg.Fixes), nil, nil)
 	d1, d2 = util.SelectInTwoEdits(fix, maps.Keys(av.DB.Navaids), d1, d2)
@@ -1526,6 +1577,7 @@ func initializeSimConfigurations(sg *scenarioGroup, catalogs map[string]map[stri
 			PrimaryAirport:          sg.PrimaryAirport,
 			MagneticVariation:       sg.MagneticVariation,
 			WindSpecifier:           scenario.WindSpecifier,
+			ScriptedWeather:         scenario.ScriptedWeather != nil,
 		}
 
 		catalog.Scenarios[name] = spec
@@ -2173,6 +2225,8 @@ func CreateNewSimConfiguration(catalog *ScenarioCatalog, scenarioGroup *scenario
 		LaunchConfig:            CreateLaunchConfig(scenario, scenarioGroup),
 		DepartureRunways:        simConfig.DepartureRunways,
 		ArrivalRunways:          simConfig.ArrivalRunways,
+		RunwayConfigurations:    scenarioGroup.runwayConfigurations(scenario.ControllerConfiguration),
+		RunwayConfiguration:     scenarioName,
 		PrimaryAirport:          simConfig.PrimaryAirport,
 		Airports:                scenarioGroup.Airports,
 		Fixes:                   scenarioGroup.Fixes,
@@ -2185,6 +2239,8 @@ func CreateNewSimConfiguration(catalog *ScenarioCatalog, scenarioGroup *scenario
 		MagneticVariation:       scenarioGroup.MagneticVariation,
 		NmPerLongitude:          scenarioGroup.NmPerLongitude,
 		WindSpecifier:           scenario.WindSpecifier,
+		ScriptedWeather:         scenario.ScriptedWeather,
+		ATISRemarks:             scenario.ATISRemarks,
 		Center:                  util.Select(scenario.Center.IsZero(), scenarioGroup.FacilityAdaptation.Center, scenario.Center),
 		Range:                   util.Select(scenario.Range == 0, scenarioGroup.FacilityAdaptation.Range, scenario.Range),
 		DefaultMaps:             scenario.DefaultMaps,
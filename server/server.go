@@ -67,7 +67,23 @@ import (
 // 57: rework contact radio transmission management
 // 58: STT fin rev?
 // 59: server-side flightstrip management
-const ViceSerializeVersion = 59
+// 60: session scoring, sim checkpoints
+// 61: session recording and replay
+// 62: pseudo-pilots
+// 63: wake turbulence encounters
+// 64: TCAS
+// 65: weather deviations
+// 66: scripted weather
+// 67: runway configuration changes
+// 68: generated ATIS broadcasts
+// 69: pilot requests
+// 70: NORDO aircraft
+// 71: voice profiles
+// 72: ERAM conflict probe
+// 73: ERAM auxiliary views
+// 74: STARS track coasting
+// 75: FMA pairs, final approach blunders
+const ViceSerializeVersion = 75
 
 const ViceServerAddress = "vice.pharr.org"
 const ViceServerPort = 8000 - 50 + ViceRPCVersion
@@ -80,6 +96,7 @@ type ServerLaunchConfig struct {
 	ExtraVideoMap string
 	ServerAddress string // address to use for remote TTS provider
 	IsLocal       bool
+	RecordDir     string // if non-empty, sessions are recorded to this directory
 }
 
 func LaunchServer(config ServerLaunchConfig, lg *log.Logger) {
@@ -136,7 +153,8 @@ func makeServer(config ServerLaunchConfig, lg *log.Logger) (int, func(), util.Er
 	serverFunc := func() {
 		server := rpc.NewServer()
 
-		sm := NewSimManager(scenarioGroups, scenarioCatalogs, mapManifests, config.ServerAddress, config.IsLocal, lg)
+		sm := NewSimManager(scenarioGroups, scenarioCatalogs, mapManifests, config.ServerAddress, config.IsLocal,
+			config.RecordDir, lg)
 		if err := server.Register(sm); err != nil {
 			lg.Errorf("unable to register SimManager: %v", err)
 			os.Exit(1)
@@ -145,6 +163,10 @@ func makeServer(config ServerLaunchConfig, lg *log.Logger) (int, func(), util.Er
 			lg.Errorf("unable to register dispatcher: %v", err)
 			os.Exit(1)
 		}
+		if err := server.RegisterName("RecordedRPC", &recordedRPCs{sm: sm}); err != nil {
+			lg.Errorf("unable to register recorded RPCs: %v", err)
+			os.Exit(1)
+		}
 
 		lg.Infof("Listening on %+v", listener)
 
@@ -158,6 +180,7 @@ func makeServer(config ServerLaunchConfig, lg *log.Logger) (int, func(), util.Er
 			} else {
 				codec := util.MakeMessagepackServerCodec(cc, lg)
 				codec = util.MakeLoggingServerCodec(conn.RemoteAddr().String(), codec, lg)
+				codec = makeRecordingServerCodec(codec, sm)
 				go server.ServeCodec(codec)
 			}
 		}
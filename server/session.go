@@ -15,6 +15,7 @@ import (
 	"github.com/mmp/vice/log"
 	"github.com/mmp/vice/sim"
 	"github.com/mmp/vice/util"
+	"github.com/mmp/vice/wx"
 )
 
 ///////////////////////////////////////////////////////////////////////////
@@ -28,6 +29,11 @@ type simSession struct {
 	password           string
 	connectionsByToken map[string]*connectionState
 
+	// If set, used for the sim rather than the SimManager's provider.
+	wxProvider wx.Provider
+	recorder   *recorder     // non-nil if the session is being recorded
+	replay     *replayDriver // non-nil if the session plays back a recording
+
 	lg *log.Logger
 	mu util.LoggingMutex
 }
@@ -59,6 +65,7 @@ type connectionState struct {
 	lastUpdateCall      time.Time
 	warnedNoUpdateCalls bool
 	stateUpdateEventSub *sim.EventsSubscription
+	atisGeneration      int // ATISGeneration of the last ATIS sent to the client
 }
 
 ///////////////////////////////////////////////////////////////////////////
@@ -171,12 +178,47 @@ func (ss *simSession) updateSimPauseState() {
 // GetStateUpdate populates the update with session state.
 // This is the main entry point for periodic state updates from a controller.
 func (ss *simSession) GetStateUpdate(token string) *SimStateUpdate {
+	conn, ok := ss.touchConnection(token)
+	if !ok {
+		return nil
+	}
+
+	update := ss.makeStateUpdate(conn)
+	return &update
+}
+
+// makeStateUpdate returns the state update for the connection. The ATIS
+// is only included if it has changed since it was last sent to the
+// connection.
+func (ss *simSession) makeStateUpdate(conn *connectionState) SimStateUpdate {
+	ss.mu.Lock(ss.lg)
+	tcw, atisGeneration := conn.tcw, conn.atisGeneration
+	ss.mu.Unlock(ss.lg)
+
+	update := ss.sim.GetStateUpdate(tcw, atisGeneration)
+	if update.ATIS != nil {
+		ss.mu.Lock(ss.lg)
+		conn.atisGeneration = update.ATIS.ATISGeneration
+		ss.mu.Unlock(ss.lg)
+	}
+
+	return SimStateUpdate{
+		StateUpdate: update,
+		ActiveTCWs:  ss.GetActiveTCWs(),
+		Events:      ss.sim.PrepareRadioTransmissionsForTCW(tcw, conn.stateUpdateEventSub.Get()),
+	}
+}
+
+// touchConnection records that we've heard from the controller with the
+// given token so that they aren't signed off as idle. It returns the
+// controller's connection.
+func (ss *simSession) touchConnection(token string) (*connectionState, bool) {
 	ss.mu.Lock(ss.lg)
 	conn, ok := ss.connectionsByToken[token]
 	if !ok {
 		ss.mu.Unlock(ss.lg)
 		ss.lg.Errorf("%s: unknown token for sim", token)
-		return nil
+		return nil, false
 	}
 
 	// Update last call time and handle reconnection
@@ -190,15 +232,9 @@ func (ss *simSession) GetStateUpdate(token string) *SimStateUpdate {
 		})
 	}
 
-	tcw := conn.tcw
-	eventSub := conn.stateUpdateEventSub
 	ss.mu.Unlock(ss.lg)
 
-	return &SimStateUpdate{
-		StateUpdate: ss.sim.GetStateUpdate(tcw),
-		ActiveTCWs:  ss.GetActiveTCWs(),
-		Events:      ss.sim.PrepareRadioTransmissionsForTCW(tcw, eventSub.Get()),
-	}
+	return conn, true
 }
 
 // MakeControllerContext returns a ControllerContext for the given token, or nil if not found.
@@ -216,6 +252,31 @@ func (ss *simSession) MakeControllerContext(token string) *controllerContext {
 		sim:      ss.sim,
 		eventSub: conn.stateUpdateEventSub,
 		session:  ss,
+		conn:     conn,
+	}
+}
+
+// Recorder returns the session's recorder, or nil if it isn't being
+// recorded.
+func (ss *simSession) Recorder() *recorder {
+	ss.mu.Lock(ss.lg)
+	defer ss.mu.Unlock(ss.lg)
+
+	return ss.recorder
+}
+
+func (ss *simSession) setRecorder(r *recorder) {
+	ss.mu.Lock(ss.lg)
+	defer ss.mu.Unlock(ss.lg)
+
+	ss.recorder = r
+}
+
+// stopRecording finishes the session's recording, if it is being recorded.
+func (ss *simSession) stopRecording() {
+	if r := ss.Recorder(); r != nil {
+		r.close(ss.sim.SimTime())
+		ss.setRecorder(nil)
 	}
 }
 
@@ -266,13 +327,13 @@ func (ss *simSession) GetActiveTCWs() []sim.TCW {
 }
 
 // RequestContact pops the next pending contact for the TCW, generates the transmission
-// with current aircraft state, and returns text + voice name for client-side synthesis.
+// with current aircraft state, and returns text + voice for client-side synthesis.
 // Returns empty values if no contact is pending.
-func (ss *simSession) RequestContact(tcw sim.TCW) (text string, voiceName string, callsign av.ADSBCallsign, ty av.RadioTransmissionType) {
+func (ss *simSession) RequestContact(tcw sim.TCW) (text string, voice sim.Voice, callsign av.ADSBCallsign, ty av.RadioTransmissionType) {
 	// Get all positions controlled by this TCW (primary + consolidated secondaries)
 	cons := ss.sim.State.CurrentConsolidation[tcw]
 	if cons == nil {
-		return "", "", "", 0
+		return "", sim.Voice{}, "", 0
 	}
 	positions := cons.OwnedPositions()
 
@@ -280,7 +341,7 @@ func (ss *simSession) RequestContact(tcw sim.TCW) (text string, voiceName string
 	for {
 		pc := ss.sim.PopReadyContact(positions)
 		if pc == nil {
-			return "", "", "", 0
+			return "", sim.Voice{}, "", 0
 		}
 
 		// Generate the contact transmission with current aircraft state
@@ -290,8 +351,12 @@ func (ss *simSession) RequestContact(tcw sim.TCW) (text string, voiceName string
 			continue
 		}
 
-		voiceName := ss.sim.VoiceAssigner.GetVoice(pc.ADSBCallsign, ss.sim.Rand)
+		voice, err := ss.sim.VoiceAssigner.GetVoice(pc.ADSBCallsign)
+		if err != nil {
+			// Fall back to the TTS engine's default voice.
+			ss.lg.Errorf("%s: %v", pc.ADSBCallsign, err)
+		}
 
-		return spokenText, voiceName, pc.ADSBCallsign, av.RadioTransmissionContact
+		return spokenText, voice, pc.ADSBCallsign, av.RadioTransmissionContact
 	}
 }
@@ -7,7 +7,6 @@ package sim
 import (
 	"fmt"
 	"log/slog"
-	"maps"
 	"slices"
 	"strings"
 	"time"
@@ -84,10 +83,32 @@ type Aircraft struct {
 	SpacingGoAroundDeclined bool
 	// Set when going around on runway heading (vs a specific assigned heading).
 	GoAroundOnRunwayHeading bool
+	// Set when the aircraft went around after encountering wake
+	// turbulence on final; affects the contact message.
+	WakeGoAround bool
+	// When the aircraft last encountered wake turbulence; see wake.go.
+	LastWakeEncounter time.Time
 	// Set when the aircraft has gone around; prevents the arrival drop
 	// filter from dropping its flight plan.
 	WentAround bool
 
+	// Set while the aircraft is requesting or flying a deviation around
+	// weather; see deviation.go.
+	WXDeviation *WXDeviation
+
+	// The aircraft's pending request to the controller, if any, and when
+	// it will next consider making one; see requests.go.
+	PilotRequest         *PilotRequest
+	NextPilotRequestTime time.Time
+
+	// The most severe TCAS advisory the aircraft currently has and the
+	// intruder responsible for it; see checkTCAS().
+	TCASAdvisory nav.TCASAdvisory
+	TCASIntruder av.ADSBCallsign
+
+	// Set while the aircraft is below the MVA; see checkMSAW().
+	MSAWAlert bool
+
 	// Departure related state
 	DepartureContactAltitude float32 // 0 = waiting for /tc point, -1 = already contacted departure
 	ReportDepartureHeading   bool    // true if runway has multiple exit heading
@@ -104,6 +125,14 @@ type Aircraft struct {
 	WaitingForGoAhead bool
 
 	EmergencyState *EmergencyState
+	// Set if the aircraft has lost two-way radio communications; see
+	// nordo.go.
+	NORDO *NORDOState
+
+	// The most recent track with radar returns and, if the track is
+	// coasting, when the returns stopped; see coast.go.
+	LastRadarTrack av.RadarTrack
+	CoastStart     time.Time
 
 	LastRadioTransmission time.Time
 
@@ -114,6 +143,9 @@ type Aircraft struct {
 	// ATIS letter the aircraft reported during initial contact (e.g., "B").
 	// Empty if the pilot did not report having ATIS.
 	ReportedATIS string
+	// Set once an arrival has decided whether to ask for the current
+	// weather; see checkWeatherRequests().
+	AskedForWeather bool
 
 	// Traffic advisory state
 	TrafficInSight      bool      // True if aircraft has reported traffic in sight
@@ -389,7 +421,7 @@ func (ac *Aircraft) InterceptApproach(lg *log.Logger) av.CommandIntent {
 }
 
 func (ac *Aircraft) InitializeArrival(ap *av.Airport, arr *av.Arrival, nmPerLongitude float32, magneticVariation float32,
-	model *wx.Model, simTime time.Time, lg *log.Logger) error {
+	r *rand.Rand, model *wx.Model, simTime time.Time, lg *log.Logger) error {
 	ac.STAR = arr.STAR
 	ac.STARRunwayWaypoints = arr.RunwayWaypoints[ac.FlightPlan.ArrivalAirport]
 
@@ -402,7 +434,7 @@ func (ac *Aircraft) InitializeArrival(ap *av.Airport, arr *av.Arrival, nmPerLong
 	ac.FlightPlan.Altitude = int(arr.CruiseAltitude)
 	if ac.FlightPlan.Altitude == 0 { // unspecified
 		ac.FlightPlan.Altitude =
-			PlausibleFinalAltitude(ac.FlightPlan, perf, nmPerLongitude, magneticVariation, rand.Make())
+			PlausibleFinalAltitude(ac.FlightPlan, perf, nmPerLongitude, magneticVariation, r)
 	}
 	if arr.Route != "" {
 		ac.FlightPlan.Route = arr.Route
@@ -411,8 +443,8 @@ func (ac *Aircraft) InitializeArrival(ap *av.Airport, arr *av.Arrival, nmPerLong
 	}
 	ac.TypeOfFlight = av.FlightTypeArrival
 
-	nav := nav.MakeArrivalNav(ac.ADSBCallsign, arr, ac.FlightPlan, perf, nmPerLongitude, magneticVariation, model,
-		simTime, lg)
+	nav := nav.MakeArrivalNav(ac.ADSBCallsign, arr, ac.FlightPlan, perf, nmPerLongitude, magneticVariation,
+		makeNavRand(ac.ADSBCallsign, simTime), model, simTime, lg)
 	if nav == nil {
 		return fmt.Errorf("error initializing Nav")
 	}
@@ -431,9 +463,20 @@ func (ac *Aircraft) InitializeArrival(ap *av.Airport, arr *av.Arrival, nmPerLong
 	return nil
 }
 
+// makeNavRand returns the random number generator for an aircraft's Nav.
+// It is seeded from the callsign and the sim time so that runs with a
+// fixed seed and replays of recorded sessions are repeatable. (Both are
+// already determined by the sim's random number generator, which isn't
+// consumed here.)
+func makeNavRand(callsign av.ADSBCallsign, simTime time.Time) *rand.Rand {
+	r := rand.Make()
+	r.Seed(util.HashString64(string(callsign)) ^ uint64(simTime.UnixNano()))
+	return r
+}
+
 func (ac *Aircraft) InitializeDeparture(ap *av.Airport, departureAirport string, dep *av.Departure,
 	runway string, exitRoute av.ExitRoute, nmPerLongitude float32, magneticVariation float32,
-	model *wx.Model, simTime time.Time, lg *log.Logger) error {
+	r *rand.Rand, model *wx.Model, simTime time.Time, lg *log.Logger) error {
 	wp := util.DuplicateSlice(exitRoute.Waypoints)
 	wp = append(wp, dep.RouteWaypoints...)
 	wp = util.FilterSliceInPlace(wp, func(wp av.Waypoint) bool { return !wp.Location.IsZero() })
@@ -454,13 +497,16 @@ func (ac *Aircraft) InitializeDeparture(ap *av.Airport, departureAirport string,
 	ac.FlightPlan.Exit = dep.Exit
 	ac.FlightPlan.DepartureRunway = runway
 
-	r := rand.Make()
-	idx := rand.SampleFiltered(r, dep.Altitudes, func(alt int) bool { return alt <= int(perf.Ceiling) })
-	if idx == -1 {
-		ac.FlightPlan.Altitude =
-			PlausibleFinalAltitude(ac.FlightPlan, perf, nmPerLongitude, magneticVariation, r)
-	} else {
-		ac.FlightPlan.Altitude = dep.Altitudes[idx]
+	// The altitude is already set if the departure is being reinitialized
+	// after a runway change.
+	if ac.FlightPlan.Altitude == 0 {
+		idx := rand.SampleFiltered(r, dep.Altitudes, func(alt int) bool { return alt <= int(perf.Ceiling) })
+		if idx == -1 {
+			ac.FlightPlan.Altitude =
+				PlausibleFinalAltitude(ac.FlightPlan, perf, nmPerLongitude, magneticVariation, r)
+		} else {
+			ac.FlightPlan.Altitude = dep.Altitudes[idx]
+		}
 	}
 
 	ac.TypeOfFlight = av.FlightTypeDeparture
@@ -468,7 +514,7 @@ func (ac *Aircraft) InitializeDeparture(ap *av.Airport, departureAirport string,
 	randomizeAltitudeRange := ac.FlightPlan.Rules == av.FlightRulesVFR
 	nav := nav.MakeDepartureNav(ac.ADSBCallsign, ac.FlightPlan, perf, exitRoute.AssignedAltitude,
 		exitRoute.ClearedAltitude, exitRoute.SpeedRestriction, wp, randomizeAltitudeRange,
-		nmPerLongitude, magneticVariation, model, simTime, lg)
+		nmPerLongitude, magneticVariation, makeNavRand(ac.ADSBCallsign, simTime), model, simTime, lg)
 	if nav == nil {
 		return fmt.Errorf("error initializing Nav")
 	}
@@ -494,7 +540,8 @@ func (ac *Aircraft) InitializeVFRDeparture(ap *av.Airport, wps av.WaypointArray,
 
 	nav := nav.MakeDepartureNav(ac.ADSBCallsign, ac.FlightPlan, perf, 0, /* assigned alt */
 		ac.FlightPlan.Altitude /* cleared alt */, 0 /* speed restriction */, wp,
-		randomizeAltitudeRange, nmPerLongitude, magneticVariation, model, simTime, lg)
+		randomizeAltitudeRange, nmPerLongitude, magneticVariation, makeNavRand(ac.ADSBCallsign, simTime),
+		model, simTime, lg)
 	if nav == nil {
 		return fmt.Errorf("error initializing Nav")
 	}
@@ -505,7 +552,7 @@ func (ac *Aircraft) InitializeVFRDeparture(ap *av.Airport, wps av.WaypointArray,
 }
 
 func (ac *Aircraft) InitializeOverflight(of *av.Overflight, nmPerLongitude float32,
-	magneticVariation float32, model *wx.Model, simTime time.Time, lg *log.Logger) error {
+	magneticVariation float32, r *rand.Rand, model *wx.Model, simTime time.Time, lg *log.Logger) error {
 	perf, ok := av.DB.AircraftPerformance[ac.FlightPlan.AircraftType]
 	if !ok {
 		lg.Errorf("%s: unable to get performance model", ac.FlightPlan.AircraftType)
@@ -515,13 +562,13 @@ func (ac *Aircraft) InitializeOverflight(of *av.Overflight, nmPerLongitude float
 	ac.FlightPlan.Altitude = int(of.CruiseAltitude)
 	if ac.FlightPlan.Altitude == 0 { // unspecified
 		ac.FlightPlan.Altitude =
-			PlausibleFinalAltitude(ac.FlightPlan, perf, nmPerLongitude, magneticVariation, rand.Make())
+			PlausibleFinalAltitude(ac.FlightPlan, perf, nmPerLongitude, magneticVariation, r)
 	}
 	ac.FlightPlan.Route = of.Waypoints.RouteString()
 	ac.TypeOfFlight = av.FlightTypeOverflight
 
 	nav := nav.MakeOverflightNav(ac.ADSBCallsign, of, ac.FlightPlan, perf, nmPerLongitude,
-		magneticVariation, model, simTime, lg)
+		magneticVariation, makeNavRand(ac.ADSBCallsign, simTime), model, simTime, lg)
 	if nav == nil {
 		return fmt.Errorf("error initializing Nav")
 	}
@@ -763,98 +810,3 @@ func (ac *Aircraft) DivertToAirport(ap string) {
 
 	ac.Nav.DivertToAirport(ap)
 }
-
-///////////////////////////////////////////////////////////////////////////
-// VoiceAssigner
-
-// AirlineVoices maps airline ICAO codes (or comma-separated codes) to voice names.
-// "default" is used for callsigns that don't match any specific airline.
-// Edit this map to customize which voices are used for each airline.
-var AirlineVoices = map[string][]string{
-	"default": {
-		"af_alloy", "af_aoede", "af_bella", "af_heart", "af_nova", "af_kore",
-		"af_river", "af_sarah", "af_sky", "am_adam", "am_echo", "am_eric", "am_fenrir", "am_liam",
-		"am_michael", "am_onyx", "am_puck",
-	},
-	"BAW,VIR,DLH,KQA": {
-		"bf_alice", "bf_emma", "bf_isabella", "bf_lily",
-		"bm_daniel", "bm_fable", "bm_george", "bm_lewis",
-	},
-	"AMX,IBE": {
-		"ef_dora", "em_alex",
-	},
-	"AFR": {
-		"ff_siwis",
-	},
-	"ITY,LOT": {
-		"if_sara", "im_nicola",
-	},
-	"TAP,TAM,ELY": {
-		"pf_dora", "pm_alex", "pm_santa",
-	},
-	"AIC": {
-		"hf_alpha", "hf_beta", "hm_omega", "hm_psi",
-	},
-	"JAL,ANA,KAL": {
-		"jf_alpha", "jf_gongitsune", "jf_nezumi", "jf_tebukuro", "jm_kumo",
-	},
-	"CAL,CCA,CES,CSN,CXA,SIA": {
-		"zf_xiaobei", "zf_xiaoni", "zf_xiaoxiao", "zm_yunjian", "zm_yunxi", "zm_yunxia", "zm_yunyang",
-	},
-}
-
-// VoiceAssigner manages the pool of available TTS voice names and assigns them
-// to aircraft callsigns. Each aircraft gets a consistent voice throughout
-// the session.
-type VoiceAssigner struct {
-	// Same keys as AirlineVoices, shuffled and consumed FIFO.
-	VoicePools map[string][]string
-	// Callsign -> voice name mapping
-	AircraftVoices map[av.ADSBCallsign]string
-}
-
-// NewVoiceAssigner creates a new VoiceAssigner with airline-based voice pools.
-func NewVoiceAssigner(r *rand.Rand) *VoiceAssigner {
-	va := &VoiceAssigner{
-		VoicePools:     maps.Clone(AirlineVoices),
-		AircraftVoices: make(map[av.ADSBCallsign]string),
-	}
-
-	for voices := range maps.Values(va.VoicePools) {
-		rand.ShuffleSlice(voices, r)
-	}
-
-	return va
-}
-
-// GetVoice returns the voice name assigned to an aircraft, assigning one if needed.
-func (va *VoiceAssigner) GetVoice(callsign av.ADSBCallsign, r *rand.Rand) string {
-	// Check if already assigned
-	if voiceName, ok := va.AircraftVoices[callsign]; ok {
-		return voiceName
-	}
-
-	getVoice := func(callsigns string) string {
-		voices := va.VoicePools[callsigns]
-		if len(voices) == 0 {
-			voices = slices.Clone(AirlineVoices[callsigns])
-			rand.ShuffleSlice(voices, r)
-		}
-
-		voice := voices[0]
-		va.VoicePools[callsigns] = voices[1:]
-		va.AircraftVoices[callsign] = voice
-		return voice
-	}
-
-	if len(callsign) > 3 {
-		icao := string(callsign[:3])
-		for callsigns := range va.VoicePools {
-			if util.SeqContains(strings.SplitSeq(callsigns, ","), icao) {
-				return getVoice(callsigns)
-			}
-		}
-	}
-
-	return getVoice("default")
-}
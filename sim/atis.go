@@ -0,0 +1,299 @@
+// sim/atis.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/math"
+	"github.com/mmp/vice/util"
+	"github.com/mmp/vice/wx"
+)
+
+const (
+	// Arrivals that don't have the current ATIS may ask for the weather
+	// once they are within this many nm of their destination.
+	weatherRequestDistance = 40
+	// Probability that such an arrival asks.
+	weatherRequestProbability = 0.3
+)
+
+// ATIS is the broadcast for an airport's current ATIS information, as
+// generated from its METAR and the active runways.
+type ATIS struct {
+	Airport string
+	Letter  string
+	Time    time.Time // Observation time of the METAR it's based on
+	Text    string    // Written text, for display
+	Spoken  string    // Text for synthesizing the broadcast
+}
+
+// updateATIS regenerates the airport's ATIS broadcast for its current
+// letter. It is called when the sim starts and whenever the ATIS
+// advances, so the broadcast for a given letter stays fixed.
+func (s *Sim) updateATIS(ap string) {
+	letter, ok := s.State.ATISLetter[ap]
+	metar, mok := s.State.METAR[ap]
+	if !ok || !mok {
+		return
+	}
+
+	rt := s.makeATISTransmission(ap, letter, metar)
+
+	// Unlike pilot transmissions, pause between each part of the ATIS.
+	var spoken []string
+	for i := range rt.Strings {
+		spoken = append(spoken, rt.Strings[i].Spoken(s.Rand, rt.Args[i]))
+	}
+
+	atis := ATIS{
+		Airport: ap,
+		Letter:  letter,
+		Time:    metar.Time,
+		Text:    rt.Written(s.Rand) + ".",
+		Spoken:  strings.Join(spoken, ", ") + ".",
+	}
+	if s.State.ATIS == nil {
+		s.State.ATIS = make(map[string]ATIS)
+	}
+	s.State.ATIS[ap] = atis
+	s.State.ATISGeneration++
+}
+
+func (s *Sim) makeATISTransmission(ap, letter string, metar wx.METAR) *av.RadioTransmission {
+	rt := &av.RadioTransmission{}
+	rt.Add("{airport} information {ch}", ap, letter)
+	t := metar.Time.UTC()
+	rt.Add("{digits} zulu", fmt.Sprintf("%02d%02d", t.Hour(), t.Minute()))
+
+	// Wind
+	if metar.WindSpeed == 0 {
+		rt.Add("wind calm")
+	} else {
+		wind, args := "wind variable at {digits}", []any{strconv.Itoa(metar.WindSpeed)}
+		if metar.WindDir != nil {
+			dir := 10 * ((*metar.WindDir + 5) / 10)
+			wind = "wind {digits} at {digits}"
+			args = append([]any{fmt.Sprintf("%03d", util.Select(dir == 0, 360, dir))}, args...)
+		}
+		if metar.WindGust != nil {
+			wind += " gusts {digits}"
+			args = append(args, strconv.Itoa(*metar.WindGust))
+		}
+		rt.Add(wind, args...)
+	}
+
+	if vis, err := metar.Visibility(); err == nil {
+		rt.Add("visibility {vis}", min(vis, 10))
+	}
+	if pw := atisWeather(metar.Raw); pw != "" {
+		rt.Add(pw)
+	}
+	addATISSky(rt, metar.Raw)
+
+	temp, dew := int(math.Round(metar.Temperature)), int(math.Round(metar.Dewpoint))
+	rt.Add("temperature "+util.Select(temp < 0, "minus ", "")+"{digits}, dewpoint "+
+		util.Select(dew < 0, "minus ", "")+"{digits}", strconv.Itoa(math.Abs(temp)), strconv.Itoa(math.Abs(dew)))
+	rt.Add("altimeter {digits}", fmt.Sprintf("%04d", int(100*metar.Altimeter_inHg()+0.5)))
+
+	// Approaches and runways in use
+	var arr, dep []string
+	for _, ar := range s.State.ArrivalRunways {
+		if ar.Airport == ap && !slices.Contains(arr, ar.Runway.Base()) {
+			arr = append(arr, ar.Runway.Base())
+		}
+	}
+	for _, dr := range s.State.DepartureRunways {
+		if dr.Airport == ap && !slices.Contains(dep, dr.Runway.Base()) {
+			dep = append(dep, dr.Runway.Base())
+		}
+	}
+	if len(arr) > 0 {
+		if metar.IsVMC() {
+			addATISRunways(rt, "visual approaches in use to runway", arr)
+		} else if airport := s.State.Airports[ap]; airport != nil {
+			for _, rwy := range arr {
+				if appr := atisApproach(airport, rwy); appr != "" {
+					rt.Add("{appr} approach in use", appr)
+				}
+			}
+		}
+		addATISRunways(rt, "landing runway", arr)
+	}
+	if len(dep) > 0 {
+		addATISRunways(rt, "departing runway", dep)
+	}
+
+	for _, rmk := range s.ATISRemarks[ap] {
+		rt.Add(rmk)
+	}
+
+	rt.Add("advise on initial contact you have information {ch}", letter)
+
+	return rt
+}
+
+// addATISRunways adds a phrase listing the given runways to the
+// transmission.
+func addATISRunways(rt *av.RadioTransmission, phrase string, rwys []string) {
+	var args []any
+	for i, rwy := range rwys {
+		phrase += util.Select(i == 0, " {rwy}", " and {rwy}")
+		args = append(args, rwy)
+	}
+	rt.Add(phrase, args...)
+}
+
+// atisApproach returns the name of the approach advertised on the ATIS
+// for the runway in IMC; precision approaches are preferred.
+func atisApproach(ap *av.Airport, rwy string) string {
+	if _, appr := preferredApproach(ap, rwy); appr != nil {
+		return appr.FullName
+	}
+	return ""
+}
+
+// preferredApproach returns the id of the instrument approach to the
+// given runway that's most likely to be in use--an ILS if there is
+// one--and the approach itself, or nil if there is none.
+func preferredApproach(ap *av.Airport, rwy string) (string, *av.Approach) {
+	var bestId string
+	var best *av.Approach
+	for id, appr := range util.SortedMap(ap.Approaches) {
+		if appr.Runway != rwy || appr.Type == av.ChartedVisualApproach {
+			continue
+		}
+		if best == nil || (appr.Type == av.ILSApproach && best.Type != av.ILSApproach) {
+			bestId, best = id, appr
+		}
+	}
+	return bestId, best
+}
+
+// addATISSky adds the sky condition from the raw METAR to the
+// transmission; the lowest broken or overcast layer is reported as the
+// ceiling.
+func addATISSky(rt *av.RadioTransmission, raw string) {
+	ceiling := false
+	for f := range strings.FieldsSeq(raw) {
+		if f == "RMK" {
+			break
+		}
+		if f == "CLR" || f == "SKC" {
+			rt.Add("sky clear")
+			continue
+		}
+		if strings.HasPrefix(f, "VV") && len(f) >= 5 {
+			if hgt, err := strconv.Atoi(f[2:5]); err == nil {
+				rt.Add("indefinite ceiling {hgt}", 100*hgt)
+				ceiling = true
+			}
+			continue
+		}
+		if len(f) < 6 {
+			continue
+		}
+		hgt, err := strconv.Atoi(f[3:6])
+		if err != nil {
+			continue
+		}
+		switch f[:3] {
+		case "FEW":
+			rt.Add("few clouds at {hgt}", 100*hgt)
+		case "SCT":
+			rt.Add("scattered clouds at {hgt}", 100*hgt)
+		case "BKN", "OVC":
+			cover := util.Select(f[:3] == "BKN", "broken", "overcast")
+			if !ceiling {
+				rt.Add("ceiling {hgt} "+cover, 100*hgt)
+				ceiling = true
+			} else {
+				rt.Add("{hgt} "+cover, 100*hgt)
+			}
+		}
+	}
+}
+
+var atisWeatherCodes = map[string]string{
+	"TS": "thunderstorm", "SH": "showers", "FZ": "freezing",
+	"RA": "rain", "SN": "snow", "DZ": "drizzle", "GR": "hail", "PL": "ice pellets",
+	"BR": "mist", "FG": "fog", "HZ": "haze", "FU": "smoke",
+}
+
+// atisWeather returns a description of the present weather in the raw
+// METAR (e.g. "-TSRA BR" -> "light thunderstorm rain, mist").
+func atisWeather(raw string) string {
+	var present []string
+	for f := range strings.FieldsSeq(raw) {
+		if f == "RMK" {
+			break
+		}
+		var desc []string
+		if after, ok := strings.CutPrefix(f, "-"); ok {
+			desc, f = append(desc, "light"), after
+		} else if after, ok := strings.CutPrefix(f, "+"); ok {
+			desc, f = append(desc, "heavy"), after
+		}
+		if f == "" || len(f)%2 != 0 {
+			continue
+		}
+		for i := 0; i < len(f); i += 2 {
+			if w, ok := atisWeatherCodes[f[i:i+2]]; ok {
+				desc = append(desc, w)
+			} else {
+				desc = nil
+				break
+			}
+		}
+		if len(desc) > 0 {
+			if i := slices.Index(desc, "showers"); i != -1 {
+				// "SHRA" -> "rain showers"
+				desc = append(slices.Delete(desc, i, i+1), "showers")
+			}
+			present = append(present, strings.Join(desc, " "))
+		}
+	}
+	return strings.Join(present, ", ")
+}
+
+// checkWeatherRequests has arrivals that don't have the current ATIS
+// occasionally ask their controller for the weather or the altimeter as
+// they get close to their destination. Each arrival decides once.
+func (s *Sim) checkWeatherRequests() {
+	for _, ac := range util.SortedMap(s.Aircraft) {
+		if ac.AskedForWeather || ac.TypeOfFlight != av.FlightTypeArrival || !ac.IsAirborne() ||
+			ac.ControllerFrequency == "" || s.isVirtualController(ac.ControllerFrequency) {
+			continue
+		}
+		arr := ac.FlightPlan.ArrivalAirport
+		ap, ok := s.State.Airports[arr]
+		if !ok || math.NMDistance2LL(ac.Position(), ap.Location) > weatherRequestDistance {
+			continue
+		}
+		letter, ok := s.State.ATISLetter[arr]
+		if !ok || ac.ReportedATIS == letter {
+			continue
+		}
+		tcp := TCP(ac.ControllerFrequency)
+		if slices.ContainsFunc(s.PendingContacts[tcp], func(pc PendingContact) bool { return pc.ADSBCallsign == ac.ADSBCallsign }) {
+			// Wait until they've checked in.
+			continue
+		}
+
+		ac.AskedForWeather = true
+		if s.Rand.Float32() < weatherRequestProbability {
+			ty := util.Select(s.Rand.Bool(), PendingTransmissionRequestWeather, PendingTransmissionRequestAltimeter)
+			s.lg.Info("requesting weather", slog.String("callsign", string(ac.ADSBCallsign)),
+				slog.Bool("altimeter", ty == PendingTransmissionRequestAltimeter))
+			s.enqueuePilotTransmission(ac.ADSBCallsign, tcp, ty)
+		}
+	}
+}
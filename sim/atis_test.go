@@ -0,0 +1,86 @@
+// sim/atis_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/log"
+	"github.com/mmp/vice/rand"
+	"github.com/mmp/vice/wx"
+)
+
+func TestATIS(t *testing.T) {
+	dir, gust := 224, 22
+	s := &Sim{
+		Rand:        rand.Make(),
+		State:       &CommonState{},
+		ATISRemarks: map[string][]string{"KJFK": {"taxiway B closed"}},
+		lg:          log.New(false, "error", ""),
+	}
+	s.State.ATISLetter = map[string]string{"KJFK": "B"}
+	s.State.Airports = map[string]*av.Airport{"KJFK": {
+		Approaches: map[string]*av.Approach{
+			"I2L": {Type: av.ILSApproach, Runway: "22L", FullName: "ILS Runway 22L"},
+			"R2L": {Type: av.RNAVApproach, Runway: "22L", FullName: "RNAV (GPS) Runway 22L"},
+		},
+	}}
+	s.State.ArrivalRunways = []ArrivalRunway{{Airport: "KJFK", Runway: "22L"}}
+	s.State.DepartureRunways = []DepartureRunway{{Airport: "KJFK", Runway: "22R"},
+		{Airport: "KJFK", Runway: "22R", Category: "North"}, {Airport: "KJFK", Runway: "31L"}}
+	s.State.METAR = map[string]wx.METAR{"KJFK": {
+		Time:        time.Date(2025, 1, 1, 18, 51, 0, 0, time.UTC),
+		Temperature: -2.4,
+		Dewpoint:    -5,
+		Altimeter:   1013.2,
+		WindDir:     &dir,
+		WindSpeed:   12,
+		WindGust:    &gust,
+		Raw:         "KJFK 011851Z 22412G22KT 1 1/2SM -SHRA BR FEW008 BKN012 OVC250 M02/M05 A2992 RMK AO2",
+	}}
+
+	s.updateATIS("KJFK")
+	atis, ok := s.State.ATIS["KJFK"]
+	if !ok {
+		t.Fatal("no ATIS generated")
+	}
+
+	for _, expect := range []string{"information B", "1851 zulu", "wind 220 at 12 gusts 22", "visibility 1 1/2",
+		"light rain showers, mist", "few clouds at 800, ceiling 1200 broken, 25000 overcast",
+		"temperature minus 2, dewpoint minus 5", "altimeter 2992", "ILS Runway 22L approach in use",
+		"landing runway 22L", "departing runway 22R and 31L", "taxiway B closed",
+		"advise on initial contact you have information B"} {
+		if !strings.Contains(atis.Text, expect) {
+			t.Errorf("%q: expected to find %q", atis.Text, expect)
+		}
+	}
+	for _, expect := range []string{"altimeter two niner niner two", "visibility one and one half", "two two left"} {
+		if !strings.Contains(atis.Spoken, expect) {
+			t.Errorf("%q: expected to find %q", atis.Spoken, expect)
+		}
+	}
+
+	// The ATIS is only included in state updates if it has changed since
+	// the given generation.
+	s.STARSComputer = &STARSComputer{}
+	s.ATISChangedTime = make(map[string]time.Time)
+	s.eventStream = NewEventStream(nil)
+	defer s.eventStream.Destroy()
+
+	gen := s.State.ATISGeneration
+	if u := s.GetStateUpdate("1A", 0); u.ATIS == nil || u.ATIS.ATIS["KJFK"].Letter != "B" {
+		t.Errorf("expected ATIS B in the initial update, got %+v", u.ATIS)
+	}
+	if u := s.GetStateUpdate("1A", gen); u.ATIS != nil {
+		t.Errorf("unchanged ATIS included in update")
+	}
+	s.advanceATIS("KJFK")
+	if u := s.GetStateUpdate("1A", gen); u.ATIS == nil || u.ATIS.ATISLetter["KJFK"] != "C" || u.ATIS.ATIS["KJFK"].Letter != "C" {
+		t.Errorf("expected ATIS C after it advanced, got %+v", u.ATIS)
+	}
+}
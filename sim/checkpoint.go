@@ -0,0 +1,261 @@
+// sim/checkpoint.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"log/slog"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/brunoga/deep"
+)
+
+// Checkpoints hold the full state of the sim in memory; this bounds how
+// much memory they can consume.
+const maxCheckpoints = 20
+
+// checkpoint is a named snapshot of the sim's state that can be restored
+// later in the session.
+type checkpoint struct {
+	CheckpointInfo
+	sim *Sim
+}
+
+// CheckpointInfo describes a saved checkpoint.
+type CheckpointInfo struct {
+	Name         string
+	SimTime      time.Time
+	Created      time.Time // wallclock time
+	NumAircraft  int
+	Paused       bool
+	SavedByTCW   TCW
+	SavedByTCP   TCP
+	RestoreCount int
+}
+
+// SaveCheckpoint takes a snapshot of the current state of the sim under
+// the given name, replacing any existing checkpoint with that name. If no
+// name is given, the current sim time is used.
+func (s *Sim) SaveCheckpoint(tcw TCW, name string) (CheckpointInfo, error) {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	if name == "" {
+		name = s.State.SimTime.UTC().Format("15:04:05Z")
+	}
+
+	idx := slices.IndexFunc(s.checkpoints, func(cp *checkpoint) bool { return cp.Name == name })
+	if idx == -1 && len(s.checkpoints) >= maxCheckpoints {
+		return CheckpointInfo{}, ErrTooManyCheckpoints
+	}
+
+	cp := &checkpoint{
+		CheckpointInfo: CheckpointInfo{
+			Name:        name,
+			SimTime:     s.State.SimTime,
+			Created:     time.Now(),
+			NumAircraft: len(s.Aircraft),
+			Paused:      s.State.Paused,
+			SavedByTCW:  tcw,
+			SavedByTCP:  s.State.PrimaryPositionForTCW(tcw),
+		},
+		sim: s.copySimState(),
+	}
+	if idx == -1 {
+		s.checkpoints = append(s.checkpoints, cp)
+	} else {
+		s.checkpoints[idx] = cp
+	}
+
+	s.lg.Info("saved checkpoint", slog.String("name", name), slog.Time("sim_time", cp.SimTime))
+
+	return cp.CheckpointInfo, nil
+}
+
+// GetCheckpoints returns information about all of the saved checkpoints,
+// ordered by sim time.
+func (s *Sim) GetCheckpoints() []CheckpointInfo {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	var info []CheckpointInfo
+	for _, cp := range s.checkpoints {
+		info = append(info, cp.CheckpointInfo)
+	}
+	slices.SortStableFunc(info, func(a, b CheckpointInfo) int { return a.SimTime.Compare(b.SimTime) })
+	return info
+}
+
+// DeleteCheckpoint discards the checkpoint with the given name.
+func (s *Sim) DeleteCheckpoint(name string) error {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	n := len(s.checkpoints)
+	s.checkpoints = slices.DeleteFunc(s.checkpoints, func(cp *checkpoint) bool { return cp.Name == name })
+	if len(s.checkpoints) == n {
+		return ErrNoNamedCheckpoint
+	}
+	return nil
+}
+
+// RestoreCheckpoint rolls the sim back to the state it was in when the
+// named checkpoint was saved. The checkpoint is retained so that it can be
+// restored again later.
+func (s *Sim) RestoreCheckpoint(name string) error {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	idx := slices.IndexFunc(s.checkpoints, func(cp *checkpoint) bool { return cp.Name == name })
+	if idx == -1 {
+		return ErrNoNamedCheckpoint
+	}
+	cp := s.checkpoints[idx]
+	cp.RestoreCount++
+
+	s.restoreSimState(cp.sim)
+
+	s.lg.Info("restored checkpoint", slog.String("name", name), slog.Time("sim_time", cp.SimTime))
+
+	return nil
+}
+
+// Snapshot returns a copy of the current state of the sim that can later
+// be passed to RestoreSnapshot. Unlike checkpoints, snapshots are owned by
+// the caller; they are used to seek when replaying recorded sessions.
+func (s *Sim) Snapshot() *Sim {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	return s.copySimState()
+}
+
+// RestoreSnapshot rolls the sim back to the state captured by Snapshot.
+// Unlike restoring a checkpoint, everything is restored, including the
+// controller consolidation; replays re-apply those changes as they go.
+func (s *Sim) RestoreSnapshot(snap *Sim) {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	// Pseudo-pilots are whoever's currently signed on, regardless of who
+	// was when the snapshot was taken.
+	pseudoPilots := s.State.PseudoPilots
+
+	// Copy again so that the snapshot itself isn't modified as the sim
+	// runs after it's restored.
+	copyExportedFields(s, deep.MustCopy(snap))
+	s.State.PseudoPilots = pseudoPilots
+
+	s.resetAfterRestore()
+}
+
+// restoreSimState replaces the state of the simulation--the aircraft,
+// the NAS computers' flight plans, pending contacts and other future
+// events, the active runways and ATIS, and the sim time--with a copy of
+// the state in snap, which
+// should have been made by copySimState. The session's state, including
+// which controllers are signed in, the consolidation, and the score, is
+// left unchanged.
+func (s *Sim) restoreSimState(snap *Sim) {
+	// Copy again so that the snapshot itself isn't modified as the sim
+	// runs after it's restored. It's done in one go so that pointers
+	// shared between fields remain shared.
+	c := deep.MustCopy(snap)
+
+	s.Aircraft = c.Aircraft
+	s.STARSComputer = c.STARSComputer
+	s.ERAMComputer = c.ERAMComputer
+	s.LocalCodePool = c.LocalCodePool
+	s.CIDAllocator = c.CIDAllocator
+	s.TotalIFR, s.TotalVFR = c.TotalIFR, c.TotalVFR
+	s.QuickFlightPlanIndex = c.QuickFlightPlanIndex
+
+	s.DepartureState = c.DepartureState
+	s.NextInboundSpawn = c.NextInboundSpawn
+	s.NextVFFRequest = c.NextVFFRequest
+	s.NextPushStart, s.PushEnd = c.NextPushStart, c.PushEnd
+	s.NextEmergencyTime = c.NextEmergencyTime
+	s.LastPilotError = c.LastPilotError
+
+	s.Handoffs = c.Handoffs
+	s.PointOuts = c.PointOuts
+	s.PendingContacts = c.PendingContacts
+	s.PendingFrequencyChanges = c.PendingFrequencyChanges
+	s.DeferredContacts = c.DeferredContacts
+	s.FutureOnCourse = c.FutureOnCourse
+	s.FutureSquawkChanges = c.FutureSquawkChanges
+	s.FutureEmergencyUpdates = c.FutureEmergencyUpdates
+	s.SeparationLosses = c.SeparationLosses
+	// The last STT command may be for an aircraft that no longer exists.
+	s.LastSTTCommand = nil
+
+	s.Rand = c.Rand
+
+	// The departure launch state above goes with the runways that were
+	// active at the time, as do the departure rates and the ATIS.
+	s.State.RunwayConfiguration = c.State.RunwayConfiguration
+	s.State.DepartureRunways, s.State.ArrivalRunways = c.State.DepartureRunways, c.State.ArrivalRunways
+	s.State.DepartureAirports = c.State.DepartureAirports
+	s.State.LaunchConfig.DepartureRates = c.State.LaunchConfig.DepartureRates
+	s.RunwaysChanged = c.RunwaysChanged
+	s.ExcessTailwindStart = c.ExcessTailwindStart
+
+	s.State.ATISLetter, s.State.ATIS = c.State.ATISLetter, c.State.ATIS
+	s.ATISChangedTime = c.ATISChangedTime
+	// Bump the generation rather than restoring it so that clients pick
+	// up the restored ATIS.
+	s.State.ATISGeneration++
+
+	s.State.SimTime = c.State.SimTime
+	s.State.ProbeConflicts = c.State.ProbeConflicts
+
+	s.resetAfterRestore()
+}
+
+// resetAfterRestore resets cached and time-related state after the sim
+// has been rolled back.
+func (s *Sim) resetAfterRestore() {
+	// The weather model caches data for the time range it was last
+	// queried with; it only needs to refetch if that's after the
+	// restored time.
+	if s.wxModel != nil {
+		s.wxModel.Restore(s.State.SimTime)
+	}
+
+	// lastSimUpdate is w.r.t. sim time, which has gone backward; the
+	// others are wallclock and are reset so that we don't try to catch up
+	// for the time spent before the restore.
+	s.lastSimUpdate = time.Time{}
+	now := time.Now()
+	s.lastUpdateTime = now
+	s.updateTimeSlop = 0
+	s.lastControlCommandTime = now
+}
+
+// copySimState returns a deep copy of the sim's exported fields, which
+// encompass all of the state that is saved when the sim is serialized: the
+// aircraft (including their navigation state), pending contacts, the
+// STARS and ERAM computers' flight plans, and so forth. Runtime-only
+// state like the mutex, event stream, and caches is left zero-valued.
+// The copy is made in one go so that pointers that are shared between
+// fields (e.g., Aircraft held for release in the STARSComputer) remain
+// shared in the copy.
+func (s *Sim) copySimState() *Sim {
+	c := &Sim{}
+	copyExportedFields(c, s)
+	return deep.MustCopy(c)
+}
+
+// copyExportedFields does a shallow copy of the exported fields of src to
+// dst, leaving dst's unexported fields unchanged.
+func copyExportedFields(dst, src *Sim) {
+	dv, sv := reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem()
+	for i := range dv.NumField() {
+		if dv.Type().Field(i).IsExported() {
+			dv.Field(i).Set(sv.Field(i))
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// sim/checkpoint_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/wx"
+)
+
+func TestCheckpoints(t *testing.T) {
+	ac := &Aircraft{ADSBCallsign: "AAL1", HoldForRelease: true}
+	ac.Nav.FlightState.Altitude = 5000
+	s := makeTestSim(t, ac)
+	s.STARSComputer = makeSTARSComputer("TST")
+	s.PendingContacts = map[TCP][]PendingContact{"1A": {{ADSBCallsign: "AAL1", TCP: "1A"}}}
+	s.STARSComputer.AddHeldDeparture(ac)
+	start := s.State.SimTime
+
+	if _, err := s.SaveCheckpoint("", "before"); err != nil {
+		t.Fatal(err)
+	}
+
+	modify := func() {
+		s.State.SimTime = start.Add(5 * time.Minute)
+		s.Aircraft["AAL1"].Nav.FlightState.Altitude = 9000
+		s.Aircraft["AAL1"].Released = true
+		delete(s.PendingContacts, "1A")
+		s.Aircraft["JBU2"] = &Aircraft{ADSBCallsign: "JBU2"}
+	}
+
+	for range 2 {
+		modify()
+		if err := s.RestoreCheckpoint("before"); err != nil {
+			t.Fatal(err)
+		}
+
+		if !s.State.SimTime.Equal(start) {
+			t.Errorf("expected sim time %s, got %s", start, s.State.SimTime)
+		}
+		if len(s.Aircraft) != 1 || s.Aircraft["AAL1"] == nil {
+			t.Fatalf("unexpected aircraft after restore: %v", s.Aircraft)
+		}
+		rac := s.Aircraft["AAL1"]
+		if rac == ac {
+			t.Errorf("restored aircraft aliases the original")
+		}
+		if rac.Nav.FlightState.Altitude != 5000 || rac.Released {
+			t.Errorf("aircraft state not restored: %+v", rac)
+		}
+		if len(s.PendingContacts["1A"]) != 1 {
+			t.Errorf("pending contacts not restored: %v", s.PendingContacts)
+		}
+		// The aircraft held for release should be the same one as in the
+		// Aircraft map.
+		if held := s.STARSComputer.HoldForRelease; len(held) != 1 || held[0] != rac {
+			t.Errorf("held departure doesn't match restored aircraft")
+		}
+	}
+
+	if cps := s.GetCheckpoints(); len(cps) != 1 || cps[0].Name != "before" || cps[0].RestoreCount != 2 {
+		t.Errorf("unexpected checkpoints: %+v", cps)
+	}
+	if err := s.RestoreCheckpoint("nope"); err != ErrNoNamedCheckpoint {
+		t.Errorf("expected ErrNoNamedCheckpoint, got %v", err)
+	}
+}
+
+func TestCheckpointRestoreKeepsSession(t *testing.T) {
+	s := makeTestSim(t, &Aircraft{ADSBCallsign: "AAL1"})
+	s.Scoring = makeScoreTracker()
+	start := s.State.SimTime
+	s.State.CurrentConsolidation = map[TCW]*TCPConsolidation{"1A": {PrimaryTCP: "1A"}}
+
+	if _, err := s.SaveCheckpoint("1A", "before"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 1A consolidates 1C and another controller signs on at 1B; meanwhile
+	// 1A has been scored.
+	s.State.SimTime = start.Add(5 * time.Minute)
+	s.State.CurrentConsolidation["1A"].SecondaryTCPs = []SecondaryTCP{{TCP: "1C"}}
+	s.State.CurrentConsolidation["1B"] = &TCPConsolidation{PrimaryTCP: "1B"}
+	s.Scoring.position("1A").HandoffsAccepted = 3
+	delete(s.Aircraft, "AAL1")
+
+	if err := s.RestoreCheckpoint("before"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !s.State.SimTime.Equal(start) || s.Aircraft["AAL1"] == nil {
+		t.Errorf("sim state not restored")
+	}
+	if c := s.State.CurrentConsolidation; len(c) != 2 || c["1B"] == nil || len(c["1A"].SecondaryTCPs) != 1 {
+		t.Errorf("consolidation changed by restore: %+v", c)
+	}
+	if ps := s.Scoring.Score.Positions["1A"]; ps == nil || ps.HandoffsAccepted != 3 {
+		t.Errorf("score changed by restore: %+v", s.Scoring.Score)
+	}
+}
+
+func TestCheckpointAcrossRunwayChange(t *testing.T) {
+	s := makeTestSim(t)
+	s.State.Airports = map[string]*av.Airport{"KJFK": {}}
+	s.State.METAR = map[string]wx.METAR{"KJFK": {
+		Time:      testStartTime,
+		Altimeter: 1013.2,
+		Raw:       "KJFK 011151Z 22012KT 10SM FEW250 20/10 A2992",
+	}}
+	s.State.RunwayConfigurations = []RunwayConfiguration{
+		{
+			Name:             "Southwest",
+			DepartureRunways: []DepartureRunway{{Airport: "KJFK", Runway: "22R", DefaultRate: 30}},
+			ArrivalRunways:   []ArrivalRunway{{Airport: "KJFK", Runway: "22L"}},
+		},
+		{
+			Name:             "Northeast",
+			DepartureRunways: []DepartureRunway{{Airport: "KJFK", Runway: "4L", DefaultRate: 30}},
+			ArrivalRunways:   []ArrivalRunway{{Airport: "KJFK", Runway: "4R"}},
+		},
+	}
+	sw := s.State.RunwayConfigurations[0]
+	s.State.RunwayConfiguration = sw.Name
+	s.State.DepartureRunways, s.State.ArrivalRunways = sw.DepartureRunways, sw.ArrivalRunways
+	s.State.DepartureAirports = map[string]any{"KJFK": nil}
+	s.State.LaunchConfig.DepartureRates = makeDepartureRates(sw.DepartureRunways)
+	s.DepartureState = map[string]map[av.RunwayID]*RunwayLaunchState{"KJFK": {"22R": {}}}
+	s.State.ATISLetter = map[string]string{"KJFK": "A"}
+	s.ATISChangedTime = map[string]time.Time{"KJFK": testStartTime}
+	s.updateATIS("KJFK")
+	atis := s.State.ATIS["KJFK"]
+	tailwindStart := testStartTime.Add(-5 * time.Minute)
+	s.ExcessTailwindStart = tailwindStart
+
+	if _, err := s.SaveCheckpoint("", "before"); err != nil {
+		t.Fatal(err)
+	}
+
+	s.State.SimTime = testStartTime.Add(20 * time.Minute)
+	if err := s.ChangeRunwayConfiguration("1A", "Northeast"); err != nil {
+		t.Fatal(err)
+	}
+	if s.State.RunwayConfiguration != "Northeast" || s.State.ATISLetter["KJFK"] != "B" || !s.RunwaysChanged {
+		t.Fatalf("runway configuration didn't change")
+	}
+
+	gen := s.State.ATISGeneration
+	if err := s.RestoreCheckpoint("before"); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.State.RunwayConfiguration != "Southwest" || !slices.Equal(s.State.DepartureRunways, sw.DepartureRunways) ||
+		len(s.State.ArrivalRunways) != 1 || s.State.ArrivalRunways[0].Runway != "22L" {
+		t.Errorf("runways not restored: %s %+v %+v", s.State.RunwayConfiguration, s.State.DepartureRunways,
+			s.State.ArrivalRunways)
+	}
+	if rates := s.State.LaunchConfig.DepartureRates["KJFK"]; len(rates) != 1 || rates["22R"] == nil {
+		t.Errorf("departure rates not restored: %+v", s.State.LaunchConfig.DepartureRates)
+	}
+	if _, ok := s.DepartureState["KJFK"]["4L"]; ok {
+		t.Errorf("launch state for the new runway not removed: %+v", s.DepartureState)
+	}
+	if s.RunwaysChanged || !s.ExcessTailwindStart.Equal(tailwindStart) {
+		t.Errorf("runway change state not restored: %v %s", s.RunwaysChanged, s.ExcessTailwindStart)
+	}
+	if s.State.ATISLetter["KJFK"] != "A" || s.State.ATIS["KJFK"] != atis || !s.ATISChangedTime["KJFK"].Equal(testStartTime) {
+		t.Errorf("ATIS not restored: %s %+v", s.State.ATISLetter["KJFK"], s.State.ATIS["KJFK"])
+	}
+	if s.State.ATISGeneration <= gen {
+		t.Errorf("ATIS generation didn't advance after restore")
+	}
+}
@@ -0,0 +1,136 @@
+// sim/coast.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/math"
+	"github.com/mmp/vice/util"
+)
+
+// Associated tracks coast when the radars stop getting beacon returns from
+// the aircraft, either because it is outside the coverage of all of the
+// facility's radar sites (e.g., due to terrain shadowing) or because its
+// transponder is in standby. A coasting track's position is extrapolated
+// from its last return and it is listed in the coast/suspend list. If
+// returns don't resume within the adapted coast timeout, the track is
+// dropped, though its flight plan is held for a few minutes so that the
+// track is reacquired if the aircraft reappears squawking the assigned
+// beacon code.
+
+// Default time after which coasting tracks are dropped.
+const defaultCoastTimeout = 60 * time.Second
+
+// hasRadarReturns returns true if the radars are getting beacon returns
+// from the aircraft.
+func (s *Sim) hasRadarReturns(ac *Aircraft) bool {
+	if ac.Mode == av.TransponderModeStandby {
+		return false
+	}
+
+	sites := s.State.FacilityAdaptation.RadarSites
+	if len(sites) == 0 {
+		// No radar sites are adapted (e.g., for ERAM), so assume coverage
+		// everywhere.
+		return true
+	}
+	for _, site := range sites {
+		if p, sec, _ := site.CheckVisibility(ac.Position(), int(ac.Altitude())); p || sec {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Sim) coastTimeout() time.Duration {
+	if t := s.State.FacilityAdaptation.CoastTimeout; t > 0 {
+		return time.Duration(t) * time.Second
+	}
+	return defaultCoastTimeout
+}
+
+// nextCoastSuspendIndex returns the lowest coast/suspend list index that
+// isn't in use.
+func (s *Sim) nextCoastSuspendIndex() int {
+	used := make(map[int]bool)
+	for _, ac := range s.Aircraft {
+		if fp := ac.NASFlightPlan; fp != nil && (fp.Suspended || !ac.CoastStart.IsZero()) {
+			used[fp.CoastSuspendIndex] = true
+		}
+	}
+	for i := 1; i < 100; i++ {
+		if !used[i] {
+			return i
+		}
+	}
+	return 0
+}
+
+// updateCoastingTracks starts, ends, and times out track coasting based
+// on whether the radars are getting returns from each aircraft.
+func (s *Sim) updateCoastingTracks() {
+	for _, ac := range util.SortedMap(s.Aircraft) {
+		if !ac.IsAirborne() {
+			if !ac.CoastStart.IsZero() {
+				ac.CoastStart = time.Time{}
+				if fp := ac.NASFlightPlan; fp != nil && !fp.Suspended {
+					fp.CoastSuspendIndex = 0
+				}
+			}
+			continue
+		}
+
+		if s.hasRadarReturns(ac) {
+			if !ac.CoastStart.IsZero() {
+				// Reacquired
+				ac.CoastStart = time.Time{}
+				if fp := ac.NASFlightPlan; fp != nil && !fp.Suspended {
+					fp.CoastSuspendIndex = 0
+				}
+			}
+			ac.LastRadarTrack = ac.GetRadarTrack(s.State.SimTime)
+			continue
+		}
+
+		if !ac.IsAssociated() {
+			// Only associated tracks coast.
+			continue
+		}
+
+		fp := ac.NASFlightPlan
+		if ac.CoastStart.IsZero() {
+			ac.CoastStart = s.State.SimTime
+			if ac.LastRadarTrack.ADSBCallsign == "" {
+				// We've never had a return from it; start from where it is now.
+				ac.LastRadarTrack = ac.GetRadarTrack(s.State.SimTime)
+			}
+			if !fp.Suspended {
+				fp.CoastSuspendIndex = s.nextCoastSuspendIndex()
+			}
+		} else if s.State.SimTime.Sub(ac.CoastStart) > s.coastTimeout() {
+			ac.CoastStart = time.Time{}
+			fp := ac.DisassociateFlightPlan()
+			fp.Suspended = false
+			fp.CoastSuspendIndex = 0
+			fp.CoastDropped = true
+			fp.DeleteTime = s.State.SimTime.Add(4 * time.Minute) // hold it for a bit before deleting
+			s.STARSComputer.FlightPlans = append(s.STARSComputer.FlightPlans, fp)
+		}
+	}
+}
+
+// coastingRadarTrack returns the radar track for an aircraft whose track
+// is coasting: its last return, dead-reckoned along its last heading at
+// its last groundspeed.
+func (s *Sim) coastingRadarTrack(ac *Aircraft) av.RadarTrack {
+	rt := ac.LastRadarTrack
+	dt := s.State.SimTime.Sub(ac.CoastStart)
+	d := rt.Groundspeed * float32(dt.Hours())
+	rt.Location = math.Offset2LL(rt.Location, rt.Heading, d, ac.NmPerLongitude(), ac.MagneticVariation())
+	rt.Ident = false
+	return rt
+}
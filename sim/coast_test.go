@@ -0,0 +1,140 @@
+// sim/coast_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"testing"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/math"
+)
+
+func TestCoastingTracks(t *testing.T) {
+	ac := makeTestAircraft("AAL1", testCenter, 5000, 90, 240)
+	ac.Squawk = 0o1234
+	ac.NASFlightPlan = &NASFlightPlan{ACID: "AAL1", AssignedSquawk: 0o1234}
+	s := makeTestSim(t, ac)
+	s.STARSComputer = makeSTARSComputer("TST")
+	s.State.FacilityAdaptation.CoastTimeout = 30
+
+	s.updateCoastingTracks()
+	if !ac.CoastStart.IsZero() {
+		t.Fatalf("track with returns is coasting")
+	}
+
+	// Standby: the track starts coasting.
+	ac.Mode = av.TransponderModeStandby
+	s.State.SimTime = s.State.SimTime.Add(time.Second)
+	s.updateCoastingTracks()
+	if ac.CoastStart.IsZero() {
+		t.Fatalf("expected track to be coasting")
+	}
+	if ac.NASFlightPlan.CoastSuspendIndex != 1 {
+		t.Errorf("expected coast list index 1, got %d", ac.NASFlightPlan.CoastSuspendIndex)
+	}
+
+	// The position is extrapolated from the last return: 240kts for 15s is 1nm.
+	s.State.SimTime = s.State.SimTime.Add(15 * time.Second)
+	s.updateCoastingTracks()
+	rt := s.coastingRadarTrack(ac)
+	if d := math.NMDistance2LL(rt.Location, testCenter); d < .9 || d > 1.1 {
+		t.Errorf("expected extrapolated track 1nm from the last return; got %.2fnm", d)
+	}
+	if rt.Squawk != 0o1234 || rt.Mode != av.TransponderModeAltitude {
+		t.Errorf("coasting track should have last return's beacon code and mode: %+v", rt)
+	}
+
+	// Returns resume: the track is reacquired.
+	ac.Mode = av.TransponderModeAltitude
+	s.updateCoastingTracks()
+	if !ac.CoastStart.IsZero() || ac.NASFlightPlan.CoastSuspendIndex != 0 {
+		t.Errorf("expected track to be reacquired")
+	}
+
+	// And dropped if it coasts past the timeout.
+	ac.Mode = av.TransponderModeStandby
+	s.updateCoastingTracks()
+	s.State.SimTime = s.State.SimTime.Add(31 * time.Second)
+	s.updateCoastingTracks()
+	if ac.IsAssociated() {
+		t.Fatalf("expected track to be dropped after the coast timeout")
+	}
+	fp := s.STARSComputer.lookupFlightPlanBySquawk(0o1234)
+	if fp == nil || !fp.CoastDropped || fp.DeleteTime.IsZero() {
+		t.Fatalf("expected dropped flight plan to be held for reacquisition: %+v", fp)
+	}
+
+	// It isn't reacquired while there are still no returns...
+	s.State.SimTime = s.State.SimTime.Add(time.Minute)
+	s.STARSComputer.Update(s)
+	if ac.IsAssociated() {
+		t.Fatalf("track reacquired without radar returns")
+	}
+
+	// ...but is once they resume.
+	ac.Mode = av.TransponderModeAltitude
+	s.STARSComputer.Update(s)
+	if !ac.IsAssociated() || ac.NASFlightPlan != fp {
+		t.Fatalf("expected track to be reacquired after returns resumed")
+	}
+	if fp.CoastDropped || !fp.DeleteTime.IsZero() || s.STARSComputer.lookupFlightPlanBySquawk(0o1234) != nil {
+		t.Errorf("reacquired flight plan is still pending deletion: %+v", fp)
+	}
+}
+
+func TestCoastSuspendIndex(t *testing.T) {
+	makeAircraft := func(callsign av.ADSBCallsign, sq av.Squawk) *Aircraft {
+		ac := makeTestAircraft(callsign, testCenter, 5000, 90, 240)
+		ac.Squawk = sq
+		ac.NASFlightPlan = &NASFlightPlan{ACID: ACID(callsign), AssignedSquawk: sq, OwningTCW: "1A"}
+		return ac
+	}
+	ac1, ac2 := makeAircraft("AAL1", 0o1234), makeAircraft("AAL2", 0o2345)
+	s := makeTestSim(t, ac1, ac2)
+	s.STARSComputer = makeSTARSComputer("TST")
+
+	suspend := func(acid ACID, suspended bool) {
+		t.Helper()
+		var spec FlightPlanSpecifier
+		spec.Suspended.Set(suspended)
+		if err := s.ModifyFlightPlan("1A", acid, spec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Coasting and suspended tracks share the list's indices.
+	ac1.Mode = av.TransponderModeStandby
+	s.updateCoastingTracks()
+	suspend("AAL2", true)
+	if i1, i2 := ac1.NASFlightPlan.CoastSuspendIndex, ac2.NASFlightPlan.CoastSuspendIndex; i1 != 1 || i2 != 2 {
+		t.Errorf("expected indices 1 and 2, got %d and %d", i1, i2)
+	}
+
+	// A coasting track keeps its index when suspended and unsuspended.
+	suspend("AAL1", true)
+	suspend("AAL1", false)
+	if i := ac1.NASFlightPlan.CoastSuspendIndex; i != 1 {
+		t.Errorf("coasting track's index changed to %d", i)
+	}
+
+	// Unsuspending frees the index for reuse.
+	suspend("AAL2", false)
+	if i := ac2.NASFlightPlan.CoastSuspendIndex; i != 0 {
+		t.Errorf("unsuspended track still has index %d", i)
+	}
+	suspend("AAL2", true)
+	if i := ac2.NASFlightPlan.CoastSuspendIndex; i != 2 {
+		t.Errorf("expected the suspended track to reuse index 2, got %d", i)
+	}
+
+	// A coasting track that lands leaves the list.
+	ac1.Nav.Perf.Speed.V2 = 130
+	ac1.Nav.FlightState.IAS = 0
+	s.updateCoastingTracks()
+	if !ac1.CoastStart.IsZero() || ac1.NASFlightPlan.CoastSuspendIndex != 0 {
+		t.Errorf("track on the ground still coasting with index %d", ac1.NASFlightPlan.CoastSuspendIndex)
+	}
+}
@@ -205,11 +205,14 @@ func (s *Sim) deleteAircraft(ac *Aircraft) {
 	if fp == nil {
 		fp = s.STARSComputer.takeFlightPlanByACID(ACID(ac.ADSBCallsign))
 	}
+	deleted := Event{Type: AircraftDeletedEvent, ADSBCallsign: ac.ADSBCallsign}
 	if fp != nil {
+		deleted.ACID = fp.ACID
 		delete(s.Handoffs, fp.ACID)
 		delete(s.PointOuts, fp.ACID)
 		s.deleteFlightPlan(fp)
 	}
+	s.eventStream.Post(deleted)
 
 	s.lg.Info("deleted aircraft", slog.String("adsb_callsign", string(ac.ADSBCallsign)))
 }
@@ -309,7 +312,7 @@ func (s *Sim) CreateFlightPlan(tcw TCW, spec FlightPlanSpecifier) error {
 		return err
 	}
 
-	fp, err := spec.GetFlightPlan(s.LocalCodePool, s.ERAMComputer.SquawkCodePool)
+	fp, err := spec.GetFlightPlan(s.LocalCodePool, s.ERAMComputer.SquawkCodePool, s.Rand)
 	if err != nil {
 		return err
 	}
@@ -366,6 +369,14 @@ func (s *Sim) preCheckFlightPlanSpecifier(spec *FlightPlanSpecifier) error {
 		}
 	}
 
+	for _, ap := range []util.Optional[string]{spec.DepartureAirport, spec.ArrivalAirport} {
+		if ap.IsSet {
+			if _, ok := av.DB.Airports[ap.Get()]; !ok {
+				return av.ErrUnknownAirport
+			}
+		}
+	}
+
 	// TODO: validate entry/exit fixes
 
 	return nil
@@ -389,7 +400,7 @@ func (s *Sim) ModifyFlightPlan(tcw TCW, acid ACID, spec FlightPlanSpecifier) err
 
 	s.lastControlCommandTime = time.Now()
 
-	fp, _, active := s.getFlightPlanForACID(acid)
+	fp, ac, active := s.getFlightPlanForACID(acid)
 	if fp == nil {
 		return ErrNoMatchingFlightPlan
 	}
@@ -422,10 +433,27 @@ func (s *Sim) ModifyFlightPlan(tcw TCW, acid ACID, spec FlightPlanSpecifier) err
 				LeaderLineDirection: spec.GlobalLeaderLineDirection.Get(),
 			})
 		}
+
+		if ac != nil && spec.Route.IsSet {
+			if err := s.amendRoute(tcw, ac, spec.Route.Get(), spec.ArrivalAirport.GetOr("")); err != nil {
+				return err
+			}
+		}
 	}
 
+	wasSuspended := fp.Suspended
 	fp.Update(spec, s)
 
+	if ac != nil && ac.CoastStart.IsZero() {
+		// Suspended tracks are listed in the coast/suspend list; coasting
+		// tracks already have an index there, which they keep.
+		if fp.Suspended && !wasSuspended {
+			fp.CoastSuspendIndex = s.nextCoastSuspendIndex()
+		} else if !fp.Suspended {
+			fp.CoastSuspendIndex = 0
+		}
+	}
+
 	return s.postCheckFlightPlanSpecifier(spec)
 }
 
@@ -459,7 +487,7 @@ func (s *Sim) AssociateFlightPlan(tcw TCW, callsign av.ADSBCallsign, spec Flight
 				return ErrDuplicateACID
 			}
 
-			fp, err := spec.GetFlightPlan(s.LocalCodePool, s.ERAMComputer.SquawkCodePool)
+			fp, err := spec.GetFlightPlan(s.LocalCodePool, s.ERAMComputer.SquawkCodePool, s.Rand)
 			if err != nil {
 				return err
 			}
@@ -690,6 +718,7 @@ func (s *Sim) HandoffTrack(tcw TCW, acid ACID, toTCP TCP) error {
 func (s *Sim) handoffTrack(fp *NASFlightPlan, toTCP TCP) {
 	s.eventStream.Post(Event{
 		Type:           OfferedHandoffEvent,
+		ACID:           fp.ACID,
 		FromController: fp.TrackingController,
 		ToController:   toTCP,
 	})
@@ -1212,6 +1241,63 @@ func (s *Sim) FlightPlanDirect(tcp TCP, fix string, acid ACID) error {
 	return nil
 }
 
+// amendRoute has the aircraft fly the route from an amended flight plan,
+// given as fixes and airways separated by spaces. If the aircraft's
+// current route passes through the last fix of the new one, the rest of
+// the current route (e.g., its STAR) is kept after that fix; otherwise it
+// proceeds to its arrival airport--the new one, if one is given--at the
+// end of the new route. Since the pilot is flying the new route from
+// then on, the controller who amended the flight plan is told what it
+// is.
+func (s *Sim) amendRoute(tcw TCW, ac *Aircraft, route string, arrivalAirport string) error {
+	wps, err := av.ParseWaypoints(route)
+	if err != nil || len(wps) == 0 {
+		return av.ErrNoMatchingFix
+	}
+	var e util.ErrorLogger
+	wps = wps.InitializeLocations(s.State, ac.NmPerLongitude(), ac.MagneticVariation(), false, &e)
+	if e.HaveErrors() {
+		return av.ErrNoMatchingFix
+	}
+
+	last := wps[len(wps)-1].Fix
+	if idx := slices.IndexFunc(ac.Nav.Waypoints, func(wp av.Waypoint) bool { return wp.Fix == last }); idx != -1 {
+		wps = append(wps[:len(wps)-1], ac.Nav.Waypoints[idx:]...)
+	} else if arrivalAirport != "" && arrivalAirport != ac.FlightPlan.ArrivalAirport {
+		ac.DivertToAirport(arrivalAirport)
+		wps = append(wps, ac.Nav.Waypoints...)
+	} else if arr := ac.Nav.FlightState.ArrivalAirport; arr.Fix != "" && last != arr.Fix {
+		// Don't leave the aircraft with nowhere to go at the end of the
+		// new route.
+		wps = append(wps, arr)
+	}
+
+	ac.Nav.Waypoints = wps
+	ac.FlightPlan.Route = route
+
+	s.eventStream.Post(Event{
+		Type:  FlightPlanDirectEvent,
+		ACID:  ac.NASFlightPlan.ACID,
+		Route: ac.Nav.Waypoints,
+	})
+
+	var fixes []string
+	for _, wp := range wps {
+		if !strings.HasPrefix(wp.Fix, "_") {
+			fixes = append(fixes, wp.Fix)
+		}
+	}
+	s.eventStream.Post(Event{
+		Type:         StatusMessageEvent,
+		ToController: s.State.PrimaryPositionForTCW(tcw),
+		WrittenText:  fmt.Sprintf("%s rerouted: %s.", ac.NASFlightPlan.ACID, strings.Join(fixes, " ")),
+	})
+	s.lg.Info("amended route", slog.String("adsb_callsign", string(ac.ADSBCallsign)), slog.String("route", route),
+		slog.Any("fixes", fixes))
+
+	return nil
+}
+
 func (s *Sim) ReleaseDeparture(tcw TCW, callsign av.ADSBCallsign) error {
 	s.mu.Lock(s.lg)
 	defer s.mu.Unlock(s.lg)
@@ -1255,6 +1341,10 @@ func (s *Sim) ShouldTriggerPilotMixUp(callsign av.ADSBCallsign) bool {
 
 	// Check if we've recently communicated with this specific aircraft
 	if ac, ok := s.Aircraft[callsign]; ok {
+		if s.flownByPseudoPilot(ac) {
+			// Any mix-ups are up to the pseudo-pilot.
+			return false
+		}
 		// Don't trigger mix-up if we just communicated with this pilot
 		if !ac.LastRadioTransmission.IsZero() && s.State.SimTime.Sub(ac.LastRadioTransmission) < 20*time.Second {
 			return false
@@ -1454,6 +1544,29 @@ func (s *Sim) ExpediteClimb(tcw TCW, callsign av.ADSBCallsign) (av.CommandIntent
 		})
 }
 
+func (s *Sim) Breakout(tcw TCW, callsign av.ADSBCallsign, hdg int, turn av.TurnDirection, altitude int) (av.CommandIntent, error) {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	intent, err := s.dispatchAircraftCommand(tcw, callsign,
+		func(tcw TCW, ac *Aircraft) error {
+			// The final monitor can break out aircraft on the finals it's
+			// monitoring even though it isn't controlling them.
+			if !s.TCWCanCommandAircraft(tcw, ac) && !s.tcwMonitorsAircraft(tcw, ac) {
+				return av.ErrOtherControllerHasTrack
+			}
+			return nil
+		},
+		func(tcw TCW, ac *Aircraft) av.CommandIntent {
+			return ac.Breakout(hdg, turn, altitude, s.State.SimTime)
+		})
+
+	if err == nil && s.TCWCanCommandAircraft(tcw, s.Aircraft[callsign]) {
+		s.cancelPendingInitialContact(callsign)
+	}
+	return intent, err
+}
+
 func (s *Sim) DirectFix(tcw TCW, callsign av.ADSBCallsign, fix string) (av.CommandIntent, error) {
 	s.mu.Lock(s.lg)
 	defer s.mu.Unlock(s.lg)
@@ -1941,6 +2054,18 @@ const (
 	PendingTransmissionGoAround                                                // Go-around announcement
 	PendingTransmissionEmergency                                               // Emergency stage transmission
 	PendingTransmissionRequestApproachClearance                                // Pilot requesting approach clearance
+	PendingTransmissionWakeEncounter                                           // Wake turbulence report
+	PendingTransmissionTCASRA                                                  // TCAS resolution advisory report
+	PendingTransmissionTCASClearOfConflict                                     // Clear of conflict after a TCAS RA
+	PendingTransmissionRequestDeviation                                        // Request to deviate around weather
+	PendingTransmissionDeviatingForWeather                                     // Deviating around weather without approval
+	PendingTransmissionClearOfWeather                                          // Done deviating around weather
+	PendingTransmissionRequestWeather                                          // Request for the current weather
+	PendingTransmissionRequestAltimeter                                        // Request for the altimeter
+	PendingTransmissionPilotRequest                                            // Altitude, direct, or ride request
+	PendingTransmissionPilotRequestFollowUp                                    // Follow-up on an unanswered request
+	PendingTransmissionExpectApproach                                          // New expected approach after a runway change
+	PendingTransmissionCommsRestored                                           // Back in contact after lost communications
 )
 
 // PendingFrequencyChange represents a pilot switching to a new frequency.
@@ -1960,12 +2085,17 @@ type PendingContact struct {
 	Type                   PendingTransmissionType // What kind of transmission
 	ReportDepartureHeading bool                    // For departures: include assigned heading
 	HasQueuedEmergency     bool                    // For departures: trigger emergency after contact
-	PrebuiltTransmission   *av.RadioTransmission   // For emergency transmissions: pre-built message
+	PrebuiltTransmission   *av.RadioTransmission   // For emergency, expected approach, and comms restored transmissions: pre-built message
 	FirstInFacility        bool                    // For arrivals: first contact in this TRACON facility
 }
 
-// addPendingContact adds an aircraft to the pending contacts queue for a controller.
+// addPendingContact adds an aircraft to the pending contacts queue for a
+// controller. Pseudo-pilots make their aircraft's transmissions
+// themselves, so nothing is queued for them.
 func (s *Sim) addPendingContact(pc PendingContact) {
+	if _, ok := s.pseudoPilotFlying(pc.TCP); ok {
+		return
+	}
 	if s.PendingContacts == nil {
 		s.PendingContacts = make(map[TCP][]PendingContact)
 	}
@@ -2227,7 +2357,7 @@ func (s *Sim) GenerateContactTransmission(pc *PendingContact) (spokenText, writt
 	defer s.mu.Unlock(s.lg)
 
 	ac, ok := s.Aircraft[pc.ADSBCallsign]
-	if !ok {
+	if !ok || ac.NORDO != nil {
 		return "", ""
 	}
 
@@ -2318,12 +2448,71 @@ func (s *Sim) GenerateContactTransmission(pc *PendingContact) (spokenText, writt
 			rt.Add(", [tower sent us around for spacing|we were sent around for spacing]")
 			ac.SentAroundForSpacing = false
 		}
+		if ac.WakeGoAround {
+			rt.Add(", [we hit some wake turbulence on final|we got into some wake on short final]")
+			ac.WakeGoAround = false
+		}
 		rt.Type = av.RadioTransmissionUnexpected
 
 	case PendingTransmissionRequestApproachClearance:
 		rt = av.MakeContactTransmission("[are we cleared for the approach|looking for the approach|we're going to need the approach here shortly]")
 		rt.Type = av.RadioTransmissionUnexpected
 
+	case PendingTransmissionWakeEncounter:
+		rt = av.MakeContactTransmission("[we just hit some wake turbulence|we're getting some wake back here|we picked up some wake], " +
+			"[we'd like a little more spacing|request more spacing|can we get some more room]")
+		rt.Type = av.RadioTransmissionUnexpected
+
+	case PendingTransmissionTCASRA:
+		rt = av.MakeContactTransmission("TCAS RA")
+		rt.Type = av.RadioTransmissionUnexpected
+
+	case PendingTransmissionTCASClearOfConflict:
+		alt, _ := ac.Nav.TargetAltitude()
+		rt = av.MakeContactTransmission("clear of conflict, [returning to|back to] {alt}", alt)
+		rt.Type = av.RadioTransmissionUnexpected
+
+	case PendingTransmissionRequestDeviation:
+		dev := ac.WXDeviation
+		if dev == nil || dev.Deviating {
+			return "", ""
+		}
+		rt = av.MakeContactTransmission("[request|requesting|we'd like] [a deviation|deviation] " +
+			strconv.Itoa(math.Abs(dev.Degrees)) + " [degrees|] " + dev.Direction() + " [for weather|around the weather|around this cell]")
+		rt.Type = av.RadioTransmissionUnexpected
+
+	case PendingTransmissionDeviatingForWeather:
+		dev := ac.WXDeviation
+		if dev == nil {
+			return "", ""
+		}
+		rt = av.MakeContactTransmission("[we're deviating|we need to deviate|we're turning] " + strconv.Itoa(math.Abs(dev.Degrees)) +
+			" [degrees|] " + dev.Direction() + " for weather, [we can't go through that|there's no way we're going through that]")
+		rt.Type = av.RadioTransmissionUnexpected
+
+	case PendingTransmissionClearOfWeather:
+		if len(ac.Nav.Waypoints) == 0 || strings.HasPrefix(ac.Nav.Waypoints[0].Fix, "_") {
+			rt = av.MakeContactTransmission("[we're clear of the weather|clear of the weather now], [back on course|resuming our route]")
+		} else {
+			rt = av.MakeContactTransmission("[we're clear of the weather|clear of the weather now], [proceeding|going] direct {fix}",
+				ac.Nav.Waypoints[0].Fix)
+		}
+
+	case PendingTransmissionRequestWeather, PendingTransmissionRequestAltimeter:
+		if ac.ReportedATIS == s.State.ATISLetter[ac.FlightPlan.ArrivalAirport] {
+			// The controller already gave them the ATIS.
+			return "", ""
+		}
+		if pc.Type == PendingTransmissionRequestWeather {
+			rt = av.MakeContactTransmission("[do you have the current weather for|can we get the latest weather at|request the current weather at] {airport}",
+				ac.FlightPlan.ArrivalAirport)
+		} else {
+			rt = av.MakeContactTransmission("[can we get the current altimeter|request the altimeter|what's the altimeter setting]")
+		}
+
+	case PendingTransmissionPilotRequest, PendingTransmissionPilotRequestFollowUp:
+		rt = makePilotRequestTransmission(ac, pc.Type == PendingTransmissionPilotRequestFollowUp)
+
 	case PendingTransmissionEmergency:
 		if pc.PrebuiltTransmission == nil {
 			return "", ""
@@ -2331,6 +2520,15 @@ func (s *Sim) GenerateContactTransmission(pc *PendingContact) (spokenText, writt
 		rt = pc.PrebuiltTransmission
 		rt.Type = av.RadioTransmissionUnexpected // Mark as urgent for display
 
+	case PendingTransmissionExpectApproach:
+		if pc.PrebuiltTransmission == nil || ac.Nav.Approach.Cleared {
+			return "", ""
+		}
+		rt = pc.PrebuiltTransmission
+
+	case PendingTransmissionCommsRestored:
+		rt = pc.PrebuiltTransmission
+
 	default:
 		return "", ""
 	}
@@ -2479,6 +2677,10 @@ func (s *Sim) RunAircraftControlCommands(tcw TCW, callsign av.ADSBCallsign, comm
 	// Update aircraft's last addressing form for readback rendering
 	if ac, ok := s.Aircraft[callsign]; ok {
 		ac.LastAddressingForm = addressingForm
+
+		if ac.NORDO != nil {
+			return s.runNORDOCommands(tcw, ac, commands)
+		}
 	}
 
 	// Handle ROLLBACK command first (may have other commands following)
@@ -2495,6 +2697,15 @@ func (s *Sim) RunAircraftControlCommands(tcw TCW, callsign av.ADSBCallsign, comm
 		}
 	}
 
+	// Commands for aircraft flown by a pseudo-pilot go to the
+	// pseudo-pilot, who carries them out and reads them back.
+	if routed, err := s.routeToPseudoPilot(tcw, callsign, commands); routed {
+		if err != nil {
+			return ControlCommandsResult{RemainingInput: strings.Join(commands, " "), Error: err}
+		}
+		return ControlCommandsResult{}
+	}
+
 	// Handle special STT commands that need direct TTS synthesis
 	// These short-circuit normal command processing
 	if len(commands) == 1 {
@@ -2800,6 +3011,27 @@ func (s *Sim) runOneControlCommand(tcw TCW, callsign av.ADSBCallsign, command st
 			}
 		}
 
+	case 'B':
+		// Break-out: BOL270/40 or BOR090 -> turn left/right to the heading
+		// and optionally climb or descend to the altitude.
+		if len(command) < 4 || command[:2] != "BO" || (command[2] != 'L' && command[2] != 'R') {
+			return nil, ErrInvalidCommandSyntax
+		}
+		turn := util.Select(command[2] == 'L', av.TurnLeft, av.TurnRight)
+		hdgStr, altStr, haveAlt := strings.Cut(command[3:], "/")
+		hdg, err := strconv.Atoi(hdgStr)
+		if err != nil {
+			return nil, err
+		}
+		alt := 0
+		if haveAlt {
+			if alt, err = strconv.Atoi(altStr); err != nil {
+				return nil, err
+			}
+			alt *= 100
+		}
+		return s.Breakout(tcw, callsign, hdg, turn, alt)
+
 	case 'C':
 		if command == "CAC" {
 			return s.CancelApproachClearance(tcw, callsign)
@@ -2865,6 +3097,8 @@ func (s *Sim) runOneControlCommand(tcw TCW, callsign av.ADSBCallsign, command st
 	case 'D':
 		if command == "DVS" {
 			return s.DescendViaSTAR(tcw, callsign)
+		} else if command == "DEV" {
+			return s.ApproveDeviation(tcw, callsign)
 		} else if components := strings.Split(command, "/"); len(components) > 1 && len(components[1]) > 1 {
 			fix := components[0][1:]
 
@@ -3011,6 +3245,8 @@ func (s *Sim) runOneControlCommand(tcw TCW, callsign av.ADSBCallsign, command st
 			return s.ResumeOwnNavigation(tcw, callsign)
 		} else if command == "RST" {
 			return s.RadarServicesTerminated(tcw, callsign)
+		} else if command == "REQ" {
+			return s.ApprovePilotRequest(tcw, callsign)
 		} else if l := len(command); l > 2 && command[l-1] == 'D' {
 			deg, err := strconv.Atoi(command[1 : l-1])
 			if err != nil {
@@ -3151,6 +3387,20 @@ func (s *Sim) runOneControlCommand(tcw TCW, callsign av.ADSBCallsign, command st
 			return nil, ErrInvalidCommandSyntax
 		}
 
+	case 'N':
+		if command == "NODEV" {
+			return s.DenyDeviation(tcw, callsign)
+		} else if command == "NORDO" {
+			return s.LostCommunications(tcw, callsign)
+		}
+		return nil, ErrInvalidCommandSyntax
+
+	case 'U':
+		if command == "UNABLE" {
+			return s.DenyPilotRequest(tcw, callsign)
+		}
+		return nil, ErrInvalidCommandSyntax
+
 	case 'V':
 		if command == "VISSEP" {
 			return s.MaintainVisualSeparation(tcw, callsign)
@@ -4,9 +4,12 @@
 package sim
 
 import (
+	"slices"
 	"testing"
 
 	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/math"
+	"github.com/mmp/vice/util"
 )
 
 func TestParseHold(t *testing.T) {
@@ -284,3 +287,77 @@ func TestParseHold(t *testing.T) {
 		})
 	}
 }
+
+func TestAmendRoute(t *testing.T) {
+	db := av.DB
+	t.Cleanup(func() { av.DB = db })
+	av.DB = &av.StaticDatabase{
+		Airports: map[string]av.FAAAirport{"KBOS": {Id: "KBOS", Location: math.Point2LL{-71, 42.4}}},
+	}
+
+	makeSim := func() (*Sim, *Aircraft) {
+		ac := makePilotRequestTestAircraft(av.FlightTypeArrival, 20000, 0)
+		ac.NASFlightPlan = &NASFlightPlan{ACID: "AAL1"}
+		ac.Nav.Waypoints = []av.Waypoint{{Fix: "WEST1"}, {Fix: "MERGE"}, {Fix: "STAR1"}, {Fix: "STAR2"}}
+		ac.Nav.FlightState.ArrivalAirport = av.Waypoint{Fix: "KJFK", Location: testCenter}
+		s := makePilotRequestTestSim(t, ac)
+		s.State.CurrentConsolidation = map[TCW]*TCPConsolidation{"1A": {PrimaryTCP: "1A"}}
+		s.State.Fixes = map[string]math.Point2LL{
+			"WEST1": {-73.5, 41}, "EAST1": {-72.5, 41}, "MERGE": {-73, 40.5},
+			"STAR1": {-73, 40.3}, "STAR2": {-73, 40.1}, "NORTH": {-72, 42},
+		}
+		return s, ac
+	}
+	fixes := func(wps []av.Waypoint) []string {
+		return util.MapSlice(wps, func(wp av.Waypoint) string { return wp.Fix })
+	}
+
+	// A new route that rejoins the current one keeps the rest of it. The
+	// controller is told what the aircraft is now flying.
+	s, ac := makeSim()
+	sub := s.eventStream.Subscribe()
+	if err := s.amendRoute("1A", ac, "EAST1 MERGE", ""); err != nil {
+		t.Fatal(err)
+	}
+	if f := fixes(ac.Nav.Waypoints); !slices.Equal(f, []string{"EAST1", "MERGE", "STAR1", "STAR2"}) {
+		t.Errorf("expected to rejoin the route at MERGE, got %v", f)
+	}
+	if ac.Nav.Waypoints[0].Location.IsZero() {
+		t.Errorf("new route's waypoints weren't located")
+	}
+	if !slices.ContainsFunc(sub.Get(), func(e Event) bool {
+		return e.Type == StatusMessageEvent && e.ToController == "1A" && e.WrittenText == "AAL1 rerouted: EAST1 MERGE STAR1 STAR2."
+	}) {
+		t.Errorf("controller wasn't told about the reroute")
+	}
+
+	// One that doesn't rejoin it goes on to the arrival airport.
+	s, ac = makeSim()
+	if err := s.amendRoute("1A", ac, "EAST1 NORTH", ""); err != nil {
+		t.Fatal(err)
+	}
+	if f := fixes(ac.Nav.Waypoints); !slices.Equal(f, []string{"EAST1", "NORTH", "KJFK"}) {
+		t.Errorf("expected to proceed to KJFK after the new route, got %v", f)
+	}
+
+	// Or to the new one, if it was changed.
+	s, ac = makeSim()
+	if err := s.amendRoute("1A", ac, "EAST1 NORTH", "KBOS"); err != nil {
+		t.Fatal(err)
+	}
+	if f := fixes(ac.Nav.Waypoints); !slices.Equal(f, []string{"EAST1", "NORTH", "KBOS"}) {
+		t.Errorf("expected to proceed to KBOS after the new route, got %v", f)
+	}
+	if ac.FlightPlan.ArrivalAirport != "KBOS" || ac.FlightPlan.Route != "EAST1 NORTH" {
+		t.Errorf("flight plan not updated: %+v", ac.FlightPlan)
+	}
+
+	// Unknown fixes are rejected and leave the route as it was.
+	s, ac = makeSim()
+	if err := s.amendRoute("1A", ac, "EAST1 NOTAFIX", ""); err == nil {
+		t.Errorf("expected an error for an unknown fix")
+	}
+	if f := fixes(ac.Nav.Waypoints); !slices.Equal(f, []string{"WEST1", "MERGE", "STAR1", "STAR2"}) {
+		t.Errorf("route changed after an invalid amendment: %v", f)
+	}
+}
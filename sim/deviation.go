@@ -0,0 +1,233 @@
+// sim/deviation.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"log/slog"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/math"
+	"github.com/mmp/vice/util"
+	"github.com/mmp/vice/wx"
+)
+
+const (
+	// Pilots deviate around precipitation at or above this STARS weather
+	// level.
+	wxDeviationLevel = 4
+	// How far ahead pilots look for weather, in nm.
+	wxLookahead = 20
+	// If the controller hasn't approved a deviation and the weather is
+	// within this many nm, the pilot deviates anyway.
+	wxDeviateAnywayDistance = 8
+	// If the controller hasn't responded to a deviation request in this
+	// long, the pilot deviates anyway once the weather is close.
+	wxDeviationResponseTimeout = time.Minute
+	// How often aircraft are checked for weather ahead, in seconds.
+	wxDeviationCheckInterval = 5
+)
+
+// WXDeviation holds the state of a pilot's deviation around convective
+// weather, from requesting it until they're clear of the weather.
+type WXDeviation struct {
+	Degrees     int // negative is left
+	RequestTime time.Time
+	Approved    bool
+	Denied      bool
+	Deviating   bool
+}
+
+func (d *WXDeviation) Direction() string {
+	return util.Select(d.Degrees < 0, "left", "right")
+}
+
+// wxDistanceAhead returns the distance in nm to the nearest precipitation
+// that pilots would deviate around along the given true heading from p,
+// or 0 if there is none within the lookahead distance.
+func (s *Sim) wxDistanceAhead(p math.Point2LL, hdg float32) float32 {
+	pnm := math.LL2NM(p, s.State.NmPerLongitude)
+	v := math.SinCos(math.Radians(hdg))
+	for d := float32(2); d <= wxLookahead; d += 2 {
+		q := math.NM2LL(math.Add2f(pnm, math.Scale2f(v, d)), s.State.NmPerLongitude)
+		if wx.DBZToLevel(s.wxModel.PrecipDBZ(q, s.State.SimTime)) >= wxDeviationLevel {
+			return d
+		}
+	}
+	return 0
+}
+
+// wxDeviationEligible returns true if the aircraft is one that would
+// deviate around weather: it's an airborne IFR aircraft flying its route
+// (rather than being vectored) and it hasn't been cleared for an
+// approach.
+func wxDeviationEligible(ac *Aircraft) bool {
+	h := ac.Nav.Heading
+	return ac.FlightPlan.Rules == av.FlightRulesIFR && ac.IsAirborne() && !ac.WaitingForLaunch &&
+		ac.Nav.Airwork == nil && h.Assigned == nil && h.Hold == nil && h.RacetrackPT == nil &&
+		h.Standard45PT == nil && h.Arc == nil && !ac.Nav.Approach.Cleared && len(ac.Nav.Waypoints) > 0
+}
+
+// updateWXDeviations looks for aircraft with weather ahead on their
+// route. Such aircraft request a deviation from their controller and
+// fly it once approved. If the controller denies the request or doesn't
+// respond, they deviate anyway once the weather gets close. Once the
+// route ahead is clear, they proceed direct to the next fix on it.
+func (s *Sim) updateWXDeviations() {
+	if s.wxModel == nil {
+		return
+	}
+	if !s.State.LaunchConfig.WeatherDeviations {
+		// They may have been turned off while aircraft were deviating.
+		for _, ac := range util.SortedMap(s.Aircraft) {
+			if dev := ac.WXDeviation; dev != nil {
+				if dev.Deviating {
+					ac.Nav.EndDeviation()
+				}
+				ac.WXDeviation = nil
+			}
+		}
+		return
+	}
+	// Precipitation changes slowly and is sampled every 2nm ahead, so
+	// there's no need to check every second. This is based on the sim
+	// time rather than the time of the last check so that it's unaffected
+	// by restoring checkpoints.
+	if s.State.SimTime.Unix()%wxDeviationCheckInterval != 0 {
+		return
+	}
+
+	for _, ac := range util.SortedMap(s.Aircraft) {
+		if s.flownByPseudoPilot(ac) {
+			// It's up to the pseudo-pilot to deviate.
+			continue
+		}
+		dev := ac.WXDeviation
+
+		if dev != nil && dev.Deviating && ac.Nav.Heading.Deviation == nil {
+			// The controller issued a new heading or route, which
+			// supersedes the deviation.
+			ac.WXDeviation = nil
+			continue
+		}
+		if !wxDeviationEligible(ac) {
+			if dev != nil && dev.Deviating {
+				ac.Nav.EndDeviation()
+			}
+			ac.WXDeviation = nil
+			continue
+		}
+
+		// Work in true headings from here on out.
+		hdg := ac.Heading() - s.State.MagneticVariation
+		humanController := ac.ControllerFrequency != "" && !s.isVirtualController(ac.ControllerFrequency)
+
+		switch {
+		case dev == nil:
+			if s.wxDistanceAhead(ac.Position(), hdg) == 0 {
+				continue
+			}
+			dev = &WXDeviation{Degrees: s.chooseWXDeviation(ac.Position(), hdg), RequestTime: s.State.SimTime}
+			ac.WXDeviation = dev
+			s.lg.Info("requesting weather deviation", slog.String("callsign", string(ac.ADSBCallsign)),
+				slog.Int("degrees", dev.Degrees))
+
+			if humanController {
+				s.enqueuePilotTransmission(ac.ADSBCallsign, TCP(ac.ControllerFrequency), PendingTransmissionRequestDeviation)
+			} else {
+				// Virtual controllers always approve.
+				s.startWXDeviation(ac)
+			}
+
+		case !dev.Deviating:
+			dist := s.wxDistanceAhead(ac.Position(), hdg)
+			if dist == 0 {
+				// The weather isn't in the way any more.
+				ac.WXDeviation = nil
+			} else if dev.Approved {
+				s.startWXDeviation(ac)
+			} else if dist <= wxDeviateAnywayDistance &&
+				(dev.Denied || s.State.SimTime.Sub(dev.RequestTime) > wxDeviationResponseTimeout) {
+				s.startWXDeviation(ac)
+				if humanController {
+					s.enqueuePilotTransmission(ac.ADSBCallsign, TCP(ac.ControllerFrequency), PendingTransmissionDeviatingForWeather)
+				}
+			}
+
+		default:
+			// Deviating; see if the direct route to the next fix is
+			// clear yet.
+			wp := ac.Nav.Waypoints
+			if len(wp) == 0 {
+				continue
+			}
+			routeHdg := math.Heading2LL(ac.Position(), wp[0].Location, s.State.NmPerLongitude, 0)
+			if s.wxDistanceAhead(ac.Position(), routeHdg) == 0 {
+				ac.Nav.EndDeviation()
+				ac.WXDeviation = nil
+				s.lg.Info("clear of weather", slog.String("callsign", string(ac.ADSBCallsign)))
+				if humanController {
+					s.enqueuePilotTransmission(ac.ADSBCallsign, TCP(ac.ControllerFrequency), PendingTransmissionClearOfWeather)
+				}
+			}
+		}
+	}
+}
+
+// chooseWXDeviation returns the number of degrees left (negative) or right
+// of the given true heading that avoids the weather ahead with the
+// smallest turn. If none do, the largest deviation toward the side with
+// weather further away is returned.
+func (s *Sim) chooseWXDeviation(p math.Point2LL, hdg float32) int {
+	best, bestDist := -40, float32(0)
+	for _, degrees := range []int{-20, 20, -30, 30, -40, 40} {
+		dist := s.wxDistanceAhead(p, hdg+float32(degrees))
+		if dist == 0 {
+			return degrees
+		} else if (degrees == -40 || degrees == 40) && dist > bestDist {
+			best, bestDist = degrees, dist
+		}
+	}
+	return best
+}
+
+func (s *Sim) startWXDeviation(ac *Aircraft) {
+	dev := ac.WXDeviation
+	dev.Deviating = true
+	ac.Nav.DeviateForWeather(math.NormalizeHeading(ac.Heading() + float32(dev.Degrees)))
+}
+
+// ApproveDeviation approves an aircraft's pending request to deviate
+// around weather.
+func (s *Sim) ApproveDeviation(tcw TCW, callsign av.ADSBCallsign) (av.CommandIntent, error) {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	return s.dispatchControlledAircraftCommand(tcw, callsign,
+		func(tcw TCW, ac *Aircraft) av.CommandIntent {
+			if ac.WXDeviation == nil || ac.WXDeviation.Deviating {
+				return av.MakeUnableIntent("unable. We don't need to deviate")
+			}
+			ac.WXDeviation.Approved = true
+			return av.NavigationIntent{Type: av.NavDeviationApproved}
+		})
+}
+
+// DenyDeviation denies an aircraft's pending request to deviate around
+// weather. The pilot will stay on course for as long as they can but will
+// still deviate if the weather gets too close.
+func (s *Sim) DenyDeviation(tcw TCW, callsign av.ADSBCallsign) (av.CommandIntent, error) {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	return s.dispatchControlledAircraftCommand(tcw, callsign,
+		func(tcw TCW, ac *Aircraft) av.CommandIntent {
+			if ac.WXDeviation == nil || ac.WXDeviation.Deviating {
+				return av.MakeUnableIntent("unable. We haven't requested a deviation")
+			}
+			ac.WXDeviation.Denied = true
+			return av.NavigationIntent{Type: av.NavDeviationDenied}
+		})
+}
@@ -0,0 +1,114 @@
+// sim/deviation_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"testing"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/log"
+	"github.com/mmp/vice/math"
+	"github.com/mmp/vice/nav"
+	"github.com/mmp/vice/wx"
+)
+
+func TestWXDeviation(t *testing.T) {
+	db := av.DB
+	t.Cleanup(func() { av.DB = db })
+	av.DB = &av.StaticDatabase{TRACONs: map[string]av.TRACON{"TST": {}}}
+
+	origin := math.Point2LL{-73, 41}
+	nmPerLongitude := math.NMPerLongitudeAt(origin)
+	nm := func(east, north float32) math.Point2LL {
+		return math.NM2LL(math.Add2f(math.LL2NM(origin, nmPerLongitude), [2]float32{east, north}), nmPerLongitude)
+	}
+
+	// A level 5 cell 2nm wide, 8-12nm north of the origin.
+	start := time.Date(2025, time.November, 1, 12, 0, 0, 0, time.UTC)
+	sw := (&wx.ScriptedWeather{
+		Conditions: []wx.ScriptedConditions{{At: 0}},
+		Precip: []wx.ScriptedPrecip{{
+			Polygon: []math.Point2LL{nm(-1, 8), nm(1, 8), nm(1, 12), nm(-1, 12)},
+			DBZ:     52,
+		}},
+	}).Anchor(start, origin)
+	lg := log.New(false, "error", "")
+
+	ac := &Aircraft{
+		ADSBCallsign: "AAL1",
+		FlightPlan:   av.FlightPlan{Rules: av.FlightRulesIFR},
+		Nav: nav.Nav{
+			FlightState: nav.FlightState{
+				Position:       origin,
+				Altitude:       12000,
+				Heading:        360,
+				IAS:            250,
+				NmPerLongitude: nmPerLongitude,
+			},
+			Waypoints: []av.Waypoint{{Fix: "NORTH", Location: nm(0, 40)}},
+		},
+	}
+	s := &Sim{
+		Aircraft:    map[av.ADSBCallsign]*Aircraft{"AAL1": ac},
+		eventStream: NewEventStream(nil),
+		State:       &CommonState{},
+		wxModel:     wx.MakeModel(wx.MakeScriptedProvider(sw), "TST", "", start, true, lg),
+	}
+	defer s.eventStream.Destroy()
+	s.State.NmPerLongitude = nmPerLongitude
+	s.State.SimTime = start
+	s.State.LaunchConfig.WeatherDeviations = true
+
+	if d := s.wxDistanceAhead(origin, 0); d < 8 || d > 10 {
+		t.Errorf("expected weather 8-10nm ahead, got %.1f", d)
+	}
+	if d := s.wxDistanceAhead(origin, 180); d != 0 {
+		t.Errorf("expected no weather behind, got %.1f", d)
+	}
+	// 20 degrees left of course misses the cell.
+	if deg := s.chooseWXDeviation(origin, 0); deg != -20 {
+		t.Errorf("expected a 20 degree left deviation, got %d", deg)
+	}
+
+	// Aircraft are only checked every wxDeviationCheckInterval seconds.
+	s.State.SimTime = start.Add(time.Second)
+	s.updateWXDeviations()
+	if ac.WXDeviation != nil {
+		t.Fatalf("deviation requested between checks")
+	}
+
+	// With no human controller, the deviation is approved right away.
+	s.State.SimTime = start.Add(wxDeviationCheckInterval * time.Second)
+	s.updateWXDeviations()
+	if ac.WXDeviation == nil || !ac.WXDeviation.Deviating || ac.WXDeviation.Degrees != -20 {
+		t.Fatalf("expected a 20 degree left deviation, got %+v", ac.WXDeviation)
+	}
+	if hdg := ac.Nav.Heading.Deviation; hdg == nil || *hdg != 340 {
+		t.Fatalf("expected deviation heading 340, got %v", hdg)
+	}
+
+	// Once past the cell, the aircraft rejoins its route.
+	ac.Nav.FlightState.Position = nm(-5, 14)
+	s.State.SimTime = s.State.SimTime.Add(wxDeviationCheckInterval * time.Second)
+	s.updateWXDeviations()
+	if ac.WXDeviation != nil || ac.Nav.Heading.Deviation != nil {
+		t.Errorf("expected the deviation to end once clear of the weather, got %+v", ac.WXDeviation)
+	}
+
+	// Deviations end if they're turned off.
+	ac.Nav.FlightState.Position = origin
+	s.State.SimTime = s.State.SimTime.Add(wxDeviationCheckInterval * time.Second)
+	s.updateWXDeviations()
+	if ac.WXDeviation == nil {
+		t.Fatalf("expected a new deviation")
+	}
+	s.State.LaunchConfig.WeatherDeviations = false
+	s.State.SimTime = s.State.SimTime.Add(time.Second)
+	s.updateWXDeviations()
+	if ac.WXDeviation != nil || ac.Nav.Heading.Deviation != nil {
+		t.Errorf("expected the deviation to end when deviations are disabled, got %+v", ac.WXDeviation)
+	}
+}
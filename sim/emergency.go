@@ -5,7 +5,6 @@
 package sim
 
 import (
-	"maps"
 	"slices"
 	"strings"
 	"time"
@@ -167,6 +166,14 @@ type EmergencyStage struct {
 	RequestEquipment    bool   `json:"request_equipment"`
 	RequestDelayVectors bool   `json:"request_delay_vectors"`
 	DeclareEmergency    bool   `json:"declare_emergency"`
+	LostComms           bool   `json:"lost_comms"`
+	Blunder             bool   `json:"blunder"`
+}
+
+// IsBlunder returns true if the emergency has the aircraft blunder off of
+// a monitored final approach course.
+func (em *Emergency) IsBlunder() bool {
+	return slices.ContainsFunc(em.Stages, func(st EmergencyStage) bool { return st.Blunder })
 }
 
 // EmergencyState tracks the current state of an aircraft's emergency.
@@ -185,11 +192,18 @@ func (s *Sim) triggerEmergency(idx int) bool {
 	em := &s.State.Emergencies[idx]
 
 	// Sample aircraft with weight 0 for virtual-controlled or existing emergencies
-	ac, ok := rand.SampleWeightedSeq(s.Rand, maps.Values(s.Aircraft), func(ac *Aircraft) float32 {
+	ac, ok := rand.SampleWeighted(s.Rand, util.MapSlice(util.SortedMapKeys(s.Aircraft),
+		func(cs av.ADSBCallsign) *Aircraft { return s.Aircraft[cs] }), func(ac *Aircraft) float32 {
 		if ac.EmergencyState != nil {
 			return 0
 		}
 
+		if em.IsBlunder() {
+			if pair, _ := s.fmaPairForAircraft(ac); pair == nil {
+				return 0
+			}
+		}
+
 		humanAllocated := !s.isVirtualController(ac.ControllerFrequency)
 		return util.Select(em.ApplicableTo.Applies(ac, humanAllocated), em.Weight, float32(0))
 	})
@@ -355,6 +369,22 @@ func (s *Sim) runEmergencyStage(ac *Aircraft) {
 
 	stage := es.Emergency.Stages[es.CurrentStage]
 
+	if stage.LostComms {
+		// No transmission, though squawking 7600 will get the
+		// controller's attention.
+		s.startNORDO(ac)
+		s.advanceEmergencyStage(ac, stage)
+		return
+	}
+
+	if stage.Blunder {
+		// Nothing is said; the final monitor controller should notice
+		// the aircraft heading toward the NTZ.
+		s.startBlunder(ac)
+		s.advanceEmergencyStage(ac, stage)
+		return
+	}
+
 	// Build transmission with various options
 	var transmission []string
 	var args []any
@@ -462,7 +492,13 @@ func (s *Sim) runEmergencyStage(ac *Aircraft) {
 	rt := av.MakeContactTransmission(strings.Join(transmission, ", "), args...)
 	s.enqueueEmergencyTransmission(ac.ADSBCallsign, TCP(ac.ControllerFrequency), rt)
 
-	// Schedule next stage based on current stage's duration
+	s.advanceEmergencyStage(ac, stage)
+}
+
+// advanceEmergencyStage schedules the next stage of the aircraft's
+// emergency based on the current stage's duration.
+func (s *Sim) advanceEmergencyStage(ac *Aircraft, stage EmergencyStage) {
+	es := ac.EmergencyState
 	es.CurrentStage++
 	if es.CurrentStage < len(es.Emergency.Stages) {
 		dur := stage.DurationMinutes
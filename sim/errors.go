@@ -30,14 +30,19 @@ var (
 	ErrInvalidVolumeId                 = errors.New("Invalid ATPA volume ID")
 	ErrNoMatchingFlight                = errors.New("No matching flight")
 	ErrNoMatchingFlightPlan            = errors.New("No matching flight plan")
+	ErrNoNamedCheckpoint               = errors.New("No checkpoint with that name")
 	ErrNoRecentCommand                 = errors.New("No recent command to roll back")
 	ErrNoVFRAircraftForFlightFollowing = errors.New("No VFR aircraft available for flight following")
+	ErrNotInstructor                   = errors.New("Not signed in as an instructor")
 	ErrNotLaunchController             = errors.New("Not signed in as the launch controller")
+	ErrNotPseudoPilot                  = errors.New("Not signed in as a pseudo-pilot")
+	ErrNotPseudoPilotAircraft          = errors.New("Aircraft is not flown by this pseudo-pilot")
 	ErrTCPAlreadyConsolidated          = errors.New("TCP already consolidated - deconsolidate first")
 	ErrTCPNotConsolidated              = errors.New("TCP is not consolidated")
 	ErrTCWIsConsolidated               = errors.New("receiving TCW is a consolidated position")
 	ErrTCWNotFound                     = errors.New("TCW not found")
 	ErrTCWNotVacant                    = errors.New("receiving TCW has an associated TCP")
+	ErrTooManyCheckpoints              = errors.New("Too many checkpoints saved")
 	ErrTooManyRestrictionAreas         = errors.New("Too many restriction areas specified")
 	ErrTrackIsActive                   = errors.New("Track is already active")
 	ErrTrackIsBeingHandedOff           = errors.New("Track is currently being handed off")
@@ -45,6 +50,7 @@ var (
 	ErrUnknownAircraftType             = errors.New("Unknown aircraft type")
 	ErrUnknownController               = errors.New("Unknown controller")
 	ErrUnknownControllerFacility       = errors.New("Unknown controller facility")
+	ErrUnknownRunwayConfiguration      = errors.New("Unknown runway configuration")
 	ErrVFRBelowMVA                     = errors.New("VFR aircraft below MVA")
 	ErrVFRSimTookTooLong               = errors.New("VFR simulation took too long")
 	ErrViolatedAirspace                = errors.New("Violated B/C airspace")
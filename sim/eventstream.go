@@ -260,6 +260,16 @@ const (
 	STTCommandEvent
 	FlightPlanDirectEvent
 	FDAMLeaderLineEvent
+	AircraftSpawnedEvent
+	AircraftDepartedEvent
+	AircraftLandedEvent
+	AircraftDeletedEvent
+	LossOfSeparationEvent
+	SpacingGoAroundEvent
+	MSAWEvent
+	WakeEncounterEvent
+	SeparationRegainedEvent
+	PseudoPilotCommandEvent
 )
 
 func (t EventType) String() string {
@@ -268,7 +278,9 @@ func (t EventType) String() string {
 		"ServerBroadcastMessage", "GlobalMessage", "AcknowledgedPointOut", "RejectedPointOut",
 		"SetGlobalLeaderLine", "ForceQL", "TransferAccepted", "TransferRejected",
 		"RecalledPointOut", "FlightPlanAssociated", "FixCoordinates", "STTCommand", "FlightPlanDirect",
-		"FDAMLeaderLine"}[t]
+		"FDAMLeaderLine", "AircraftSpawned", "AircraftDeparted", "AircraftLanded", "AircraftDeleted",
+		"LossOfSeparation", "SpacingGoAround", "MSAW", "WakeEncounter", "SeparationRegained",
+		"PseudoPilotCommand"}[t]
 }
 
 type Event struct {
@@ -281,12 +293,14 @@ type Event struct {
 	WrittenText           string
 	SpokenText            string
 	RadioTransmissionType av.RadioTransmissionType       // For radio transmissions only
+	Readback              bool                           // Radio transmission responding to the controller
 	LeaderLineDirection   *math.CardinalOrdinalDirection // SetGlobalLeaderLineEvent, FDAMLeaderLineEvent
 	WaypointInfo          []math.Point2LL
 	STTTranscript         string
 	STTCommand            string
 	STTTimings            string
 	Route                 av.WaypointArray // For QU
+	SeparationLoss        *SeparationLoss  // LossOfSeparationEvent, SeparationRegainedEvent
 }
 
 func (e *Event) String() string {
@@ -0,0 +1,122 @@
+// sim/fma.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"log/slog"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/math"
+)
+
+// Simultaneous independent approaches to parallel runways are watched by
+// final monitor controllers, who issue break-out instructions to aircraft
+// on the adjacent final if an aircraft "blunders" toward the no
+// transgression zone (NTZ) between the two final approach courses.
+// Blunders are injected through the emergency system.
+
+// fmaPairForAircraft returns the FMA pair for the runway of the approach
+// the aircraft has been assigned if it is on the corresponding final
+// approach course, and the index of the runway within the pair.
+func (s *Sim) fmaPairForAircraft(ac *Aircraft) (*av.FMAPair, int) {
+	if !ac.IsArrival() || ac.Nav.Approach.Assigned == nil {
+		return nil, 0
+	}
+	ap, ok := s.State.Airports[ac.FlightPlan.ArrivalAirport]
+	if !ok {
+		return nil, 0
+	}
+
+	rwy := ac.Nav.Approach.Assigned.Runway
+	for i := range ap.FMAPairs {
+		pair := &ap.FMAPairs[i]
+		for j, r := range pair.Runways {
+			if r == rwy && pair.Monitored(ac.Position(), ac.Altitude(), ac.NmPerLongitude(), ac.MagneticVariation()) {
+				return pair, j
+			}
+		}
+	}
+	return nil, 0
+}
+
+// tcwMonitorsAircraft returns true if the TCW controls the final monitor
+// position for the final approach course that the aircraft is on.
+func (s *Sim) tcwMonitorsAircraft(tcw TCW, ac *Aircraft) bool {
+	pair, _ := s.fmaPairForAircraft(ac)
+	return pair != nil && pair.MonitorController != "" && s.State.TCWControlsPosition(tcw, pair.MonitorController)
+}
+
+// TCWMonitorsTrack returns true if the TCW controls the final monitor
+// position for a final approach course that the track is on.
+func (ss *CommonState) TCWMonitorsTrack(tcw TCW, trk *Track) bool {
+	if !trk.IsArrival() {
+		return false
+	}
+	ap, ok := ss.Airports[trk.ArrivalAirport]
+	if !ok {
+		return false
+	}
+	for _, pair := range ap.FMAPairs {
+		if pair.MonitorController != "" && ss.TCWControlsPosition(tcw, pair.MonitorController) &&
+			pair.Monitored(trk.Location, trk.TransponderAltitude, ss.NmPerLongitude, ss.MagneticVariation) {
+			return true
+		}
+	}
+	return false
+}
+
+// startBlunder has an aircraft on a monitored final turn off of the final
+// approach course toward the adjacent one. It does so without saying
+// anything to ATC.
+func (s *Sim) startBlunder(ac *Aircraft) {
+	pair, _ := s.fmaPairForAircraft(ac)
+	if pair == nil {
+		s.lg.Warnf("%s: not on a monitored final; unable to blunder", ac.ADSBCallsign)
+		return
+	}
+
+	// The NTZ centerline is to the left of the final approach course if
+	// the aircraft is to its right.
+	_, across := pair.FinalOffset(ac.Position(), ac.NmPerLongitude(), ac.MagneticVariation())
+	deg := float32(20 + s.Rand.Intn(16))
+	var hdg float32
+	var turn av.TurnDirection
+	if across > 0 {
+		hdg, turn = pair.Heading-deg, av.TurnLeft
+	} else {
+		hdg, turn = pair.Heading+deg, av.TurnRight
+	}
+	hdg = math.NormalizeHeading(hdg)
+
+	ac.Nav.AssignHeading(hdg, turn, s.State.SimTime)
+
+	s.lg.Info("final approach blunder", slog.String("adsb_callsign", string(ac.ADSBCallsign)),
+		slog.Float64("heading", float64(hdg)), slog.String("runways", pair.Runways[0]+"/"+pair.Runways[1]))
+}
+
+// Breakout issues break-out instructions to an aircraft on final: a turn
+// to the given heading and optionally a climb or descent to the given
+// altitude.
+func (ac *Aircraft) Breakout(hdg int, turn av.TurnDirection, altitude int, simTime time.Time) av.CommandIntent {
+	intent := ac.Nav.AssignHeading(float32(hdg), turn, simTime)
+	hi, ok := intent.(av.HeadingIntent)
+	if !ok {
+		return intent
+	}
+
+	// Pilots are trained to respond immediately to break-out instructions.
+	if dh := ac.Nav.DeferredNavHeading; dh != nil {
+		dh.Time = simTime.Add(time.Duration(1+ac.Nav.Rand.Intn(2)) * time.Second)
+	}
+
+	bi := av.BreakoutIntent{Heading: hi}
+	if altitude != 0 {
+		if ai, ok := ac.Nav.AssignAltitude(float32(altitude), false).(av.AltitudeIntent); ok {
+			bi.Altitude = &ai
+		}
+	}
+	return bi
+}
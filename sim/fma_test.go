@@ -0,0 +1,98 @@
+// sim/fma_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"testing"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/math"
+)
+
+func TestFinalApproachBlunder(t *testing.T) {
+	s := makeTestSim(t)
+	// Runways 8L and 9R, landing east, 1.2nm apart.
+	s.State.Airports = map[string]*av.Airport{"KATL": {
+		FMAPairs: []av.FMAPair{{
+			Runways:    [2]string{"8L", "9R"},
+			NTZLength:  10,
+			Ceiling:    6000,
+			Thresholds: [2]math.Point2LL{testPoint(0, 0.6), testPoint(0, -0.6)},
+			Heading:    90,
+		}},
+	}}
+
+	// Arrivals are 5nm from the thresholds.
+	makeArrival := func(rwy string, north float32) *Aircraft {
+		ac := makeTestAircraft("DAL1", testPoint(-5, north), 3000, 90, 160)
+		ac.TypeOfFlight = av.FlightTypeArrival
+		ac.FlightPlan.ArrivalAirport = "KATL"
+		ac.Nav.Perf.Ceiling = 41000
+		ac.Nav.Approach.Assigned = &av.Approach{Runway: rwy}
+		return ac
+	}
+
+	// Aircraft on the northern final should turn right, toward the
+	// southern one, and vice versa.
+	for _, test := range []struct {
+		rwy   string
+		north float32
+		idx   int
+		turn  av.TurnDirection
+	}{{"8L", 0.6, 0, av.TurnRight}, {"9R", -0.6, 1, av.TurnLeft}} {
+		ac := makeArrival(test.rwy, test.north)
+		pair, idx := s.fmaPairForAircraft(ac)
+		if pair == nil || idx != test.idx {
+			t.Fatalf("%s: expected FMA pair index %d, got %v %d", test.rwy, test.idx, pair, idx)
+		}
+
+		s.startBlunder(ac)
+		dh := ac.Nav.DeferredNavHeading
+		if dh == nil || dh.Heading == nil || *dh.Turn != test.turn {
+			t.Fatalf("%s: expected blunder turn %v, got %+v", test.rwy, test.turn, dh)
+		}
+		if d := math.HeadingDifference(*dh.Heading, 90); d < 20 || d > 35 {
+			t.Errorf("%s: blunder heading %.0f is %.0f degrees off the final", test.rwy, *dh.Heading, d)
+		}
+	}
+
+	// Not monitored above the ceiling or on a runway that isn't in a pair.
+	high := makeArrival("8L", 0.6)
+	high.Nav.FlightState.Altitude = 8000
+	if pair, _ := s.fmaPairForAircraft(high); pair != nil {
+		t.Errorf("aircraft above the FMA ceiling is monitored")
+	}
+	if pair, _ := s.fmaPairForAircraft(makeArrival("8R", 0.6)); pair != nil {
+		t.Errorf("aircraft landing on 8R is monitored")
+	}
+
+	// Break-outs are executed promptly.
+	ac := makeArrival("9R", -0.6)
+	intent := ac.Breakout(180, av.TurnRight, 4000, s.State.SimTime)
+	bi, ok := intent.(av.BreakoutIntent)
+	if !ok || bi.Heading.Heading != 180 || bi.Altitude == nil || bi.Altitude.Altitude != 4000 {
+		t.Fatalf("unexpected break-out intent %+v", intent)
+	}
+	if dt := ac.Nav.DeferredNavHeading.Time.Sub(s.State.SimTime); dt > 3*time.Second {
+		t.Errorf("break-out delayed by %s", dt)
+	}
+
+	// The final monitor can issue break-outs to aircraft it isn't
+	// controlling, but other controllers can't.
+	s.State.Airports["KATL"].FMAPairs[0].MonitorController = "1M"
+	s.State.CurrentConsolidation = map[TCW]*TCPConsolidation{"1M": {PrimaryTCP: "1M"}, "2A": {PrimaryTCP: "2A"}}
+	ac = makeArrival("8L", 0.6)
+	ac.ControllerFrequency = "1T"
+	s.Aircraft = map[av.ADSBCallsign]*Aircraft{ac.ADSBCallsign: ac}
+	if _, err := s.Breakout("2A", ac.ADSBCallsign, 360, av.TurnLeft, 0); err != av.ErrOtherControllerHasTrack {
+		t.Errorf("break-out from a controller that isn't monitoring the final: %v", err)
+	}
+	if intent, err := s.Breakout("1M", ac.ADSBCallsign, 360, av.TurnLeft, 0); err != nil {
+		t.Errorf("final monitor unable to issue a break-out: %v", err)
+	} else if _, ok := intent.(av.BreakoutIntent); !ok {
+		t.Errorf("unexpected break-out intent %+v", intent)
+	}
+}
@@ -0,0 +1,91 @@
+// sim/helpers_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"testing"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/log"
+	"github.com/mmp/vice/math"
+	"github.com/mmp/vice/nav"
+	"github.com/mmp/vice/rand"
+	"github.com/mmp/vice/util"
+)
+
+// Shared fixture for tests that exercise a Sim with a few hand-placed
+// aircraft: everything happens around testCenter, starting at
+// testStartTime.
+const testNmPerLongitude = 45
+
+var (
+	testCenter    = math.Point2LL{-73, 41}
+	testStartTime = time.Date(2025, time.November, 1, 12, 0, 0, 0, time.UTC)
+)
+
+// testPoint returns the point that is the given number of nm east and
+// north of testCenter.
+func testPoint(east, north float32) math.Point2LL {
+	return math.Point2LL{testCenter[0] + east/testNmPerLongitude, testCenter[1] + north/60}
+}
+
+// makeTestAircraft returns an IFR aircraft with an altitude-reporting
+// transponder flying at the given position, altitude, heading, and speed.
+func makeTestAircraft(callsign av.ADSBCallsign, p math.Point2LL, altitude, heading, ias float32) *Aircraft {
+	ac := &Aircraft{
+		ADSBCallsign: callsign,
+		Mode:         av.TransponderModeAltitude,
+		FlightPlan:   av.FlightPlan{Rules: av.FlightRulesIFR},
+		Nav: nav.Nav{
+			Rand: rand.Make(),
+			FlightState: nav.FlightState{
+				Position:       p,
+				Altitude:       altitude,
+				Heading:        heading,
+				IAS:            ias,
+				GS:             ias,
+				NmPerLongitude: testNmPerLongitude,
+			},
+		},
+	}
+	ac.Nav.Rand.Seed(util.HashString64(string(callsign)))
+	return ac
+}
+
+// makeTestPerformance returns performance characteristics along the
+// lines of a narrowbody jet's.
+func makeTestPerformance() av.AircraftPerformance {
+	var perf av.AircraftPerformance
+	perf.Engine.AircraftType = "J"
+	perf.Ceiling = 41000
+	perf.Rate.Climb, perf.Rate.Descent = 2500, 2000
+	perf.Rate.Accelerate, perf.Rate.Decelerate = 5, 5
+	perf.Speed.Min, perf.Speed.Landing = 120, 140
+	perf.Speed.CruiseTAS, perf.Speed.MaxTAS = 450, 500
+	perf.Turn.MaxBankAngle, perf.Turn.MaxBankRate = 25, 3
+	return perf
+}
+
+// makeTestSim returns a Sim at testStartTime holding the given aircraft.
+// Its event stream is destroyed when the test finishes.
+func makeTestSim(t *testing.T, aircraft ...*Aircraft) *Sim {
+	s := &Sim{
+		Aircraft:    make(map[av.ADSBCallsign]*Aircraft),
+		eventStream: NewEventStream(nil),
+		State:       &CommonState{},
+		Rand:        rand.Make(),
+		lg:          log.New(false, "error", ""),
+	}
+	t.Cleanup(s.eventStream.Destroy)
+	s.Rand.Seed(1)
+	s.State.Center = testCenter
+	s.State.NmPerLongitude = testNmPerLongitude
+	s.State.SimTime = testStartTime
+	for _, ac := range aircraft {
+		s.Aircraft[ac.ADSBCallsign] = ac
+	}
+	return s
+}
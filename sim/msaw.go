@@ -0,0 +1,44 @@
+// sim/msaw.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/util"
+)
+
+// checkMSAW issues minimum safe altitude warnings for associated aircraft
+// that are below the MVA, following the same rules as STARS: MSAW must be
+// enabled for the flight plan, the aircraft must not be established on an
+// approach, and it must be outside of the MSAW inhibit filters.
+func (s *Sim) checkMSAW() {
+	if s.mvaGrid == nil {
+		s.initializeAirspaceGrids()
+	}
+	inhibitMSAW := s.State.FacilityAdaptation.Filters.InhibitMSAW
+
+	for _, ac := range util.SortedMap(s.Aircraft) {
+		warn := false
+		if ac.IsAssociated() && !ac.NASFlightPlan.DisableMSAW && ac.MVAsApply() && ac.IsAirborne() &&
+			ac.Mode == av.TransponderModeAltitude {
+			alt := int(ac.Altitude())
+			if !inhibitMSAW.Inside(ac.Position(), alt) {
+				mva := s.mvaGrid.GetMVA(ac.Position())
+				warn = mva > 0 && alt < mva
+			}
+		}
+
+		if warn && !ac.MSAWAlert {
+			// It's a new alert
+			s.eventStream.Post(Event{
+				Type:         MSAWEvent,
+				ADSBCallsign: ac.ADSBCallsign,
+				ACID:         ac.NASFlightPlan.ACID,
+				ToController: ac.NASFlightPlan.TrackingController,
+			})
+		}
+		ac.MSAWAlert = warn
+	}
+}
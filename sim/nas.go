@@ -38,8 +38,8 @@ func makeERAMComputer(fac string, loc *av.LocalSquawkCodePool) *ERAMComputer {
 	return ec
 }
 
-func (ec *ERAMComputer) CreateSquawk() (av.Squawk, error) {
-	return ec.SquawkCodePool.Get(rand.Make())
+func (ec *ERAMComputer) CreateSquawk(r *rand.Rand) (av.Squawk, error) {
+	return ec.SquawkCodePool.Get(r)
 }
 
 func (ec *ERAMComputer) ReturnSquawk(code av.Squawk) error {
@@ -96,7 +96,7 @@ func (sc *STARSComputer) Update(s *Sim) {
 		return true
 	})
 
-	for _, ac := range s.Aircraft {
+	for _, ac := range util.SortedMap(s.Aircraft) {
 		if !ac.IsAirborne() || ac.Squawk == 0o1200 {
 			continue
 		}
@@ -139,6 +139,12 @@ func (sc *STARSComputer) Update(s *Sim) {
 					return false
 				}
 
+				if fp.CoastDropped {
+					// Reacquire tracks that were dropped after coasting,
+					// once the radars can see them again.
+					return s.hasRadarReturns(ac)
+				}
+
 				if !fp.DeleteTime.IsZero() {
 					// Don't auto-associate flight plans that have been dropped.
 					return false
@@ -196,6 +202,10 @@ func (sc *STARSComputer) Update(s *Sim) {
 					if s.State.IsLocalController(fp.TrackingController) {
 						fp.LastLocalController = fp.TrackingController
 					}
+					if fp.CoastDropped {
+						fp.CoastDropped = false
+						fp.DeleteTime = time.Time{}
+					}
 
 					ac.AssociateFlightPlan(fp)
 
@@ -0,0 +1,358 @@
+// sim/nordo.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/math"
+	"github.com/mmp/vice/nav"
+	"github.com/mmp/vice/util"
+)
+
+// Two-way radio communications failure ("NORDO") handling, following
+// 14 CFR 91.185(c) for IFR aircraft in IMC: the route flown is the
+// assigned one, or if being vectored, direct back to the route (AVE-F:
+// assigned, vectored, expected, filed), and the altitude is the highest
+// of the last assigned altitude, the minimum altitude for IFR operations
+// (approximated using the MVAs), and the altitude ATC said to expect
+// (M-E-A). Arrivals hold at their clearance limit if they get there early
+// and start the approach at their ETA.
+
+const (
+	// Probability that the aircraft's receiver still works, in which case
+	// it will ident when asked to.
+	nordoReceiverProbability = 0.5
+	// Departures are assumed to have been told to expect their filed
+	// altitude this long after departure.
+	nordoExpectedAltitudeDelay = 10 * time.Minute
+	// Arrivals hold at the clearance limit if they will get there this
+	// much earlier than their ETA.
+	nordoMinimumHoldTime = 2 * time.Minute
+)
+
+// NORDOState holds the state of an aircraft that has lost two-way radio
+// communications with ATC.
+type NORDOState struct {
+	Start time.Time
+	// Set if the aircraft can still hear ATC, though it can't transmit.
+	ReceiverWorks bool
+	// Code the aircraft was squawking before it changed to 7600.
+	Squawk av.Squawk
+
+	// Altitude last assigned by ATC before the failure.
+	AssignedAltitude int
+	// Altitude ATC told the aircraft to expect and when to expect it.
+	ExpectedAltitude     int
+	ExpectedAltitudeTime time.Time
+
+	// For arrivals: the approach the aircraft will fly, the fix from which
+	// it begins, and the time at which it will start the approach.
+	Approach        string
+	ClearanceLimit  string
+	ETA             time.Time
+	StartedApproach bool
+}
+
+// startNORDO puts the aircraft into the lost communications state.
+func (s *Sim) startNORDO(ac *Aircraft) {
+	if ac.NORDO != nil {
+		return
+	}
+
+	n := &NORDOState{
+		Start:            s.State.SimTime,
+		ReceiverWorks:    s.Rand.Float32() < nordoReceiverProbability,
+		Squawk:           ac.Squawk,
+		AssignedAltitude: int(clearedAltitude(ac)),
+	}
+	if n.AssignedAltitude == 0 {
+		n.AssignedAltitude = int(ac.Altitude())
+	}
+	ac.NORDO = n
+	ac.PilotRequest = nil
+
+	s.enqueueTransponderChange(ac.ADSBCallsign, 0o7600, ac.Mode)
+
+	// If being vectored, proceed direct back to the route.
+	if ac.Nav.Heading.Assigned != nil {
+		ac.ResumeOwnNavigation()
+	}
+
+	if ac.IsDeparture() {
+		dep := util.Select(ac.FirstSeen.IsZero(), s.State.SimTime, ac.FirstSeen)
+		n.ExpectedAltitude = ac.FlightPlan.Altitude
+		n.ExpectedAltitudeTime = dep.Add(nordoExpectedAltitudeDelay)
+	} else if ac.IsArrival() {
+		s.planNORDOArrival(ac, n)
+	}
+
+	s.lg.Info("lost communications", slog.String("adsb_callsign", string(ac.ADSBCallsign)),
+		slog.Bool("receiver_works", n.ReceiverWorks), slog.Int("assigned_altitude", n.AssignedAltitude),
+		slog.String("approach", n.Approach), slog.String("clearance_limit", n.ClearanceLimit),
+		slog.Time("eta", n.ETA))
+}
+
+// planNORDOArrival determines the approach a NORDO arrival will fly, the
+// fix from which it will start it, and when it will do so.
+func (s *Sim) planNORDOArrival(ac *Aircraft, n *NORDOState) {
+	airport := s.State.Airports[ac.FlightPlan.ArrivalAirport]
+	if airport == nil {
+		return
+	}
+
+	// Fly the approach ATC said to expect, if any, and otherwise the one
+	// most likely in use for the active runway.
+	n.Approach = ac.Nav.Approach.AssignedId
+	if n.Approach == "" {
+		for _, ar := range s.State.ArrivalRunways {
+			if ar.Airport == ac.FlightPlan.ArrivalAirport {
+				if id, _ := preferredApproach(airport, ar.Runway.Base()); id != "" {
+					n.Approach = id
+					ac.ExpectApproach(id, airport, "", s.lg)
+					break
+				}
+			}
+		}
+	}
+	appr := ac.Nav.Approach.Assigned
+	if n.Approach == "" || appr == nil {
+		return
+	}
+
+	// The clearance limit is the first fix on the route that is part of
+	// the approach.
+	onApproach := func(wp av.Waypoint) bool {
+		return slices.ContainsFunc(appr.Waypoints, func(wps av.WaypointArray) bool {
+			return slices.ContainsFunc(wps, func(awp av.Waypoint) bool { return awp.Fix == wp.Fix })
+		})
+	}
+	wps := ac.Nav.AssignedWaypoints()
+	idx := slices.IndexFunc(wps, func(wp av.Waypoint) bool {
+		return !strings.HasPrefix(wp.Fix, "_") && onApproach(wp)
+	})
+
+	gs := max(ac.GS(), 120)
+	if idx == -1 {
+		// Nowhere to hold; start the approach once it's the closest it
+		// will get along the route.
+		d := math.NMDistance2LL(ac.Position(), ac.ArrivalAirportLocation())
+		n.ETA = s.State.SimTime.Add(time.Duration(d / gs * float32(time.Hour)))
+		return
+	}
+
+	n.ClearanceLimit = wps[idx].Fix
+	d, err := ac.DistanceAlongRoute(n.ClearanceLimit)
+	if err != nil {
+		d = math.NMDistance2LL(ac.Position(), wps[idx].Location)
+	}
+	arrive := s.State.SimTime.Add(time.Duration(d / gs * float32(time.Hour)))
+
+	// The ETA the pilot calculated from the flight plan generally doesn't
+	// match up with how the flight has actually gone.
+	n.ETA = arrive.Add(time.Duration(s.Rand.Intn(7*60)) * time.Second)
+
+	if n.ETA.Sub(arrive) >= nordoMinimumHoldTime {
+		var hold *av.Hold
+		if _, ok := av.DB.EnrouteHolds[n.ClearanceLimit]; !ok {
+			// Standard right-turn hold on the inbound course.
+			from := util.Select(idx > 0, wps[max(idx-1, 0)].Location, ac.Position())
+			hold = &av.Hold{
+				Fix:           n.ClearanceLimit,
+				InboundCourse: math.Heading2LL(from, wps[idx].Location, ac.NmPerLongitude(), ac.MagneticVariation()),
+				TurnDirection: av.TurnRight,
+				LegMinutes:    1,
+			}
+		}
+		ac.HoldAtFix(n.ClearanceLimit, hold)
+	}
+}
+
+// nordoMinimumAltitude returns the minimum altitude for IFR operations
+// for the aircraft's route ahead, rounded up to the next thousand feet.
+// 91.185 calls for the MEA, but we don't have the MEAs for the airways and
+// off-airway routes that aircraft fly, so the MVAs around the aircraft's
+// position and its next two fixes stand in for it. The MVAs are generally
+// a bit lower than the MEA, but they do keep the aircraft clear of terrain
+// and obstacles and they're what the controller will be looking at.
+func (s *Sim) nordoMinimumAltitude(ac *Aircraft) int {
+	if s.mvaGrid == nil {
+		s.initializeAirspaceGrids()
+	}
+
+	mva := s.mvaGrid.GetMVA(ac.Position())
+	for i, wp := range ac.Nav.Waypoints {
+		if i == 2 {
+			break
+		}
+		mva = max(mva, s.mvaGrid.GetMVA(wp.Location))
+	}
+	if mva <= 0 {
+		return 0
+	}
+	return 1000 * ((mva + 999) / 1000)
+}
+
+// updateNORDO has aircraft that have lost communications follow the
+// 91.185 altitude rules and start their approach at their ETA.
+func (s *Sim) updateNORDO() {
+	for _, ac := range util.SortedMap(s.Aircraft) {
+		n := ac.NORDO
+		if n == nil || !ac.IsAirborne() || ac.Nav.Approach.Cleared {
+			continue
+		}
+
+		if ac.IsArrival() && !n.StartedApproach && !n.ETA.IsZero() && !s.State.SimTime.Before(n.ETA) {
+			n.StartedApproach = true
+			if _, ok := ac.ClearedApproach(n.Approach, s.State.SimTime, s.lg); ok {
+				// Descend in accordance with the approach procedure.
+				ac.Nav.Altitude = nav.NavAltitude{}
+				s.lg.Info("lost communications: starting approach", slog.String("adsb_callsign", string(ac.ADSBCallsign)),
+					slog.String("approach", n.Approach))
+				continue
+			}
+			s.lg.Warn("lost communications: unable to start approach", slog.String("adsb_callsign", string(ac.ADSBCallsign)),
+				slog.String("approach", n.Approach))
+		}
+
+		alt := max(n.AssignedAltitude, s.nordoMinimumAltitude(ac))
+		if n.ExpectedAltitude != 0 && !s.State.SimTime.Before(n.ExpectedAltitudeTime) {
+			alt = max(alt, n.ExpectedAltitude)
+		}
+		if a := ac.Nav.Altitude.Assigned; a == nil || int(*a) != alt {
+			ac.AssignAltitude(alt, false)
+		}
+	}
+}
+
+// LostCommunications lets an instructor cause an aircraft to lose
+// communications.
+func (s *Sim) LostCommunications(tcw TCW, callsign av.ADSBCallsign) (av.CommandIntent, error) {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	if !s.PrivilegedTCWs[tcw] {
+		return nil, ErrNotInstructor
+	}
+	ac, ok := s.Aircraft[callsign]
+	if !ok {
+		return nil, av.ErrNoAircraftForCallsign
+	}
+	s.startNORDO(ac)
+	return nil, nil
+}
+
+// RestoreCommunications lets an instructor end an aircraft's lost
+// communications. It goes back to its previous squawk code and continues
+// with its current clearance, letting the controller know that it's back.
+func (s *Sim) RestoreCommunications(tcw TCW, callsign av.ADSBCallsign) (av.CommandIntent, error) {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	if !s.PrivilegedTCWs[tcw] {
+		return nil, ErrNotInstructor
+	}
+	ac, ok := s.Aircraft[callsign]
+	if !ok {
+		return nil, av.ErrNoAircraftForCallsign
+	}
+	s.endNORDO(ac)
+	return nil, nil
+}
+
+// endNORDO takes the aircraft out of the lost communications state.
+func (s *Sim) endNORDO(ac *Aircraft) {
+	n := ac.NORDO
+	if n == nil {
+		return
+	}
+	ac.NORDO = nil
+
+	s.enqueueTransponderChange(ac.ADSBCallsign, n.Squawk, ac.Mode)
+
+	if freq := ac.ControllerFrequency; freq != "" && !s.isVirtualController(freq) {
+		alt, _ := ac.Nav.TargetAltitude()
+		rt := av.MakeContactTransmission("[we've got our radios back|we had a radio failure, we're back with you now], [at|for] {alt}", alt)
+		s.addPendingContact(PendingContact{
+			ADSBCallsign:         ac.ADSBCallsign,
+			TCP:                  TCP(freq),
+			Type:                 PendingTransmissionCommsRestored,
+			PrebuiltTransmission: rt,
+		})
+	}
+
+	s.lg.Info("communications restored", slog.String("adsb_callsign", string(ac.ADSBCallsign)))
+}
+
+// runNORDOCommands handles commands issued to an aircraft that has lost
+// communications: there's never a readback. An instructor may delete it
+// or restore its communications with NORDO, after which any remaining
+// commands are handled as usual. If the aircraft can still hear ATC, it
+// complies with the instructions whose receipt the controller can confirm
+// from the radar track; see nordoReceiverCommand.
+func (s *Sim) runNORDOCommands(tcw TCW, ac *Aircraft, commands []string) ControlCommandsResult {
+	for i, command := range commands {
+		switch {
+		case command == "X":
+			s.DeleteAircraft(tcw, ac.ADSBCallsign)
+			return ControlCommandsResult{}
+
+		case command == "NORDO":
+			if _, err := s.RestoreCommunications(tcw, ac.ADSBCallsign); err != nil {
+				return ControlCommandsResult{RemainingInput: strings.Join(commands[i:], " "), Error: err}
+			}
+			if rest := commands[i+1:]; len(rest) > 0 {
+				return s.RunAircraftControlCommands(tcw, ac.ADSBCallsign, strings.Join(rest, " "))
+			}
+			return ControlCommandsResult{}
+
+		case ac.NORDO.ReceiverWorks && nordoReceiverCommand(command):
+			intent, err := s.runOneControlCommand(tcw, ac.ADSBCallsign, command)
+			if err != nil {
+				return ControlCommandsResult{RemainingInput: strings.Join(commands[i:], " "), Error: err}
+			}
+			if alt, ok := intent.(av.AltitudeIntent); ok {
+				// The new altitude supersedes both the last assigned
+				// altitude and the one it was told to expect.
+				ac.NORDO.AssignedAltitude = int(alt.Altitude)
+				ac.NORDO.ExpectedAltitude = 0
+			}
+		}
+	}
+	return ControlCommandsResult{}
+}
+
+// nordoReceiverCommand returns true if the command is one that an aircraft
+// that can hear ATC but can't transmit will comply with: ATC can confirm
+// that it was received from the transponder (ident and squawk changes),
+// the track's heading, or its Mode C altitude, so these are what
+// controllers use to work such an aircraft.
+func nordoReceiverCommand(command string) bool {
+	digits := func(s string) bool { return s != "" && util.IsAllNumbers(s) }
+
+	switch {
+	case command == "":
+		return false
+	case command == "ID" || command == "H" || command == "SQS" || command == "SQA" || command == "SQON":
+		return true
+	case len(command) == 6 && strings.HasPrefix(command, "SQ"):
+		return digits(command[2:])
+	}
+
+	switch command[0] {
+	case 'A', 'C', 'D', 'H':
+		return digits(command[1:])
+	case 'L', 'R':
+		return digits(strings.TrimSuffix(command[1:], "D"))
+	case 'T':
+		n := len(command)
+		return (command[n-1] == 'L' || command[n-1] == 'R') && digits(command[1:n-1])
+	}
+	return false
+}
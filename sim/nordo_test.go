@@ -0,0 +1,135 @@
+// sim/nordo_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"testing"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/math"
+)
+
+func TestNORDOReceiverCommand(t *testing.T) {
+	for _, cmd := range []string{"ID", "SQ1234", "SQS", "SQA", "SQON", "H", "H270", "L180", "R090", "L20D",
+		"T30L", "T15R", "A50", "C120", "D80"} {
+		if !nordoReceiverCommand(cmd) {
+			t.Errorf("%s: expected a NORDO aircraft that can hear ATC to comply", cmd)
+		}
+	}
+	for _, cmd := range []string{"", "S210", "CI22L", "DVS", "DJIMEE", "HJIMEE", "TO", "RON", "SQ12", "X", "TRAFFIC/10/5/60"} {
+		if nordoReceiverCommand(cmd) {
+			t.Errorf("%s: unexpected compliance from a NORDO aircraft", cmd)
+		}
+	}
+}
+
+func TestNORDOCommands(t *testing.T) {
+	makeNORDO := func(receiverWorks bool) (*Sim, *Aircraft) {
+		ac := makePilotRequestTestAircraft(av.FlightTypeOverflight, 10000, 0)
+		ac.Squawk = 0o1234
+		s := makePilotRequestTestSim(t, ac)
+		s.PrivilegedTCWs = map[TCW]bool{"1A": true}
+		if _, err := s.LostCommunications("1A", "AAL1"); err != nil {
+			t.Fatal(err)
+		}
+		if ac.NORDO == nil {
+			t.Fatalf("aircraft didn't lose communications")
+		}
+		ac.NORDO.ReceiverWorks = receiverWorks
+		return s, ac
+	}
+
+	// Without a receiver, instructions are ignored.
+	s, ac := makeNORDO(false)
+	if res := s.RunAircraftControlCommands("1A", "AAL1", "D80 ID"); res.Error != nil {
+		t.Fatal(res.Error)
+	}
+	if alt := ac.Nav.Altitude.Assigned; alt == nil || *alt != 10000 || ac.Nav.Altitude.Cleared != nil {
+		t.Errorf("NORDO aircraft without a receiver followed an altitude assignment")
+	}
+	if !ac.IdentStartTime.IsZero() {
+		t.Errorf("NORDO aircraft without a receiver idented")
+	}
+
+	// With one, it follows the instructions that ATC can confirm from the
+	// track and the new altitude becomes the one it will maintain.
+	s, ac = makeNORDO(true)
+	ac.NORDO.ExpectedAltitude = 35000
+	if res := s.RunAircraftControlCommands("1A", "AAL1", "D80 S210 ID"); res.Error != nil {
+		t.Fatal(res.Error)
+	}
+	if alt := ac.Nav.Altitude.Assigned; alt == nil || *alt != 8000 {
+		t.Errorf("NORDO aircraft with a receiver didn't descend to 8000")
+	}
+	if ac.NORDO.AssignedAltitude != 8000 || ac.NORDO.ExpectedAltitude != 0 {
+		t.Errorf("expected the NORDO altitude to be 8000, got %+v", ac.NORDO)
+	}
+	if ac.Nav.Speed.Assigned != nil {
+		t.Errorf("NORDO aircraft followed a speed assignment")
+	}
+	if ac.IdentStartTime.IsZero() {
+		t.Errorf("NORDO aircraft with a receiver didn't ident")
+	}
+
+	// Only instructors can restore communications.
+	s.PrivilegedTCWs = nil
+	if res := s.RunAircraftControlCommands("1A", "AAL1", "NORDO"); res.Error != ErrNotInstructor || ac.NORDO == nil {
+		t.Errorf("non-instructor restored communications: %v", res.Error)
+	}
+
+	// Restoring communications returns the aircraft to its previous code
+	// and it checks in with the controller.
+	s.PrivilegedTCWs = map[TCW]bool{"1A": true}
+	s.FutureSquawkChanges = nil
+	if res := s.RunAircraftControlCommands("1A", "AAL1", "NORDO"); res.Error != nil {
+		t.Fatal(res.Error)
+	}
+	if ac.NORDO != nil {
+		t.Fatalf("communications weren't restored")
+	}
+	if sq := s.FutureSquawkChanges; len(sq) != 1 || sq[0].Code != 0o1234 {
+		t.Errorf("expected a change back to 1234, got %+v", sq)
+	}
+	if pc := s.PendingContacts["1A"]; len(pc) != 1 || pc[0].Type != PendingTransmissionCommsRestored {
+		t.Errorf("expected the aircraft to check back in, got %+v", pc)
+	}
+}
+
+func TestUpdateNORDOAltitude(t *testing.T) {
+	ac := makePilotRequestTestAircraft(av.FlightTypeOverflight, 6000, 0)
+	s := makePilotRequestTestSim(t, ac)
+
+	// A 9,500' MVA around the aircraft's position.
+	p := ac.Position()
+	ring := [][2]float32{{p[0] - 0.1, p[1] - 0.1}, {p[0] + 0.1, p[1] - 0.1}, {p[0] + 0.1, p[1] + 0.1}, {p[0] - 0.1, p[1] + 0.1}}
+	s.mvaGrid = av.MakeMVAGrid([]av.MVA{{
+		MinimumLimit: 9500,
+		Bounds:       math.Extent2D{P0: ring[0], P1: ring[2]},
+		ExteriorRing: ring,
+	}})
+
+	now := s.State.SimTime
+	ac.NORDO = &NORDOState{
+		Start:                now,
+		AssignedAltitude:     6000,
+		ExpectedAltitude:     14000,
+		ExpectedAltitudeTime: now.Add(5 * time.Minute),
+	}
+
+	// The MVA, rounded up to the next thousand feet, is higher than the
+	// last assigned altitude.
+	s.updateNORDO()
+	if alt := ac.Nav.Altitude.Assigned; alt == nil || *alt != 10000 {
+		t.Errorf("expected to climb to 10000 for the MVA, got %v", alt)
+	}
+
+	// Once it's time, it climbs to the altitude it was told to expect.
+	s.State.SimTime = now.Add(5 * time.Minute)
+	s.updateNORDO()
+	if alt := ac.Nav.Altitude.Assigned; alt == nil || *alt != 14000 {
+		t.Errorf("expected to climb to the expected altitude of 14000, got %v", alt)
+	}
+}
@@ -0,0 +1,258 @@
+// sim/probe.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"slices"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/math"
+	"github.com/mmp/vice/util"
+)
+
+// The ERAM conflict probe projects each flight's trajectory along its
+// route and toward its assigned altitude and reports predicted losses of
+// en route separation (5nm, 1000') up to 20 minutes in the future. A
+// trial plan runs the same check with a candidate altitude or direct
+// clearance for one flight so that the controller can see whether it
+// would resolve a conflict before issuing it.
+
+const (
+	probeLookahead = 20 * time.Minute
+	probeStep      = 30 * time.Second
+	// Separation is checked at this many times per step, interpolating
+	// between trajectory points.
+	probeSubsteps = 10
+	// The probe is rerun at the ERAM track update interval.
+	probeInterval = 12 * time.Second
+
+	probeLateralMinimum = 5
+	// Vertical separation is 1000' through FL410 (RVSM) and 2000' above.
+	probeVerticalMinimum     = 1000
+	probeVerticalMinimumHigh = 2000
+	probeRVSMCeiling         = 41000
+)
+
+// ProbeConflict is a predicted loss of separation between two flights.
+type ProbeConflict struct {
+	ACIDs [2]ACID
+	// Start is when separation is first predicted to be lost.
+	Start time.Time
+	// Lateral (nm) and Vertical (feet) are the minimum predicted
+	// separation during the conflict.
+	Lateral  float32
+	Vertical float32
+}
+
+// probePoint is a predicted position and altitude along a trajectory;
+// they are spaced probeStep apart in time, starting with the current
+// position.
+type probePoint struct {
+	Location math.Point2LL
+	Altitude float32
+}
+
+// probeEligible returns true if the aircraft's flight plan is probed.
+func (s *Sim) probeEligible(ac *Aircraft) bool {
+	return ac.IsAssociated() && ac.IsAirborne() && ac.NASFlightPlan.Rules == av.FlightRulesIFR &&
+		!ac.Nav.Approach.Cleared
+}
+
+// probeCenterTracked returns true if the aircraft is tracked by an ERAM
+// controller; conflicts are only reported if at least one of the two
+// aircraft is, so that the probe doesn't flag terminal sequencing.
+func (s *Sim) probeCenterTracked(ac *Aircraft) bool {
+	ctrl, ok := s.State.Controllers[ac.NASFlightPlan.TrackingController]
+	return ok && ctrl.ERAMFacility
+}
+
+// probeTrajectory predicts the aircraft's trajectory. If altitude is
+// non-zero, it is used in place of the aircraft's assigned altitude, and
+// if direct is non-empty, the aircraft is taken to proceed direct to
+// that fix and then along its route.
+func (s *Sim) probeTrajectory(ac *Aircraft, altitude int, direct string) []probePoint {
+	var route []math.Point2LL
+	wps := ac.Nav.Waypoints
+	if direct != "" {
+		if idx := slices.IndexFunc(wps, func(wp av.Waypoint) bool { return wp.Fix == direct }); idx != -1 {
+			wps = wps[idx:]
+		} else if p, ok := av.DB.LookupWaypoint(direct); ok {
+			wps = []av.Waypoint{{Fix: direct, Location: p}}
+		}
+	} else if ac.Nav.Heading.Assigned != nil {
+		// Vectored aircraft are assumed to continue on their heading.
+		wps = nil
+	}
+	for _, wp := range wps {
+		route = append(route, wp.Location)
+	}
+
+	target := float32(altitude)
+	if target == 0 {
+		target = clearedAltitude(ac)
+	}
+	perf := ac.AircraftPerformance()
+	climb := util.Select(perf.Rate.Climb > 0, perf.Rate.Climb, 1500)
+	descent := util.Select(perf.Rate.Descent > 0, perf.Rate.Descent, 2000)
+
+	pos, alt, hdg := ac.Position(), ac.Altitude(), ac.Heading()
+	gs := max(ac.GS(), 100)
+	nmPerLongitude, magneticVariation := ac.NmPerLongitude(), ac.MagneticVariation()
+
+	traj := []probePoint{{Location: pos, Altitude: alt}}
+	for t := probeStep; t <= probeLookahead; t += probeStep {
+		d := gs * float32(probeStep.Hours())
+		for d > 0 && len(route) > 0 {
+			hdg = math.Heading2LL(pos, route[0], nmPerLongitude, magneticVariation)
+			if leg := math.NMDistance2LL(pos, route[0]); leg <= d {
+				d -= leg
+				pos, route = route[0], route[1:]
+			} else {
+				pos = math.Offset2LL(pos, hdg, d, nmPerLongitude, magneticVariation)
+				d = 0
+			}
+		}
+		if d > 0 {
+			// Past the end of the route (or vectored); continue straight.
+			pos = math.Offset2LL(pos, hdg, d, nmPerLongitude, magneticVariation)
+		}
+
+		if target != 0 {
+			if alt < target {
+				alt = min(target, alt+climb*float32(probeStep.Minutes()))
+			} else {
+				alt = max(target, alt-descent*float32(probeStep.Minutes()))
+			}
+		}
+
+		traj = append(traj, probePoint{Location: pos, Altitude: alt})
+	}
+	return traj
+}
+
+// probeConflict checks a pair of trajectories for a predicted loss of
+// separation. The trajectories are interpolated between successive
+// points so that conflicts between fast aircraft on crossing courses
+// aren't missed; lateral and vertical separation are always evaluated at
+// the same times.
+func (s *Sim) probeConflict(a, b []probePoint, acids [2]ACID) (ProbeConflict, bool) {
+	nmPerLongitude := s.State.NmPerLongitude
+	n := min(len(a), len(b))
+	// sample returns the lateral and vertical separation a fraction u of
+	// the way between the i and i+1th trajectory points.
+	sample := func(i int, u float32) (float32, float32, float32) {
+		point := func(tr []probePoint) ([2]float32, float32) {
+			p0 := math.LL2NM(tr[i].Location, nmPerLongitude)
+			if u == 0 {
+				return p0, tr[i].Altitude
+			}
+			p1 := math.LL2NM(tr[i+1].Location, nmPerLongitude)
+			return math.Lerp2f(u, p0, p1), math.Lerp(u, tr[i].Altitude, tr[i+1].Altitude)
+		}
+		pa, alta := point(a)
+		pb, altb := point(b)
+		return math.Distance2f(pa, pb), math.Abs(alta - altb), min(alta, altb)
+	}
+
+	var pc ProbeConflict
+	found := false
+	if n == 0 {
+		return pc, found
+	}
+	for k := 0; k <= (n-1)*probeSubsteps; k++ {
+		i, u := k/probeSubsteps, float32(k%probeSubsteps)/probeSubsteps
+		lateral, vertical, alt := sample(i, u)
+
+		vmin := float32(probeVerticalMinimum)
+		if alt > probeRVSMCeiling {
+			vmin = probeVerticalMinimumHigh
+		}
+
+		if lateral < probeLateralMinimum && vertical < vmin {
+			if !found {
+				found = true
+				pc = ProbeConflict{
+					ACIDs:    acids,
+					Start:    s.State.SimTime.Add(time.Duration(k) * probeStep / probeSubsteps),
+					Lateral:  lateral,
+					Vertical: vertical,
+				}
+			} else {
+				pc.Lateral = min(pc.Lateral, lateral)
+				pc.Vertical = min(pc.Vertical, vertical)
+			}
+		} else if found {
+			break
+		}
+	}
+	return pc, found
+}
+
+// updateConflictProbe periodically reprobes all eligible flights and
+// updates the current set of predicted conflicts.
+func (s *Sim) updateConflictProbe() {
+	if s.State.SimTime.Before(s.nextConflictProbe) {
+		return
+	}
+	s.nextConflictProbe = s.State.SimTime.Add(probeInterval)
+
+	var aircraft []*Aircraft
+	var trajectories [][]probePoint
+	for _, ac := range util.SortedMap(s.Aircraft) {
+		if s.probeEligible(ac) {
+			aircraft = append(aircraft, ac)
+			trajectories = append(trajectories, s.probeTrajectory(ac, 0, ""))
+		}
+	}
+
+	var conflicts []ProbeConflict
+	for i, a := range aircraft {
+		for j := i + 1; j < len(aircraft); j++ {
+			b := aircraft[j]
+			if !s.probeCenterTracked(a) && !s.probeCenterTracked(b) {
+				continue
+			}
+			acids := [2]ACID{a.NASFlightPlan.ACID, b.NASFlightPlan.ACID}
+			if pc, ok := s.probeConflict(trajectories[i], trajectories[j], acids); ok {
+				conflicts = append(conflicts, pc)
+			}
+		}
+	}
+	s.State.ProbeConflicts = conflicts
+}
+
+// TrialPlan probes the given flight as if it had been assigned the given
+// altitude (if non-zero) or cleared direct to the given fix (if
+// non-empty) and returns the conflicts that would result.
+func (s *Sim) TrialPlan(tcw TCW, acid ACID, altitude int, fix string) ([]ProbeConflict, error) {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	_, ac, _ := s.getFlightPlanForACID(acid)
+	if ac == nil {
+		return nil, ErrNoMatchingFlightPlan
+	}
+	if fix != "" {
+		if _, ok := av.DB.LookupWaypoint(fix); !ok && !slices.ContainsFunc(ac.Nav.Waypoints,
+			func(wp av.Waypoint) bool { return wp.Fix == fix }) {
+			return nil, av.ErrNoMatchingFix
+		}
+	}
+
+	trial := s.probeTrajectory(ac, altitude, fix)
+
+	var conflicts []ProbeConflict
+	for _, other := range util.SortedMap(s.Aircraft) {
+		if other == ac || !s.probeEligible(other) {
+			continue
+		}
+		acids := [2]ACID{acid, other.NASFlightPlan.ACID}
+		if pc, ok := s.probeConflict(trial, s.probeTrajectory(other, 0, ""), acids); ok {
+			conflicts = append(conflicts, pc)
+		}
+	}
+	return conflicts, nil
+}
@@ -0,0 +1,91 @@
+// sim/probe_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"testing"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+)
+
+func TestConflictProbe(t *testing.T) {
+	makeAircraft := func(callsign av.ADSBCallsign, east float32, heading float32, alt float32) *Aircraft {
+		ac := makeTestAircraft(callsign, testPoint(east, 0), alt, heading, 400)
+		ac.NASFlightPlan = &NASFlightPlan{ACID: ACID(callsign), Rules: av.FlightRulesIFR, TrackingController: "10"}
+		ac.Nav.Altitude.Assigned = &alt
+		ac.Nav.Heading.Assigned = &heading
+		return ac
+	}
+
+	// Head-on, 60nm apart at 400kts each: they'll meet in 4.5 minutes.
+	a := makeAircraft("AAL1", -30, 90, 35000)
+	b := makeAircraft("JBU2", 30, 270, 35000)
+	s := makeTestSim(t, a, b)
+	s.State.Controllers = map[ControlPosition]*av.Controller{"10": {Position: "10", ERAMFacility: true}}
+
+	s.updateConflictProbe()
+	if len(s.State.ProbeConflicts) != 1 {
+		t.Fatalf("expected a predicted conflict, got %d", len(s.State.ProbeConflicts))
+	}
+	pc := s.State.ProbeConflicts[0]
+	if pc.ACIDs != [2]ACID{"AAL1", "JBU2"} {
+		t.Errorf("unexpected aircraft in conflict: %+v", pc.ACIDs)
+	}
+	if d := pc.Start.Sub(s.State.SimTime); d < 3*time.Minute || d > 5*time.Minute {
+		t.Errorf("expected conflict in about 4 minutes; got %s", d)
+	}
+	if pc.Lateral > 1 || pc.Vertical != 0 {
+		t.Errorf("expected the aircraft to be predicted to pass overhead; got %+v", pc)
+	}
+
+	// A trial plan for a different altitude should be conflict-free.
+	if c, err := s.TrialPlan("", "AAL1", 37000, ""); err != nil {
+		t.Errorf("trial plan: %v", err)
+	} else if len(c) != 0 {
+		t.Errorf("expected no conflicts at FL370, got %+v", c)
+	}
+	if c, err := s.TrialPlan("", "AAL1", 35000, ""); err != nil {
+		t.Errorf("trial plan: %v", err)
+	} else if len(c) != 1 {
+		t.Errorf("expected a conflict at FL350, got %+v", c)
+	}
+
+	// And the actual conflict goes away once it has been issued.
+	*a.Nav.Altitude.Assigned = 37000
+	a.Nav.FlightState.Altitude = 37000
+	s.State.SimTime = s.State.SimTime.Add(probeInterval)
+	s.updateConflictProbe()
+	if len(s.State.ProbeConflicts) != 0 {
+		t.Errorf("expected no conflicts after the altitude change, got %+v", s.State.ProbeConflicts)
+	}
+}
+
+func TestProbeConflictSampling(t *testing.T) {
+	s := makeTestSim(t)
+
+	pt := func(east float32, alt float32) probePoint {
+		return probePoint{Location: testPoint(east, 0), Altitude: alt}
+	}
+	acids := [2]ACID{"AAL1", "JBU2"}
+
+	// The two pass each other early in the step, while still more than
+	// 1,000' apart, and only come within 1,000' vertically once they're
+	// well past each other, so separation is never lost.
+	a := []probePoint{pt(-2, 35000), pt(8, 35000)}
+	b := []probePoint{pt(2, 37000), pt(-8, 35600)}
+	if pc, ok := s.probeConflict(a, b, acids); ok {
+		t.Errorf("unexpected conflict %+v", pc)
+	}
+
+	// If the second one is level at 35,600', they are in conflict when
+	// they pass.
+	b = []probePoint{pt(2, 35600), pt(-8, 35600)}
+	if pc, ok := s.probeConflict(a, b, acids); !ok {
+		t.Errorf("expected a conflict")
+	} else if pc.Lateral > 1 || pc.Vertical != 600 {
+		t.Errorf("expected the aircraft to pass 600' apart, got %+v", pc)
+	}
+}
@@ -0,0 +1,240 @@
+// sim/pseudopilot.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/util"
+)
+
+// PseudoPilot describes a human who has signed on to fly aircraft rather
+// than to control them. The controller's commands for those aircraft are
+// passed along to the pseudo-pilot as PseudoPilotCommandEvents rather
+// than being carried out; the pseudo-pilot then issues commands with the
+// same syntax from the pilot's side, and the aircraft just does what
+// it's told. The sim doesn't make any transmissions on their aircraft's
+// behalf--no readbacks, check-ins, or requests--so it's up to the
+// pseudo-pilot to compose those.
+type PseudoPilot struct {
+	Initials string
+	// Aircraft tuned to these positions' frequencies are flown by the
+	// pseudo-pilot. If empty, all aircraft on a controller's frequency
+	// are.
+	Positions []ControlPosition
+}
+
+// Flies returns true if the pseudo-pilot flies aircraft on the given
+// frequency.
+func (pp *PseudoPilot) Flies(freq ControlPosition) bool {
+	return freq != "" && (len(pp.Positions) == 0 || slices.Contains(pp.Positions, freq))
+}
+
+// SignOnPseudoPilot signs on a new pseudo-pilot who will fly the aircraft
+// on the given positions' frequencies. It returns the TCW that identifies
+// the pseudo-pilot.
+func (s *Sim) SignOnPseudoPilot(initials string, positions []ControlPosition) (TCW, *EventsSubscription, error) {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	for _, pos := range positions {
+		if _, ok := s.State.Controllers[pos]; !ok {
+			return "", nil, ErrUnknownController
+		}
+	}
+
+	if s.State.PseudoPilots == nil {
+		s.State.PseudoPilots = make(map[TCW]*PseudoPilot)
+	}
+
+	// Pseudo-pilot workstations are numbered PP1, PP2, ...
+	var tcw TCW
+	for i := 1; ; i++ {
+		tcw = TCW(fmt.Sprintf("PP%d", i))
+		_, isPP := s.State.PseudoPilots[tcw]
+		_, isController := s.State.CurrentConsolidation[tcw]
+		if !isPP && !isController {
+			break
+		}
+	}
+
+	s.State.PseudoPilots[tcw] = &PseudoPilot{
+		Initials:  initials,
+		Positions: slices.Clone(positions),
+	}
+
+	return tcw, s.eventStream.Subscribe(), nil
+}
+
+func (s *Sim) SignOffPseudoPilot(tcw TCW) {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	delete(s.State.PseudoPilots, tcw)
+}
+
+// IsPseudoPilot returns true if the given TCW is a pseudo-pilot's.
+func (s *Sim) IsPseudoPilot(tcw TCW) bool {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	_, ok := s.State.PseudoPilots[tcw]
+	return ok
+}
+
+// pseudoPilotAircraft returns the aircraft with the given callsign if the
+// pseudo-pilot at the TCW flies it, along with the TCW of the controller
+// whose frequency it is on.
+func (s *Sim) pseudoPilotAircraft(tcw TCW, callsign av.ADSBCallsign) (*Aircraft, TCW, error) {
+	pp, ok := s.State.PseudoPilots[tcw]
+	if !ok {
+		return nil, "", ErrNotPseudoPilot
+	}
+
+	ac, ok := s.Aircraft[callsign]
+	if !ok {
+		return nil, "", av.ErrNoAircraftForCallsign
+	}
+	if !pp.Flies(ac.ControllerFrequency) {
+		return nil, "", ErrNotPseudoPilotAircraft
+	}
+
+	ctrlTCW := s.State.TCWForPosition(ac.ControllerFrequency)
+	if _, ok := s.State.CurrentConsolidation[ctrlTCW]; !ok {
+		return nil, "", ErrUnknownController
+	}
+	return ac, ctrlTCW, nil
+}
+
+// pseudoPilotFlying returns the TCW of the pseudo-pilot who flies the
+// aircraft on the given frequency, if there is one. Virtual controllers'
+// aircraft are always flown by the sim.
+func (s *Sim) pseudoPilotFlying(freq ControlPosition) (TCW, bool) {
+	if freq == "" || s.isVirtualController(freq) {
+		return "", false
+	}
+	for _, tcw := range util.SortedMapKeys(s.State.PseudoPilots) {
+		if s.State.PseudoPilots[tcw].Flies(freq) {
+			return tcw, true
+		}
+	}
+	return "", false
+}
+
+// flownByPseudoPilot returns true if the aircraft is flown by a
+// pseudo-pilot, in which case the sim should neither change its nav
+// state nor transmit for it.
+func (s *Sim) flownByPseudoPilot(ac *Aircraft) bool {
+	_, ok := s.pseudoPilotFlying(ac.ControllerFrequency)
+	return ok
+}
+
+// routeToPseudoPilot passes the controller's commands for an aircraft
+// along to the pseudo-pilot who flies it. It returns false if the
+// aircraft isn't flown by a pseudo-pilot, in which case the sim should
+// run the commands itself.
+func (s *Sim) routeToPseudoPilot(tcw TCW, callsign av.ADSBCallsign, commands []string) (bool, error) {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	ac, ok := s.Aircraft[callsign]
+	if !ok {
+		return false, nil
+	}
+	ppTCW, ok := s.pseudoPilotFlying(ac.ControllerFrequency)
+	if !ok {
+		return false, nil
+	}
+	if !s.TCWCanCommandAircraft(tcw, ac) {
+		return true, av.ErrOtherControllerHasTrack
+	}
+
+	ac.LastRadioTransmission = s.State.SimTime
+	s.eventStream.Post(Event{
+		Type:           PseudoPilotCommandEvent,
+		ADSBCallsign:   callsign,
+		FromController: s.State.PrimaryPositionForTCW(tcw),
+		DestinationTCW: ppTCW,
+		WrittenText:    strings.Join(commands, " "),
+	})
+	s.lg.Infof("%s: routed \"%s\" from %s to pseudo-pilot %s", callsign, strings.Join(commands, " "), tcw, ppTCW)
+
+	return true, nil
+}
+
+// RunPseudoPilotCommands runs commands for an aircraft flown by the
+// pseudo-pilot at the given TCW. The commands use the same syntax as
+// RunAircraftControlCommands and are carried out as if they had been
+// issued by the controller whose frequency the aircraft is on, though
+// there is no readback. This is the only way that the nav state of a
+// pseudo-pilot's aircraft is changed.
+func (s *Sim) RunPseudoPilotCommands(tcw TCW, callsign av.ADSBCallsign, commandStr string) ControlCommandsResult {
+	s.mu.Lock(s.lg)
+	_, ctrlTCW, err := s.pseudoPilotAircraft(tcw, callsign)
+	s.mu.Unlock(s.lg)
+
+	if err != nil {
+		return ControlCommandsResult{RemainingInput: commandStr, Error: err}
+	}
+
+	commands := strings.Fields(commandStr)
+	for i, command := range commands {
+		// Any intents are discarded; it's up to the pseudo-pilot to say
+		// something if they want to.
+		if _, err := s.runOneControlCommand(ctrlTCW, callsign, command); err != nil {
+			return ControlCommandsResult{
+				RemainingInput: strings.Join(commands[i:], " "),
+				Error:          err,
+			}
+		}
+	}
+
+	s.lg.Infof("%s: pseudo-pilot %s ran \"%s\"", callsign, tcw, commandStr)
+
+	return ControlCommandsResult{}
+}
+
+// PseudoPilotTransmit sends a radio transmission composed by a
+// pseudo-pilot from one of their aircraft to the controller whose
+// frequency it is on. The text is posted as given; as with other pilot
+// transmissions, prepareRadioTransmissions adds the callsign when the
+// event is delivered to the controller, in the form that the
+// transmission type calls for (e.g., the controller's name and the
+// callsign before it for av.RadioTransmissionContact). Readback is used
+// if the type is unspecified.
+func (s *Sim) PseudoPilotTransmit(tcw TCW, callsign av.ADSBCallsign, text string, ty av.RadioTransmissionType) error {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	ac, ctrlTCW, err := s.pseudoPilotAircraft(tcw, callsign)
+	if err != nil {
+		return err
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return ErrInvalidCommandSyntax
+	}
+	if ty == av.RadioTransmissionUnknown {
+		ty = av.RadioTransmissionReadback
+	}
+
+	ac.LastRadioTransmission = s.State.SimTime
+	s.eventStream.Post(Event{
+		Type:                  RadioTransmissionEvent,
+		ADSBCallsign:          callsign,
+		ToController:          ac.ControllerFrequency,
+		DestinationTCW:        ctrlTCW,
+		WrittenText:           text,
+		SpokenText:            text,
+		RadioTransmissionType: ty,
+		Readback:              ty == av.RadioTransmissionReadback,
+	})
+
+	return nil
+}
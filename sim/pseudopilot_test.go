@@ -0,0 +1,139 @@
+// sim/pseudopilot_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"testing"
+
+	av "github.com/mmp/vice/aviation"
+)
+
+func TestPseudoPilot(t *testing.T) {
+	s := &Sim{
+		Aircraft: map[av.ADSBCallsign]*Aircraft{
+			"AAL1": {ADSBCallsign: "AAL1", ControllerFrequency: "1A"},
+			"JBU2": {ADSBCallsign: "JBU2", ControllerFrequency: "2B"},
+		},
+		eventStream: NewEventStream(nil),
+		State: &CommonState{
+			DynamicState: DynamicState{
+				CurrentConsolidation: map[TCW]*TCPConsolidation{
+					"1A": {PrimaryTCP: "1A"},
+					"2B": {PrimaryTCP: "2B"},
+				},
+			},
+			Controllers: map[ControlPosition]*av.Controller{"1A": {}, "2B": {}},
+		},
+	}
+	defer s.eventStream.Destroy()
+
+	if _, _, err := s.SignOnPseudoPilot("XX", []ControlPosition{"3C"}); err != ErrUnknownController {
+		t.Errorf("expected ErrUnknownController, got %v", err)
+	}
+
+	tcw, sub, err := s.SignOnPseudoPilot("XX", []ControlPosition{"1A"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+	if tcw != "PP1" {
+		t.Errorf("expected TCW PP1, got %s", tcw)
+	}
+	if tcw2, sub2, _ := s.SignOnPseudoPilot("YY", nil); tcw2 != "PP2" {
+		t.Errorf("expected TCW PP2, got %s", tcw2)
+	} else {
+		sub2.Unsubscribe()
+	}
+
+	if err := s.PseudoPilotTransmit(tcw, "JBU2", "request higher", av.RadioTransmissionContact); err != ErrNotPseudoPilotAircraft {
+		t.Errorf("expected ErrNotPseudoPilotAircraft, got %v", err)
+	}
+	if err := s.PseudoPilotTransmit("1A", "AAL1", "request higher", av.RadioTransmissionContact); err != ErrNotPseudoPilot {
+		t.Errorf("expected ErrNotPseudoPilot, got %v", err)
+	}
+
+	if err := s.PseudoPilotTransmit(tcw, "AAL1", "request higher", av.RadioTransmissionContact); err != nil {
+		t.Fatal(err)
+	}
+	events := sub.Get()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if e := events[0]; e.Type != RadioTransmissionEvent || e.ADSBCallsign != "AAL1" || e.DestinationTCW != "1A" ||
+		e.WrittenText != "request higher" {
+		t.Errorf("unexpected event: %+v", e)
+	}
+
+	s.SignOffPseudoPilot(tcw)
+	if s.IsPseudoPilot(tcw) {
+		t.Errorf("%s still signed on after sign-off", tcw)
+	}
+}
+
+func TestPseudoPilotCommandRouting(t *testing.T) {
+	ac := makeTestAircraft("AAL1", testPoint(0, 0), 8000, 90, 250)
+	ac.Nav.Perf = makeTestPerformance()
+	ac.ControllerFrequency = "1A"
+	s := makeTestSim(t, ac)
+	s.State.CurrentConsolidation = map[TCW]*TCPConsolidation{"1A": {PrimaryTCP: "1A"}}
+	s.State.Controllers = map[ControlPosition]*av.Controller{"1A": {}}
+
+	tcw, sub, err := s.SignOnPseudoPilot("XX", []ControlPosition{"1A"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	// The controller's commands go to the pseudo-pilot and aren't run.
+	if result := s.RunAircraftControlCommands("1A", "AAL1", "C120"); result.Error != nil {
+		t.Fatal(result.Error)
+	}
+	if ac.Nav.Altitude.Assigned != nil {
+		t.Errorf("controller's command changed the assigned altitude to %.0f", *ac.Nav.Altitude.Assigned)
+	}
+	events := sub.Get()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d: %+v", len(events), events)
+	}
+	if e := events[0]; e.Type != PseudoPilotCommandEvent || e.ADSBCallsign != "AAL1" || e.DestinationTCW != tcw ||
+		e.FromController != "1A" || e.WrittenText != "C120" {
+		t.Errorf("unexpected event: %+v", e)
+	}
+
+	if result := s.RunAircraftControlCommands("2B", "AAL1", "C120"); result.Error != av.ErrOtherControllerHasTrack {
+		t.Errorf("expected ErrOtherControllerHasTrack, got %v", result.Error)
+	}
+
+	// The pseudo-pilot's commands are run, without a readback.
+	if result := s.RunPseudoPilotCommands(tcw, "AAL1", "C120"); result.Error != nil {
+		t.Fatal(result.Error)
+	}
+	if ac.Nav.Altitude.Assigned == nil || *ac.Nav.Altitude.Assigned != 12000 {
+		t.Errorf("expected assigned altitude 12000, got %v", ac.Nav.Altitude.Assigned)
+	}
+	if events := sub.Get(); len(events) != 0 {
+		t.Errorf("unexpected events: %+v", events)
+	}
+
+	// Nor does the sim queue check-ins or requests for the aircraft.
+	s.enqueuePilotTransmission("AAL1", "1A", PendingTransmissionPilotRequest)
+	if len(s.PendingContacts["1A"]) != 0 {
+		t.Errorf("unexpected pending contacts: %+v", s.PendingContacts["1A"])
+	}
+	if s.pilotRequestEligible(ac) {
+		t.Errorf("pseudo-pilot's aircraft shouldn't make requests")
+	}
+
+	// Once the pseudo-pilot signs off, the sim flies it again.
+	s.SignOffPseudoPilot(tcw)
+	s.enqueuePilotTransmission("AAL1", "1A", PendingTransmissionPilotRequest)
+	if len(s.PendingContacts["1A"]) != 1 {
+		t.Errorf("expected 1 pending contact, got %+v", s.PendingContacts["1A"])
+	}
+	delete(s.PendingContacts, "1A")
+	if !s.pilotRequestEligible(ac) {
+		t.Errorf("aircraft should be able to make requests after the pseudo-pilot signs off")
+	}
+}
@@ -0,0 +1,290 @@
+// sim/report.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"slices"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/util"
+)
+
+// SessionReport summarizes the traffic that was handled over the course
+// of a sim run. It is built up from EventStream events by a
+// SessionReporter and is meant to be written out as JSON so that changes
+// to scenarios can be regression-tested in batch.
+type SessionReport struct {
+	Facility  string    `json:"facility"`
+	Scenario  string    `json:"scenario,omitempty"`
+	Seed      uint64    `json:"seed,omitempty"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+
+	Spawned          int `json:"spawned"`
+	Departed         int `json:"departed"`
+	Landed           int `json:"landed"`
+	Deleted          int `json:"deleted"`
+	HandoffsOffered  int `json:"handoffs_offered"`
+	HandoffsAccepted int `json:"handoffs_accepted"`
+
+	Aircraft         []*AircraftSessionReport     `json:"aircraft"`
+	Sectors          map[TCP]*SectorSessionReport `json:"sectors"`
+	SeparationLosses []SeparationLoss             `json:"separation_losses"`
+}
+
+// AircraftSessionReport records the lifecycle of a single aircraft. Times
+// that are unset correspond to things that didn't happen during the run.
+type AircraftSessionReport struct {
+	ADSBCallsign     av.ADSBCallsign `json:"callsign"`
+	ACID             ACID            `json:"acid,omitempty"`
+	AircraftType     string          `json:"aircraft_type,omitempty"`
+	Rules            string          `json:"rules,omitempty"`
+	TypeOfFlight     string          `json:"type_of_flight,omitempty"`
+	DepartureAirport string          `json:"departure_airport,omitempty"`
+	ArrivalAirport   string          `json:"arrival_airport,omitempty"`
+
+	// Prespawned is set for aircraft that were already present when the
+	// reporter was created.
+	Prespawned bool      `json:"prespawned,omitempty"`
+	Spawned    time.Time `json:"spawned"`
+	Departed   time.Time `json:"departed,omitzero"`
+	Landed     time.Time `json:"landed,omitzero"`
+	Deleted    time.Time `json:"deleted,omitzero"`
+
+	// Controllers lists the positions that tracked the aircraft, in order.
+	Controllers []TCP                   `json:"controllers,omitempty"`
+	Handoffs    []*HandoffSessionReport `json:"handoffs,omitempty"`
+}
+
+type HandoffSessionReport struct {
+	From     TCP       `json:"from"`
+	To       TCP       `json:"to"`
+	Offered  time.Time `json:"offered,omitzero"`
+	Accepted time.Time `json:"accepted,omitzero"`
+}
+
+// SectorSessionReport gives traffic counts for a single control position.
+type SectorSessionReport struct {
+	// Aircraft is the number of distinct aircraft the position tracked.
+	Aircraft         int `json:"aircraft"`
+	HandoffsOffered  int `json:"handoffs_offered"`
+	HandoffsReceived int `json:"handoffs_received"`
+	HandoffsAccepted int `json:"handoffs_accepted"`
+}
+
+// SessionReporter consumes events from a Sim's EventStream and accumulates
+// a SessionReport. Update should be called after each call to Sim.Step so
+// that events are timestamped with the sim time at which they occurred.
+type SessionReporter struct {
+	sim      *Sim
+	events   *EventsSubscription
+	report   SessionReport
+	aircraft map[av.ADSBCallsign]*AircraftSessionReport
+	acids    map[ACID]av.ADSBCallsign
+}
+
+// NewSessionReporter returns a SessionReporter for the given sim; aircraft
+// that already exist (e.g., from Prespawn) are included in the report as
+// having spawned at the current sim time.
+func NewSessionReporter(s *Sim, scenario string, seed uint64) *SessionReporter {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	r := &SessionReporter{
+		sim:    s,
+		events: s.eventStream.Subscribe(),
+		report: SessionReport{
+			Facility:  s.State.Facility,
+			Scenario:  scenario,
+			Seed:      seed,
+			StartTime: s.State.SimTime,
+			EndTime:   s.State.SimTime,
+			Sectors:   make(map[TCP]*SectorSessionReport),
+		},
+		aircraft: make(map[av.ADSBCallsign]*AircraftSessionReport),
+		acids:    make(map[ACID]av.ADSBCallsign),
+	}
+
+	for callsign := range s.Aircraft {
+		acr := r.lookupAircraft(callsign)
+		acr.Prespawned = true
+		acr.Spawned = s.State.SimTime
+		r.report.Spawned++
+	}
+	r.updateAircraft()
+
+	return r
+}
+
+// Update processes all of the events that have been posted since the last
+// call to Update.
+func (r *SessionReporter) Update() {
+	r.sim.mu.Lock(r.sim.lg)
+	defer r.sim.mu.Unlock(r.sim.lg)
+
+	// Sync up with the current aircraft first so that we know the ACIDs
+	// of aircraft named in handoff events.
+	r.updateAircraft()
+
+	now := r.sim.State.SimTime
+	for _, e := range r.events.Get() {
+		r.processEvent(e, now)
+	}
+	r.report.EndTime = now
+}
+
+// Report returns the report for the run up to the last call to Update.
+// Losses of separation that are still ongoing are included, ending at the
+// report's end time. The SessionReporter should not be used after Report
+// is called.
+func (r *SessionReporter) Report() SessionReport {
+	r.events.Unsubscribe()
+
+	r.sim.mu.Lock(r.sim.lg)
+	for _, l := range r.sim.SeparationLosses {
+		lc := *l
+		lc.End = r.report.EndTime
+		r.report.SeparationLosses = append(r.report.SeparationLosses, lc)
+	}
+	r.sim.mu.Unlock(r.sim.lg)
+
+	r.report.Aircraft = nil
+	for _, acr := range util.SortedMap(r.aircraft) {
+		r.report.Aircraft = append(r.report.Aircraft, acr)
+	}
+	slices.SortStableFunc(r.report.Aircraft, func(a, b *AircraftSessionReport) int {
+		return a.Spawned.Compare(b.Spawned)
+	})
+
+	return r.report
+}
+
+func (r *SessionReporter) lookupAircraft(callsign av.ADSBCallsign) *AircraftSessionReport {
+	acr, ok := r.aircraft[callsign]
+	if !ok {
+		acr = &AircraftSessionReport{ADSBCallsign: callsign}
+		r.aircraft[callsign] = acr
+	}
+	return acr
+}
+
+func (r *SessionReporter) lookupSector(tcp TCP) *SectorSessionReport {
+	sr, ok := r.report.Sectors[tcp]
+	if !ok {
+		sr = &SectorSessionReport{}
+		r.report.Sectors[tcp] = sr
+	}
+	return sr
+}
+
+// updateAircraft records flight plan details and tracking controllers for
+// the aircraft currently in the sim. Assumes the sim's lock is held.
+func (r *SessionReporter) updateAircraft() {
+	for callsign, ac := range r.sim.Aircraft {
+		acr := r.lookupAircraft(callsign)
+		fp := ac.FlightPlan
+		acr.AircraftType = fp.AircraftType
+		acr.Rules = fp.Rules.String()
+		acr.DepartureAirport = fp.DepartureAirport
+		acr.ArrivalAirport = fp.ArrivalAirport
+		switch ac.TypeOfFlight {
+		case av.FlightTypeDeparture:
+			acr.TypeOfFlight = "departure"
+		case av.FlightTypeArrival:
+			acr.TypeOfFlight = "arrival"
+		case av.FlightTypeOverflight:
+			acr.TypeOfFlight = "overflight"
+		}
+
+		if ac.IsAssociated() {
+			acr.ACID = ac.NASFlightPlan.ACID
+			r.acids[acr.ACID] = callsign
+			r.noteTracking(acr, ac.NASFlightPlan.TrackingController)
+		}
+	}
+}
+
+func (r *SessionReporter) noteTracking(acr *AircraftSessionReport, tcp TCP) {
+	if tcp == "" || slices.Contains(acr.Controllers, tcp) {
+		return
+	}
+	acr.Controllers = append(acr.Controllers, tcp)
+	r.lookupSector(tcp).Aircraft++
+}
+
+func (r *SessionReporter) aircraftForEvent(e Event) (*AircraftSessionReport, bool) {
+	if e.ADSBCallsign != "" {
+		return r.lookupAircraft(e.ADSBCallsign), true
+	}
+	if callsign, ok := r.acids[e.ACID]; ok {
+		return r.lookupAircraft(callsign), true
+	}
+	// Handoffs of tracks that aren't associated with an aircraft (or that
+	// we otherwise haven't seen) are still reflected in the sector counts.
+	return nil, false
+}
+
+func (r *SessionReporter) processEvent(e Event, now time.Time) {
+	switch e.Type {
+	case AircraftSpawnedEvent:
+		r.report.Spawned++
+		acr := r.lookupAircraft(e.ADSBCallsign)
+		acr.Spawned = now
+		if e.ACID != "" {
+			acr.ACID = e.ACID
+			r.acids[e.ACID] = e.ADSBCallsign
+		}
+		r.noteTracking(acr, e.ToController)
+
+	case AircraftDepartedEvent:
+		r.report.Departed++
+		r.lookupAircraft(e.ADSBCallsign).Departed = now
+
+	case AircraftLandedEvent:
+		r.report.Landed++
+		r.lookupAircraft(e.ADSBCallsign).Landed = now
+
+	case AircraftDeletedEvent:
+		r.report.Deleted++
+		r.lookupAircraft(e.ADSBCallsign).Deleted = now
+
+	case SeparationRegainedEvent:
+		if e.SeparationLoss != nil {
+			r.report.SeparationLosses = append(r.report.SeparationLosses, *e.SeparationLoss)
+		}
+
+	case OfferedHandoffEvent:
+		r.report.HandoffsOffered++
+		r.lookupSector(e.FromController).HandoffsOffered++
+		r.lookupSector(e.ToController).HandoffsReceived++
+		if acr, ok := r.aircraftForEvent(e); ok {
+			acr.Handoffs = append(acr.Handoffs, &HandoffSessionReport{
+				From:    e.FromController,
+				To:      e.ToController,
+				Offered: now,
+			})
+		}
+
+	case AcceptedHandoffEvent, AcceptedRedirectedHandoffEvent:
+		r.report.HandoffsAccepted++
+		r.lookupSector(e.ToController).HandoffsAccepted++
+		if acr, ok := r.aircraftForEvent(e); ok {
+			// Match it up with the outstanding offer, if there is one.
+			idx := slices.IndexFunc(acr.Handoffs, func(h *HandoffSessionReport) bool {
+				return h.Accepted.IsZero() && h.To == e.ToController
+			})
+			if idx == -1 {
+				acr.Handoffs = append(acr.Handoffs, &HandoffSessionReport{
+					From: e.FromController,
+					To:   e.ToController,
+				})
+				idx = len(acr.Handoffs) - 1
+			}
+			acr.Handoffs[idx].Accepted = now
+			r.noteTracking(acr, e.ToController)
+		}
+	}
+}
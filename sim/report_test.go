@@ -0,0 +1,177 @@
+// sim/report_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/log"
+	"github.com/mmp/vice/math"
+	"github.com/mmp/vice/wx"
+)
+
+func TestSessionReporter(t *testing.T) {
+	s := makeTestSim(t, &Aircraft{ADSBCallsign: "AAL1"})
+	start := s.State.SimTime
+
+	r := NewSessionReporter(s, "test", 1)
+
+	step := func(events ...Event) {
+		s.State.SimTime = s.State.SimTime.Add(time.Second)
+		for _, e := range events {
+			s.eventStream.Post(e)
+		}
+		r.Update()
+	}
+
+	step(Event{Type: AircraftSpawnedEvent, ADSBCallsign: "JBU2", ACID: "JBU2", ToController: "1A"})
+	step(Event{Type: AircraftDepartedEvent, ADSBCallsign: "JBU2"})
+	step(Event{Type: OfferedHandoffEvent, ACID: "JBU2", FromController: "1A", ToController: "2B"})
+	step(Event{Type: AcceptedHandoffEvent, ACID: "JBU2", FromController: "1A", ToController: "2B"})
+	step(Event{Type: AircraftLandedEvent, ADSBCallsign: "AAL1"},
+		Event{Type: AircraftDeletedEvent, ADSBCallsign: "AAL1"})
+
+	report := r.Report()
+
+	if report.Spawned != 2 || report.Departed != 1 || report.Landed != 1 || report.Deleted != 1 {
+		t.Errorf("unexpected totals: %+v", report)
+	}
+	if report.HandoffsOffered != 1 || report.HandoffsAccepted != 1 {
+		t.Errorf("unexpected handoff totals: %+v", report)
+	}
+	if !report.EndTime.Equal(start.Add(5 * time.Second)) {
+		t.Errorf("expected end time %s, got %s", start.Add(5*time.Second), report.EndTime)
+	}
+
+	if len(report.Aircraft) != 2 {
+		t.Fatalf("expected 2 aircraft, got %d", len(report.Aircraft))
+	}
+	aal, jbu := report.Aircraft[0], report.Aircraft[1]
+	if aal.ADSBCallsign != "AAL1" || !aal.Prespawned || !aal.Landed.Equal(start.Add(5*time.Second)) {
+		t.Errorf("unexpected AAL1 report: %+v", aal)
+	}
+	if jbu.ADSBCallsign != "JBU2" || !jbu.Spawned.Equal(start.Add(time.Second)) ||
+		!jbu.Departed.Equal(start.Add(2*time.Second)) {
+		t.Errorf("unexpected JBU2 report: %+v", jbu)
+	}
+	if len(jbu.Handoffs) != 1 || !jbu.Handoffs[0].Offered.Equal(start.Add(3*time.Second)) ||
+		!jbu.Handoffs[0].Accepted.Equal(start.Add(4*time.Second)) {
+		t.Errorf("unexpected JBU2 handoffs: %+v", jbu.Handoffs)
+	}
+
+	if sr := report.Sectors["1A"]; sr == nil || sr.Aircraft != 1 || sr.HandoffsOffered != 1 {
+		t.Errorf("unexpected sector 1A report: %+v", sr)
+	}
+	if sr := report.Sectors["2B"]; sr == nil || sr.Aircraft != 1 || sr.HandoffsAccepted != 1 {
+		t.Errorf("unexpected sector 2B report: %+v", sr)
+	}
+}
+
+// runSeededOverflights runs a sim that only launches overflights for the
+// given amount of time and returns the session report along with the
+// aircraft at the end of the run.
+func runSeededOverflights(t *testing.T, seed uint64, d time.Duration) (SessionReport, map[av.ADSBCallsign]*Aircraft) {
+	t.Helper()
+
+	wp := func(fix string, p math.Point2LL) av.Waypoint {
+		return av.Waypoint{Fix: fix, Location: p}
+	}
+	entry := wp("ENTRY", testPoint(-45, 0))
+	entry.InitExtra().Radius = 5
+	of := av.Overflight{
+		Waypoints: av.WaypointArray{
+			entry,
+			wp("MID", testCenter),
+			wp("EXIT", testPoint(45, 0)),
+		},
+		InitialAltitudes:  []int{11000, 13000, 15000, 17000},
+		CruiseAltitude:    17000,
+		InitialSpeed:      280,
+		InitialController: "1A",
+		Airlines: []av.OverflightAirline{{
+			AirlineSpecifier: av.AirlineSpecifier{ICAO: "AAL", AircraftTypes: []string{"B738"}},
+			DepartureAirport: "KBOS",
+			ArrivalAirport:   "KDCA",
+		}},
+	}
+
+	s := NewSim(NewSimConfiguration{
+		Facility: "TST",
+		InboundFlows: map[string]*av.InboundFlow{
+			"east": {Overflights: []av.Overflight{of}},
+		},
+		LaunchConfig: LaunchConfig{
+			OverflightMode:       LaunchAutomatic,
+			InboundFlowRates:     map[string]map[string]float32{"east": {"overflights": 120}},
+			InboundFlowRateScale: 1,
+		},
+		ControlPositions: map[TCP]*av.Controller{
+			"1A": {Position: "1A", Frequency: 120000, RadioName: "Test Approach"},
+		},
+		ControllerConfiguration: &ControllerConfiguration{
+			DefaultConsolidation: PositionConsolidation{"1A": {}},
+		},
+		ScriptedWeather: &wx.ScriptedWeather{Conditions: []wx.ScriptedConditions{{}}},
+		StartTime:       testStartTime,
+		NmPerLongitude:  testNmPerLongitude,
+		Center:          testCenter,
+		Range:           50,
+		Seed:            seed,
+	}, nil, log.New(false, "error", t.TempDir()))
+
+	r := NewSessionReporter(s, "test", seed)
+	for range int(d / time.Second) {
+		s.Step(time.Second)
+		r.Update()
+	}
+	return r.Report(), s.Aircraft
+}
+
+func TestSeededRunsRepeatable(t *testing.T) {
+	db := av.DB
+	t.Cleanup(func() { av.DB = db })
+
+	av.DB = &av.StaticDatabase{
+		AircraftPerformance: map[string]av.AircraftPerformance{"B738": makeTestPerformance()},
+		Airlines:            map[string]av.Airline{"AAL": {ICAO: "AAL"}},
+		Airports: map[string]av.FAAAirport{
+			"KBOS": {Id: "KBOS", Location: math.Point2LL{-71.005, 42.364}},
+			"KDCA": {Id: "KDCA", Location: math.Point2LL{-77.038, 38.852}},
+		},
+		Callsigns: map[string]string{"AAL": "American"},
+		TRACONs:   map[string]av.TRACON{"TST": {}},
+	}
+
+	report0, aircraft0 := runSeededOverflights(t, 1, 5*time.Minute)
+	report1, aircraft1 := runSeededOverflights(t, 1, 5*time.Minute)
+
+	if report0.Spawned < 2 {
+		t.Fatalf("expected multiple overflights to be launched, got %d", report0.Spawned)
+	}
+	if !reflect.DeepEqual(report0, report1) {
+		t.Errorf("reports differ with the same seed:\n%+v\n%+v", report0, report1)
+	}
+
+	if len(aircraft0) != len(aircraft1) {
+		t.Fatalf("expected %d aircraft, got %d", len(aircraft0), len(aircraft1))
+	}
+	for callsign, ac0 := range aircraft0 {
+		ac1, ok := aircraft1[callsign]
+		if !ok {
+			t.Errorf("%s: missing from second run", callsign)
+			continue
+		}
+		if !reflect.DeepEqual(ac0.Nav.Waypoints, ac1.Nav.Waypoints) {
+			t.Errorf("%s: waypoints differ: %+v vs %+v", callsign, ac0.Nav.Waypoints, ac1.Nav.Waypoints)
+		}
+		if ac0.Nav.FlightState.Altitude != ac1.Nav.FlightState.Altitude ||
+			ac0.Nav.FlightState.Position != ac1.Nav.FlightState.Position {
+			t.Errorf("%s: flight state differs: %+v vs %+v", callsign, ac0.Nav.FlightState, ac1.Nav.FlightState)
+		}
+	}
+}
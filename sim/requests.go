@@ -0,0 +1,371 @@
+// sim/requests.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/math"
+	"github.com/mmp/vice/nav"
+	"github.com/mmp/vice/util"
+)
+
+const (
+	// How often each aircraft considers making a request.
+	pilotRequestCheckInterval = 30 * time.Second
+	// Probability that an aircraft that has a reason to make a request
+	// does so when it's checked.
+	pilotRequestProbability = 0.2
+	// Minimum time between an aircraft's requests.
+	pilotRequestInterval = 5 * time.Minute
+	// If the controller hasn't responded to a request in this long, the
+	// pilot asks again; if there's still no response after another period
+	// this long, they give up.
+	pilotRequestFollowUpTime = 90 * time.Second
+	// Vertical wind shear across 2,000', in knots, at or above which
+	// pilots find the ride choppy enough to want a different altitude.
+	chopWindShear = 15
+	// Direct requests are only made if they'd save at least this many nm.
+	directRequestMinSavings = 4
+)
+
+type PilotRequestType int
+
+const (
+	PilotRequestHigher       PilotRequestType = iota // "request higher"
+	PilotRequestLower                                // "request lower"
+	PilotRequestLowerForRide                         // "request lower for the ride"
+	PilotRequestRideReport                           // "any reports of chop at FL240?"
+	PilotRequestDirect                               // "request direct CAMRN"
+)
+
+// PilotRequest is a pilot-initiated request that is waiting for the
+// controller's response. The controller may approve it (REQ), deny it
+// (UNABLE), or issue some other clearance instead, which also ends the
+// request.
+type PilotRequest struct {
+	Type       PilotRequestType
+	Altitude   int    // Altitude requested or asked about
+	Fix        string // For direct requests
+	TCP        TCP    // Controller the request was made to
+	Time       time.Time
+	FollowedUp bool
+
+	// The aircraft's cleared altitude when the request was made; if the
+	// controller changes it, the request has been answered.
+	ClearedAltitude float32
+}
+
+func (r *PilotRequest) isAltitudeRequest() bool {
+	return r.Type != PilotRequestDirect
+}
+
+// clearedAltitude returns the altitude the aircraft is currently cleared
+// to, or 0 if it has no cleared altitude.
+func clearedAltitude(ac *Aircraft) float32 {
+	if a := ac.Nav.Altitude.Assigned; a != nil {
+		return *a
+	} else if c := ac.Nav.Altitude.Cleared; c != nil {
+		return *c
+	}
+	return 0
+}
+
+// updatePilotRequests has aircraft on human controllers' frequencies
+// occasionally make requests based on their phase of flight, the winds
+// aloft, and their route, and it handles follow-ups for requests that
+// haven't been answered.
+func (s *Sim) updatePilotRequests() {
+	now := s.State.SimTime
+	for _, ac := range util.SortedMap(s.Aircraft) {
+		if req := ac.PilotRequest; req != nil {
+			s.checkPilotRequest(ac, req)
+			continue
+		}
+
+		if now.Before(ac.NextPilotRequestTime) {
+			continue
+		}
+		if ac.NextPilotRequestTime.IsZero() {
+			// Don't have aircraft make requests right away.
+			ac.NextPilotRequestTime = now.Add(pilotRequestCheckInterval + time.Duration(s.Rand.Intn(240))*time.Second)
+			continue
+		}
+		ac.NextPilotRequestTime = now.Add(pilotRequestCheckInterval)
+
+		if !s.pilotRequestEligible(ac) {
+			continue
+		}
+		req := s.makePilotRequest(ac)
+		if req == nil || s.Rand.Float32() > pilotRequestProbability {
+			continue
+		}
+
+		ac.PilotRequest = req
+		ac.NextPilotRequestTime = now.Add(pilotRequestInterval)
+		s.lg.Info("pilot request", slog.String("callsign", string(ac.ADSBCallsign)),
+			slog.Int("type", int(req.Type)), slog.Int("altitude", req.Altitude), slog.String("fix", req.Fix))
+		s.enqueuePilotTransmission(ac.ADSBCallsign, req.TCP, PendingTransmissionPilotRequest)
+	}
+}
+
+// checkPilotRequest ends the aircraft's request if the controller has
+// responded to it with another clearance or if it's no longer relevant,
+// and otherwise has the pilot follow up if it's gone unanswered.
+func (s *Sim) checkPilotRequest(ac *Aircraft, req *PilotRequest) {
+	answered := false
+	if ac.ControllerFrequency != ControlPosition(req.TCP) || !ac.IsAirborne() || ac.Nav.Approach.Cleared {
+		answered = true
+	} else if req.isAltitudeRequest() {
+		answered = clearedAltitude(ac) != req.ClearedAltitude
+	} else {
+		wps := ac.Nav.Waypoints
+		answered = ac.Nav.Heading.Assigned != nil || len(wps) == 0 || wps[0].Fix == req.Fix ||
+			!slices.ContainsFunc(wps, func(wp av.Waypoint) bool { return wp.Fix == req.Fix })
+	}
+	if answered {
+		ac.PilotRequest = nil
+		return
+	}
+
+	if s.State.SimTime.Sub(req.Time) > pilotRequestFollowUpTime {
+		if req.FollowedUp {
+			// Give up.
+			ac.PilotRequest = nil
+			return
+		}
+		req.FollowedUp = true
+		req.Time = s.State.SimTime
+		s.enqueuePilotTransmission(ac.ADSBCallsign, req.TCP, PendingTransmissionPilotRequestFollowUp)
+	}
+}
+
+// pilotRequestEligible returns true if the aircraft is in a position to
+// make a request of its controller.
+func (s *Sim) pilotRequestEligible(ac *Aircraft) bool {
+	if ac.FlightPlan.Rules != av.FlightRulesIFR || !ac.IsAirborne() || ac.WaitingForLaunch ||
+		ac.ControllerFrequency == "" || s.isVirtualController(ac.ControllerFrequency) || s.flownByPseudoPilot(ac) ||
+		ac.WXDeviation != nil || ac.TCASAdvisory != nav.TCASNone || ac.EmergencyState != nil ||
+		ac.Nav.Approach.Cleared || ac.Nav.Airwork != nil {
+		return false
+	}
+	// Wait until any other transmissions from the aircraft have been made.
+	tcp := TCP(ac.ControllerFrequency)
+	return !slices.ContainsFunc(s.PendingContacts[tcp], func(pc PendingContact) bool {
+		return pc.ADSBCallsign == ac.ADSBCallsign
+	})
+}
+
+// makePilotRequest returns a request the aircraft could reasonably make
+// given its current situation, or nil if there is none.
+func (s *Sim) makePilotRequest(ac *Aircraft) *PilotRequest {
+	req := &PilotRequest{
+		TCP:             TCP(ac.ControllerFrequency),
+		Time:            s.State.SimTime,
+		ClearedAltitude: clearedAltitude(ac),
+	}
+
+	alt := ac.Altitude()
+	target, _ := ac.Nav.TargetAltitude()
+	level := math.Abs(alt-target) < 100
+
+	// Bumpy ride? Only consider it when level in the flight levels.
+	if level && alt >= 18000 {
+		if ride := s.betterRideAltitude(ac); ride != 0 {
+			req.Altitude = ride
+			req.Type = util.Select(ride < int(alt), PilotRequestLowerForRide, PilotRequestRideReport)
+			return req
+		}
+	}
+
+	cruise := float32(ac.FlightPlan.Altitude)
+	if level && (ac.IsDeparture() || ac.IsOverflight()) && target+1000 < cruise && req.ClearedAltitude != 0 {
+		req.Type = PilotRequestHigher
+		req.Altitude = int(cruise)
+		return req
+	}
+
+	if level && ac.IsArrival() && alt > 10000 {
+		if ap, ok := av.DB.Airports[ac.FlightPlan.ArrivalAirport]; ok {
+			// Ask for lower once they're within the usual 3:1 descent
+			// profile distance of their destination.
+			d := math.NMDistance2LL(ac.Position(), ap.Location)
+			if d < 3*(alt-float32(ap.Elevation))/1000+10 {
+				req.Type = PilotRequestLower
+				req.Altitude = max(10000, 1000*int((alt-4000)/1000))
+				return req
+			}
+		}
+	}
+
+	if fix := s.directRequestFix(ac); fix != "" {
+		req.Type = PilotRequestDirect
+		req.Fix = fix
+		return req
+	}
+
+	return nil
+}
+
+// betterRideAltitude returns a nearby altitude with less wind shear than
+// the aircraft's current one if the ride at its current altitude is
+// choppy, or 0 otherwise.
+func (s *Sim) betterRideAltitude(ac *Aircraft) int {
+	if s.wxModel == nil {
+		return 0
+	}
+
+	p := ac.Position()
+	shear := func(alt float32) float32 {
+		lo := s.wxModel.Lookup(p, alt-1000, s.State.SimTime).WindVec()
+		hi := s.wxModel.Lookup(p, alt+1000, s.State.SimTime).WindVec()
+		// WindVec is in nm/s
+		return 3600 * math.Length2f(math.Sub2f(hi, lo))
+	}
+
+	alt := 1000 * math.Round(ac.Altitude()/1000)
+	if shear(alt) < chopWindShear {
+		return 0
+	}
+
+	ceiling := ac.AircraftPerformance().Ceiling
+	if ceiling == 0 {
+		ceiling = 41000
+	}
+	for _, delta := range []float32{-2000, 2000, -4000, 4000} {
+		a := alt + delta
+		if a < 18000 || a > min(ceiling, float32(ac.FlightPlan.Altitude)+4000) {
+			continue
+		}
+		if shear(a) < chopWindShear {
+			return int(a)
+		}
+	}
+	return 0
+}
+
+// directRequestFix returns a fix further along the aircraft's route that
+// it might ask to proceed direct to, or the empty string if there isn't a
+// suitable one.
+func (s *Sim) directRequestFix(ac *Aircraft) string {
+	if ac.Nav.Heading.Assigned != nil || ac.Nav.Heading.Hold != nil || ac.IsArrival() {
+		// Arrivals stay on their STARs to meet its restrictions.
+		return ""
+	}
+
+	wps := ac.Nav.Waypoints
+	if len(wps) < 2 {
+		return ""
+	}
+	routeDist := math.NMDistance2LL(ac.Position(), wps[0].Location)
+	fix, bestSavings := "", float32(directRequestMinSavings)
+	for i := 1; i < min(len(wps), 6); i++ {
+		routeDist += math.NMDistance2LL(wps[i-1].Location, wps[i].Location)
+		if wps[i].HandoffController() != "" || wps[i].PointOut() != "" {
+			// Don't skip past scripted handoffs and point outs.
+			break
+		}
+		if strings.HasPrefix(wps[i].Fix, "_") || wps[i].Location.IsZero() {
+			continue
+		}
+		if savings := routeDist - math.NMDistance2LL(ac.Position(), wps[i].Location); savings > bestSavings {
+			fix, bestSavings = wps[i].Fix, savings
+		}
+	}
+	return fix
+}
+
+// ApprovePilotRequest approves the aircraft's pending request: it's
+// cleared to the altitude it asked for or direct to the fix it requested.
+// Ride report requests are only questions, so the pilot just acknowledges
+// the controller's answer and stays at its current altitude.
+func (s *Sim) ApprovePilotRequest(tcw TCW, callsign av.ADSBCallsign) (av.CommandIntent, error) {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	return s.dispatchControlledAircraftCommand(tcw, callsign,
+		func(tcw TCW, ac *Aircraft) av.CommandIntent {
+			req := ac.PilotRequest
+			if req == nil {
+				return av.MakeUnableIntent("unable. We don't have a request")
+			}
+			ac.PilotRequest = nil
+
+			switch req.Type {
+			case PilotRequestHigher, PilotRequestLower, PilotRequestLowerForRide:
+				return ac.AssignAltitude(req.Altitude, false)
+			case PilotRequestRideReport:
+				return av.NavigationIntent{Type: av.NavRideReportAcknowledged}
+			case PilotRequestDirect:
+				return ac.DirectFix(req.Fix, s.State.SimTime)
+			default:
+				s.lg.Errorf("%d: unhandled pilot request type", req.Type)
+				return nil
+			}
+		})
+}
+
+// DenyPilotRequest tells the pilot that the controller is unable to
+// approve their pending request.
+func (s *Sim) DenyPilotRequest(tcw TCW, callsign av.ADSBCallsign) (av.CommandIntent, error) {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	return s.dispatchControlledAircraftCommand(tcw, callsign,
+		func(tcw TCW, ac *Aircraft) av.CommandIntent {
+			req := ac.PilotRequest
+			if req == nil {
+				return av.MakeUnableIntent("unable. We don't have a request")
+			}
+			ac.PilotRequest = nil
+			return av.NavigationIntent{Type: util.Select(req.Type == PilotRequestDirect, av.NavRequestDeniedDirect,
+				av.NavRequestDeniedAltitude)}
+		})
+}
+
+// makePilotRequestTransmission returns the transmission for the aircraft's
+// pending request, or nil if it no longer has one.
+func makePilotRequestTransmission(ac *Aircraft, followUp bool) *av.RadioTransmission {
+	req := ac.PilotRequest
+	if req == nil || req.FollowedUp != followUp {
+		return nil
+	}
+
+	if followUp {
+		switch req.Type {
+		case PilotRequestHigher:
+			return av.MakeContactTransmission("[any word on higher|still looking for higher|checking on higher]")
+		case PilotRequestLower, PilotRequestLowerForRide:
+			return av.MakeContactTransmission("[any word on lower|still looking for lower|checking on lower], {alt} [if able|]",
+				req.Altitude)
+		case PilotRequestRideReport:
+			return av.MakeContactTransmission("[any word on|checking on] the ride at {alt}", req.Altitude)
+		case PilotRequestDirect:
+			return av.MakeContactTransmission("[any word on|still looking for|checking on] direct {fix}", req.Fix)
+		}
+		return nil
+	}
+
+	switch req.Type {
+	case PilotRequestHigher:
+		return av.MakeContactTransmission("[request higher|looking for higher|any chance of higher], [we'd like {alt}|final altitude {alt}|]",
+			req.Altitude)
+	case PilotRequestLower:
+		return av.MakeContactTransmission("[request lower|ready for lower|whenever you're ready, we'd like lower]")
+	case PilotRequestLowerForRide:
+		return av.MakeContactTransmission("[it's pretty bumpy up here|we're getting a continuous light chop|the ride's not great up here], "+
+			"request {alt} [for the ride|if able|]", req.Altitude)
+	case PilotRequestRideReport:
+		return av.MakeContactTransmission("[any reports of chop at|any ride reports at|how's the ride at] {alt}? "+
+			"[it's bumpy here|we're getting some chop]", req.Altitude)
+	case PilotRequestDirect:
+		return av.MakeContactTransmission("[request direct|requesting direct|any chance of direct] {fix}", req.Fix)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+// sim/requests_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"testing"
+
+	av "github.com/mmp/vice/aviation"
+)
+
+func makePilotRequestTestSim(t *testing.T, ac *Aircraft) *Sim {
+	s := makeTestSim(t, ac)
+	s.State.CurrentConsolidation = map[TCW]*TCPConsolidation{"1A": {PrimaryTCP: "1A"}}
+	return s
+}
+
+// makePilotRequestTestAircraft returns an aircraft level at the given
+// altitude, north of testCenter and headed south toward it, on 1A's
+// frequency.
+func makePilotRequestTestAircraft(ty av.TypeOfFlight, altitude float32, north float32) *Aircraft {
+	ac := makeTestAircraft("AAL1", testPoint(0, north), altitude, 180, 250)
+	ac.TypeOfFlight = ty
+	ac.ControllerFrequency = "1A"
+	ac.FlightPlan.Altitude = 35000
+	ac.FlightPlan.ArrivalAirport = "KJFK"
+	ac.Nav.Altitude.Assigned = &altitude
+	ac.Nav.Perf.Ceiling = 41000
+	return ac
+}
+
+func TestMakePilotRequest(t *testing.T) {
+	db := av.DB
+	t.Cleanup(func() { av.DB = db })
+	av.DB = &av.StaticDatabase{
+		Airports: map[string]av.FAAAirport{"KJFK": {Id: "KJFK", Location: testCenter}},
+	}
+
+	// A departure level below its cruise altitude asks for higher.
+	ac := makePilotRequestTestAircraft(av.FlightTypeDeparture, 10000, 0)
+	s := makePilotRequestTestSim(t, ac)
+	if req := s.makePilotRequest(ac); req == nil || req.Type != PilotRequestHigher || req.Altitude != 35000 ||
+		req.ClearedAltitude != 10000 || req.TCP != "1A" {
+		t.Errorf("expected a request for higher, got %+v", req)
+	}
+
+	// One at its cruise altitude has nothing to ask for unless there's a
+	// shortcut along its route.
+	ac = makePilotRequestTestAircraft(av.FlightTypeDeparture, 35000, 0)
+	s = makePilotRequestTestSim(t, ac)
+	if req := s.makePilotRequest(ac); req != nil {
+		t.Errorf("unexpected request %+v", req)
+	}
+	ac.Nav.Waypoints = []av.Waypoint{
+		{Fix: "WEST1", Location: testPoint(-10, -10)},
+		{Fix: "EAST1", Location: testPoint(10, -20)},
+		{Fix: "SOUTH", Location: testPoint(0, -30)},
+	}
+	if req := s.makePilotRequest(ac); req == nil || req.Type != PilotRequestDirect || req.Fix != "SOUTH" {
+		t.Errorf("expected a request for direct SOUTH, got %+v", req)
+	}
+
+	// Arrivals ask for lower once they're within a 3:1 descent of their
+	// destination.
+	ac = makePilotRequestTestAircraft(av.FlightTypeArrival, 16000, 80)
+	s = makePilotRequestTestSim(t, ac)
+	if req := s.makePilotRequest(ac); req != nil {
+		t.Errorf("unexpected request far from the airport %+v", req)
+	}
+	ac.Nav.FlightState.Position = testPoint(0, 40)
+	if req := s.makePilotRequest(ac); req == nil || req.Type != PilotRequestLower || req.Altitude != 12000 {
+		t.Errorf("expected a request for lower to 12000, got %+v", req)
+	}
+
+	// Aircraft don't make requests of virtual controllers.
+	s.ControlPositions = map[TCP]*av.Controller{"1A": {Position: "1A"}}
+	if s.pilotRequestEligible(ac) {
+		t.Errorf("aircraft on a virtual controller's frequency is eligible to make requests")
+	}
+}
+
+func TestPilotRequestResponses(t *testing.T) {
+	respond := func(req PilotRequest, approve bool) (*Aircraft, av.CommandIntent) {
+		t.Helper()
+
+		ac := makePilotRequestTestAircraft(av.FlightTypeDeparture, 24000, 0)
+		ac.Nav.Waypoints = []av.Waypoint{{Fix: "WEST1", Location: testPoint(-9, -12)},
+			{Fix: "SOUTH", Location: testPoint(0, -30)}}
+		req.TCP = "1A"
+		ac.PilotRequest = &req
+		s := makePilotRequestTestSim(t, ac)
+
+		var intent av.CommandIntent
+		var err error
+		if approve {
+			intent, err = s.ApprovePilotRequest("1A", "AAL1")
+		} else {
+			intent, err = s.DenyPilotRequest("1A", "AAL1")
+		}
+		if err != nil {
+			t.Fatalf("%+v: %v", req, err)
+		}
+		if ac.PilotRequest != nil {
+			t.Errorf("%+v: request still pending after response", req)
+		}
+		return ac, intent
+	}
+
+	// Approved altitude requests are cleared to the requested altitude.
+	for _, ty := range []PilotRequestType{PilotRequestHigher, PilotRequestLower, PilotRequestLowerForRide} {
+		ac, _ := respond(PilotRequest{Type: ty, Altitude: 28000}, true)
+		if alt := ac.Nav.Altitude.Assigned; alt == nil || *alt != 28000 {
+			t.Errorf("%d: expected to be assigned 28000, got %v", ty, alt)
+		}
+	}
+
+	// Approving a ride report doesn't change the aircraft's altitude.
+	ac, intent := respond(PilotRequest{Type: PilotRequestRideReport, Altitude: 28000}, true)
+	if alt := ac.Nav.Altitude.Assigned; alt == nil || *alt != 24000 {
+		t.Errorf("ride report changed the assigned altitude to %v", alt)
+	}
+	if ni, ok := intent.(av.NavigationIntent); !ok || ni.Type != av.NavRideReportAcknowledged {
+		t.Errorf("expected the ride report to be acknowledged, got %#v", intent)
+	}
+
+	_, intent = respond(PilotRequest{Type: PilotRequestDirect, Fix: "SOUTH"}, true)
+	if ni, ok := intent.(av.NavigationIntent); !ok || ni.Type != av.NavDirectFix || ni.Fix != "SOUTH" {
+		t.Errorf("expected to proceed direct SOUTH, got %#v", intent)
+	}
+
+	// UNABLE leaves the aircraft's clearance as it was.
+	for _, test := range []struct {
+		req    PilotRequest
+		intent av.NavigationType
+	}{
+		{PilotRequest{Type: PilotRequestHigher, Altitude: 28000}, av.NavRequestDeniedAltitude},
+		{PilotRequest{Type: PilotRequestRideReport, Altitude: 28000}, av.NavRequestDeniedAltitude},
+		{PilotRequest{Type: PilotRequestDirect, Fix: "SOUTH"}, av.NavRequestDeniedDirect},
+	} {
+		ac, intent := respond(test.req, false)
+		if alt := ac.Nav.Altitude.Assigned; alt == nil || *alt != 24000 || ac.Nav.Waypoints[0].Fix != "WEST1" {
+			t.Errorf("%+v: clearance changed after UNABLE", test.req)
+		}
+		if ni, ok := intent.(av.NavigationIntent); !ok || ni.Type != test.intent {
+			t.Errorf("%+v: expected intent %d, got %#v", test.req, test.intent, intent)
+		}
+	}
+
+	// There's nothing to approve if there's no request.
+	ac = makePilotRequestTestAircraft(av.FlightTypeDeparture, 24000, 0)
+	s := makePilotRequestTestSim(t, ac)
+	if intent, err := s.ApprovePilotRequest("1A", "AAL1"); err != nil {
+		t.Fatal(err)
+	} else if _, ok := intent.(av.UnableIntent); !ok {
+		t.Errorf("expected unable with no request, got %#v", intent)
+	}
+}
@@ -0,0 +1,393 @@
+// sim/runwayconfig.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"cmp"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/math"
+	"github.com/mmp/vice/util"
+)
+
+const (
+	// Runways generally aren't used if the tailwind component is greater
+	// than this, in knots.
+	maxRunwayTailwind = 5
+	// How long an excessive tailwind must persist before a virtual TMU
+	// changes the runway configuration.
+	runwayChangeDelay = 10 * time.Minute
+	// Additional time it takes a departure that has already taxied out to
+	// get to a new runway.
+	retaxiDelay = 3 * time.Minute
+)
+
+// RunwayConfiguration is a set of departure and arrival runways that a
+// running sim may switch to, e.g. after a wind shift. A sim's runway
+// configurations come from the scenarios in its scenario group that share
+// its controller configuration.
+type RunwayConfiguration struct {
+	Name             string
+	DepartureRunways []DepartureRunway
+	ArrivalRunways   []ArrivalRunway
+}
+
+// RunwayTailwind is the tailwind component on a runway given the
+// airport's current METAR.
+type RunwayTailwind struct {
+	Airport  string
+	Runway   string
+	Tailwind float32 // knots; negative for a headwind
+}
+
+// runwayTailwinds returns the tailwind components on the given runways,
+// sorted from largest to smallest. Runways at airports without a METAR
+// are skipped and variable winds are taken to be calm.
+func (ss *CommonState) runwayTailwinds(dep []DepartureRunway, arr []ArrivalRunway) []RunwayTailwind {
+	var tw []RunwayTailwind
+	add := func(airport string, id av.RunwayID) {
+		if slices.ContainsFunc(tw, func(t RunwayTailwind) bool { return t.Airport == airport && t.Runway == id.Base() }) {
+			return
+		}
+		metar, ok := ss.METAR[airport]
+		if !ok {
+			return
+		}
+		rwy, ok := av.LookupRunway(airport, id.Base())
+		if !ok {
+			return
+		}
+
+		t := RunwayTailwind{Airport: airport, Runway: id.Base()}
+		if metar.WindDir != nil {
+			// Both the runway heading and the wind direction are true and
+			// the wind direction is the one it's blowing from.
+			t.Tailwind = -float32(metar.WindSpeed) * math.Cos(math.Radians(float32(*metar.WindDir)-rwy.Heading))
+		}
+		tw = append(tw, t)
+	}
+	for _, r := range dep {
+		add(r.Airport, r.Runway)
+	}
+	for _, r := range arr {
+		add(r.Airport, r.Runway)
+	}
+
+	slices.SortStableFunc(tw, func(a, b RunwayTailwind) int { return cmp.Compare(b.Tailwind, a.Tailwind) })
+	return tw
+}
+
+// ExcessiveTailwinds returns the active runways where the tailwind
+// component is more than is acceptable for using them.
+func (ss *CommonState) ExcessiveTailwinds() []RunwayTailwind {
+	tw := ss.runwayTailwinds(ss.DepartureRunways, ss.ArrivalRunways)
+	return slices.DeleteFunc(tw, func(t RunwayTailwind) bool { return t.Tailwind <= maxRunwayTailwind })
+}
+
+// MaxTailwind returns the largest tailwind component on any of the
+// runway configuration's runways.
+func (ss *CommonState) MaxTailwind(rc RunwayConfiguration) float32 {
+	if tw := ss.runwayTailwinds(rc.DepartureRunways, rc.ArrivalRunways); len(tw) > 0 {
+		return tw[0].Tailwind
+	}
+	return 0
+}
+
+// checkRunwayTailwinds lets the controllers know when the tailwind on an
+// active runway becomes excessive. If automatic runway changes are
+// enabled and the tailwind persists, it then switches to the runway
+// configuration with the least tailwind, as a TMU would.
+func (s *Sim) checkRunwayTailwinds() {
+	tw := s.State.ExcessiveTailwinds()
+	if len(tw) == 0 {
+		s.ExcessTailwindStart = time.Time{}
+		return
+	}
+
+	if s.ExcessTailwindStart.IsZero() {
+		s.ExcessTailwindStart = s.State.SimTime
+		s.lg.Info("excessive tailwind", slog.Any("tailwinds", tw))
+
+		rwys := util.MapSlice(tw, func(t RunwayTailwind) string {
+			return fmt.Sprintf("%s %s (%d knots)", t.Airport, t.Runway, int(t.Tailwind+0.5))
+		})
+		s.eventStream.Post(Event{
+			Type:        StatusMessageEvent,
+			WrittenText: fmt.Sprintf("Tailwind over %d knots on %s.", maxRunwayTailwind, strings.Join(rwys, ", ")),
+		})
+		return
+	}
+
+	if !s.State.LaunchConfig.AutomaticRunwayChanges || s.State.SimTime.Sub(s.ExcessTailwindStart) < runwayChangeDelay {
+		return
+	}
+
+	var best *RunwayConfiguration
+	var bestTailwind float32
+	for i, rc := range s.State.RunwayConfigurations {
+		if rc.Name == s.State.RunwayConfiguration {
+			continue
+		}
+		if t := s.State.MaxTailwind(rc); t <= maxRunwayTailwind && (best == nil || t < bestTailwind) {
+			best, bestTailwind = &s.State.RunwayConfigurations[i], t
+		}
+	}
+	if best != nil {
+		s.changeRunwayConfiguration(*best, "TMU")
+	}
+}
+
+// ChangeRunwayConfiguration switches the sim to the named runway
+// configuration.
+func (s *Sim) ChangeRunwayConfiguration(tcw TCW, name string) error {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	idx := slices.IndexFunc(s.State.RunwayConfigurations, func(rc RunwayConfiguration) bool { return rc.Name == name })
+	if idx == -1 {
+		return ErrUnknownRunwayConfiguration
+	}
+	if name != s.State.RunwayConfiguration {
+		s.changeRunwayConfiguration(s.State.RunwayConfigurations[idx], string(s.State.PrimaryPositionForTCW(tcw)))
+	}
+	return nil
+}
+
+// changeRunwayConfiguration makes the given configuration's runways the
+// active ones. Departures that haven't launched yet taxi to a new runway
+// that serves their exit, arrivals expecting an approach to a runway
+// that is no longer active are given a new one, and the ATIS is updated
+// at each airport where the runways changed.
+func (s *Sim) changeRunwayConfiguration(rc RunwayConfiguration, by string) {
+	s.lg.Info("changing runway configuration", slog.String("from", s.State.RunwayConfiguration),
+		slog.String("to", rc.Name), slog.String("by", by))
+
+	runways := func(dep []DepartureRunway, arr []ArrivalRunway) map[string][]string {
+		m := make(map[string][]string)
+		for _, r := range dep {
+			m[r.Airport] = append(m[r.Airport], "D"+string(r.Runway)+"/"+r.Category)
+		}
+		for _, r := range arr {
+			m[r.Airport] = append(m[r.Airport], "A"+string(r.Runway))
+		}
+		for _, rwys := range m {
+			slices.Sort(rwys)
+		}
+		return m
+	}
+	prev, cur := runways(s.State.DepartureRunways, s.State.ArrivalRunways), runways(rc.DepartureRunways, rc.ArrivalRunways)
+
+	s.State.RunwayConfiguration = rc.Name
+	s.State.DepartureRunways = rc.DepartureRunways
+	s.State.ArrivalRunways = rc.ArrivalRunways
+	s.RunwaysChanged = true
+	s.ExcessTailwindStart = time.Time{}
+
+	retaxied, deleted := s.updateDeparturesForRunwayChange()
+
+	reassigned := 0
+	for _, ac := range util.SortedMap(s.Aircraft) {
+		if s.reassignExpectedApproach(ac) {
+			reassigned++
+		}
+	}
+
+	airports := maps.Clone(prev)
+	maps.Copy(airports, cur)
+	for ap := range airports {
+		if !slices.Equal(prev[ap], cur[ap]) {
+			s.advanceATIS(ap)
+		}
+	}
+
+	msg := fmt.Sprintf("%s changed the runway configuration to %s.", by, rc.Name)
+	if retaxied > 0 {
+		msg += fmt.Sprintf(" %d departures are taxiing to new runways.", retaxied)
+	}
+	if deleted > 0 {
+		msg += fmt.Sprintf(" %d departures with no runway for their exit were removed.", deleted)
+	}
+	if reassigned > 0 {
+		msg += fmt.Sprintf(" %d arrivals now expect new approaches.", reassigned)
+	}
+	s.eventStream.Post(Event{
+		Type:        StatusMessageEvent,
+		WrittenText: msg,
+	})
+}
+
+// updateDeparturesForRunwayChange updates the departure rates and launch
+// state for the active departure runways. Departures waiting to launch
+// from runways that are no longer active taxi to one that serves their
+// exit; ones for which there isn't one are deleted.
+func (s *Sim) updateDeparturesForRunwayChange() (retaxied, deleted int) {
+	lc := &s.State.LaunchConfig
+	lc.DepartureRates = makeDepartureRates(s.State.DepartureRunways)
+
+	// Set up the new runways first so that the departures have somewhere
+	// to go.
+	for ap, rwyRates := range util.SortedMap(lc.DepartureRates) {
+		s.State.DepartureAirports[ap] = nil
+		if s.DepartureState[ap] == nil {
+			s.DepartureState[ap] = make(map[av.RunwayID]*RunwayLaunchState)
+		}
+		for rwy, rates := range util.SortedMap(rwyRates) {
+			state, ok := s.DepartureState[ap][rwy]
+			if !ok {
+				state = &RunwayLaunchState{}
+				s.DepartureState[ap][rwy] = state
+			}
+			state.setIFRRate(s, sumRateMap(rates, lc.DepartureRateScale))
+		}
+	}
+
+	for ap, rwyStates := range util.SortedMap(s.DepartureState) {
+		for rwy, state := range util.SortedMap(rwyStates) {
+			if _, ok := lc.DepartureRates[ap][rwy]; ok {
+				continue
+			}
+
+			// Only IFRs move; VFRs depart from the VFR runway, which
+			// doesn't change.
+			retaxi := func(deps []DepartureAircraft, add func(*RunwayLaunchState, DepartureAircraft)) []DepartureAircraft {
+				return util.FilterSliceInPlace(deps, func(dep DepartureAircraft) bool {
+					ac, ok := s.Aircraft[dep.ADSBCallsign]
+					if !ok || ac.FlightPlan.Rules != av.FlightRulesIFR {
+						return true
+					}
+					if to := s.retaxiDeparture(ac, ap); to != nil {
+						add(to, dep)
+						retaxied++
+					} else {
+						s.deleteAircraft(ac)
+						deleted++
+					}
+					return false
+				})
+			}
+			released := func(to *RunwayLaunchState, dep DepartureAircraft) {
+				to.ReleasedIFR = append(to.ReleasedIFR, dep)
+			}
+
+			state.IFRSpawnRate = 0
+			state.Gate = retaxi(state.Gate, func(to *RunwayLaunchState, dep DepartureAircraft) {
+				to.Gate = append(to.Gate, dep)
+			})
+			state.Held = retaxi(state.Held, func(to *RunwayLaunchState, dep DepartureAircraft) {
+				dep.ReleaseDelay += retaxiDelay
+				to.Held = append(to.Held, dep)
+			})
+			state.ReleasedIFR = retaxi(state.ReleasedIFR, released)
+			state.Sequenced = retaxi(state.Sequenced, released)
+
+			if state.VFRSpawnRate == 0 && len(state.ReleasedVFR) == 0 && len(state.Sequenced) == 0 {
+				delete(rwyStates, rwy)
+			}
+		}
+	}
+
+	return
+}
+
+// retaxiDeparture reinitializes a departure that hasn't launched for an
+// active runway that serves its exit and returns that runway's launch
+// state. It returns nil if there is no such runway.
+func (s *Sim) retaxiDeparture(ac *Aircraft, airport string) *RunwayLaunchState {
+	ap := s.State.Airports[airport]
+	exit := ac.FlightPlan.Exit
+	idx := slices.IndexFunc(ap.Departures, func(d av.Departure) bool {
+		return d.Exit == exit && d.Destination == ac.FlightPlan.ArrivalAirport
+	})
+	if idx == -1 {
+		return nil
+	}
+
+	for _, rwy := range s.State.DepartureRunways {
+		if rwy.Airport != airport || (rwy.Category != "" && rwy.Category != ap.ExitCategories[exit]) {
+			continue
+		}
+		exitRoutes := ap.DepartureRoutes[rwy.Runway]
+		exitRoute, ok := exitRoutes[exit]
+		if !ok {
+			continue
+		}
+
+		if err := ac.InitializeDeparture(ap, airport, &ap.Departures[idx], string(rwy.Runway), *exitRoute,
+			s.State.NmPerLongitude, s.State.MagneticVariation, s.Rand, s.wxModel, s.State.SimTime, s.lg); err != nil {
+			s.lg.Errorf("%s: unable to reinitialize departure for runway %s: %v", ac.ADSBCallsign, rwy.Runway, err)
+			return nil
+		}
+		ac.ReportDepartureHeading = exitRoutesHaveVariedHeadings(exitRoutes)
+		if fp := s.STARSComputer.lookupFlightPlanByACID(ACID(ac.ADSBCallsign)); fp != nil {
+			fp.Route = ac.FlightPlan.Route
+		}
+
+		s.lg.Info("retaxied departure", slog.String("adsb_callsign", string(ac.ADSBCallsign)),
+			slog.String("runway", string(rwy.Runway)))
+		return s.DepartureState[airport][rwy.Runway]
+	}
+	return nil
+}
+
+// reassignExpectedApproach has an arrival that is expecting an approach
+// to a runway that isn't active expect one to an active runway instead,
+// preferring an approach of the same type. If the aircraft is talking to
+// a human controller, the pilot lets them know which approach they now
+// expect. It returns true if the aircraft's approach was changed.
+func (s *Sim) reassignExpectedApproach(ac *Aircraft) bool {
+	appr := ac.Nav.Approach.Assigned
+	if ac.TypeOfFlight != av.FlightTypeArrival || appr == nil || ac.Nav.Approach.Cleared {
+		return false
+	}
+
+	airport := ac.FlightPlan.ArrivalAirport
+	var active []string
+	for _, ar := range s.State.ArrivalRunways {
+		if ar.Airport == airport {
+			active = append(active, ar.Runway.Base())
+		}
+	}
+	if len(active) == 0 || slices.Contains(active, appr.Runway) {
+		return false
+	}
+
+	ap := s.State.Airports[airport]
+	id := ""
+	for name, a := range util.SortedMap(ap.Approaches) {
+		if slices.Contains(active, a.Runway) && (id == "" || (a.Type == appr.Type && ap.Approaches[id].Type != appr.Type)) {
+			id = name
+		}
+	}
+	if id == "" {
+		return false
+	}
+
+	intent := ac.ExpectApproach(id, ap, "", s.lg)
+	if _, ok := intent.(av.ApproachIntent); !ok {
+		s.lg.Warn("unable to reassign expected approach", slog.String("adsb_callsign", string(ac.ADSBCallsign)),
+			slog.String("approach", id))
+		return false
+	}
+	s.lg.Info("reassigned expected approach", slog.String("adsb_callsign", string(ac.ADSBCallsign)),
+		slog.String("approach", id))
+
+	if freq := ac.ControllerFrequency; freq != "" && !s.isVirtualController(freq) {
+		rt := av.MakeContactTransmission("[looks like the runways changed|we see the runway change], ")
+		intent.Render(rt, s.Rand)
+		s.addPendingContact(PendingContact{
+			ADSBCallsign:         ac.ADSBCallsign,
+			TCP:                  TCP(freq),
+			Type:                 PendingTransmissionExpectApproach,
+			PrebuiltTransmission: rt,
+		})
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+// sim/runwayconfig_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"testing"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/math"
+	"github.com/mmp/vice/wx"
+)
+
+func setRunwayTestDB(t *testing.T) {
+	db := av.DB
+	t.Cleanup(func() { av.DB = db })
+
+	av.DB = &av.StaticDatabase{
+		Airports: map[string]av.FAAAirport{
+			"KJFK": {Id: "KJFK", Runways: []av.Runway{
+				{Id: "4L", Heading: 40},
+				{Id: "22R", Heading: 220},
+				{Id: "31L", Heading: 310},
+			}},
+			"KLGA": {Id: "KLGA", Runways: []av.Runway{{Id: "13", Heading: 130}}},
+		},
+	}
+}
+
+func TestRunwayTailwinds(t *testing.T) {
+	setRunwayTestDB(t)
+
+	dir := 220
+	ss := &CommonState{}
+	ss.METAR = map[string]wx.METAR{"KJFK": {WindDir: &dir, WindSpeed: 10}}
+
+	dep := []DepartureRunway{
+		{Airport: "KJFK", Runway: "4L", Category: "North"},
+		{Airport: "KJFK", Runway: "4L.South", Category: "South"},
+	}
+	arr := []ArrivalRunway{
+		{Airport: "KJFK", Runway: "22R"},
+		{Airport: "KJFK", Runway: "31L"},
+		// KLGA doesn't have a METAR and so is skipped.
+		{Airport: "KLGA", Runway: "13"},
+	}
+
+	tw := ss.runwayTailwinds(dep, arr)
+	expected := []RunwayTailwind{
+		{Airport: "KJFK", Runway: "4L", Tailwind: 10},
+		{Airport: "KJFK", Runway: "31L", Tailwind: 0},
+		{Airport: "KJFK", Runway: "22R", Tailwind: -10},
+	}
+	if len(tw) != len(expected) {
+		t.Fatalf("expected %d runways, got %+v", len(expected), tw)
+	}
+	for i, e := range expected {
+		if tw[i].Airport != e.Airport || tw[i].Runway != e.Runway || math.Abs(tw[i].Tailwind-e.Tailwind) > 0.01 {
+			t.Errorf("%d: expected %+v, got %+v", i, e, tw[i])
+		}
+	}
+
+	// Variable winds are taken to be calm.
+	ss.METAR["KJFK"] = wx.METAR{WindSpeed: 5}
+	for _, rt := range ss.runwayTailwinds(dep, arr) {
+		if rt.Tailwind != 0 {
+			t.Errorf("%s: expected no tailwind with variable winds, got %.1f", rt.Runway, rt.Tailwind)
+		}
+	}
+}
+
+func TestExcessiveTailwinds(t *testing.T) {
+	setRunwayTestDB(t)
+
+	ss := &CommonState{}
+	ss.DepartureRunways = []DepartureRunway{{Airport: "KJFK", Runway: "4L"}}
+	ss.ArrivalRunways = []ArrivalRunway{{Airport: "KJFK", Runway: "31L"}}
+
+	dir := 220
+	for _, test := range []struct {
+		speed     int
+		excessive []string
+	}{
+		{speed: 3},
+		// Exactly the maximum is acceptable.
+		{speed: maxRunwayTailwind},
+		{speed: 12, excessive: []string{"4L"}},
+	} {
+		ss.METAR = map[string]wx.METAR{"KJFK": {WindDir: &dir, WindSpeed: test.speed}}
+		tw := ss.ExcessiveTailwinds()
+		if len(tw) != len(test.excessive) {
+			t.Errorf("%d knots: expected excessive tailwinds on %v, got %+v", test.speed, test.excessive, tw)
+			continue
+		}
+		for i, rwy := range test.excessive {
+			if tw[i].Runway != rwy {
+				t.Errorf("%d knots: expected excessive tailwinds on %v, got %+v", test.speed, test.excessive, tw)
+			}
+		}
+	}
+}
+
+func TestMergeDepartureRates(t *testing.T) {
+	cur := map[string]map[av.RunwayID]map[string]float32{
+		"KJFK": {"31L": {"North": 10, "South": 20}},
+		"KLGA": {"13": {"": 15}},
+	}
+	// Edits made to the previous configuration, where KJFK was departing
+	// 4L.
+	edited := map[string]map[av.RunwayID]map[string]float32{
+		"KJFK": {"4L": {"North": 30}},
+		"KLGA": {"13": {"": 25}},
+	}
+
+	merged := mergeDepartureRates(cur, edited)
+	if len(merged) != 2 || len(merged["KJFK"]) != 1 {
+		t.Fatalf("runways changed in merge: %v", merged)
+	}
+	if r := merged["KJFK"]["31L"]; r["North"] != 10 || r["South"] != 20 {
+		t.Errorf("expected the current rates for KJFK 31L, got %v", r)
+	}
+	if r := merged["KLGA"]["13"][""]; r != 25 {
+		t.Errorf("expected the edited rate of 25 for KLGA 13, got %.0f", r)
+	}
+	if cur["KLGA"]["13"][""] != 15 {
+		t.Errorf("current rates were modified")
+	}
+}
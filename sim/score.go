@@ -0,0 +1,236 @@
+// sim/score.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"maps"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/util"
+)
+
+// A pilot check-in that hasn't gotten a response from the controller
+// after this long is counted as unanswered.
+const checkInResponseTimeout = 30 * time.Second
+
+// SessionScore gives objective measures of how the human controllers in
+// a sim performed; it's used for training debriefs.
+type SessionScore struct {
+	Start     time.Time              `json:"start"`
+	End       time.Time              `json:"end"`
+	Positions map[TCP]*PositionScore `json:"positions"`
+}
+
+// PositionScore holds the scoring metrics for a single control position.
+type PositionScore struct {
+	// From the handoff being offered to this position until it is accepted.
+	HandoffsAccepted     int          `json:"handoffs_accepted"`
+	HandoffAcceptLatency LatencyStats `json:"handoff_accept_latency"`
+
+	// Tower-initiated go-arounds for insufficient spacing on final for
+	// aircraft that were cleared for the approach by this position.
+	SpacingGoArounds int `json:"spacing_go_arounds"`
+	// Wake turbulence encounters by aircraft following others too
+	// closely, attributed in the same way.
+	WakeEncounters int `json:"wake_encounters"`
+
+	SeparationLosses int `json:"separation_losses"`
+	MSAWAlerts       int `json:"msaw_alerts"`
+
+	// From a departure appearing in the release list until this position
+	// released it.
+	ReleaseDelay LatencyStats `json:"release_delay"`
+
+	// Pilot-initiated transmissions and how long it took the controller to
+	// respond to them.
+	CheckIns           int          `json:"check_ins"`
+	UnansweredCheckIns int          `json:"unanswered_check_ins"`
+	CheckInResponse    LatencyStats `json:"check_in_response"`
+}
+
+// LatencyStats accumulates summary statistics for a series of delays.
+type LatencyStats struct {
+	Count       int     `json:"count"`
+	MeanSeconds float32 `json:"mean_seconds"`
+	MaxSeconds  float32 `json:"max_seconds"`
+}
+
+func (l *LatencyStats) Add(d time.Duration) {
+	sec := float32(d.Seconds())
+	l.Count++
+	l.MeanSeconds += (sec - l.MeanSeconds) / float32(l.Count)
+	l.MaxSeconds = max(l.MaxSeconds, sec)
+}
+
+// ScoreTracker maintains the SessionScore for a Sim, along with the state
+// needed to compute it from the sim's events.
+type ScoreTracker struct {
+	Score SessionScore
+
+	HandoffOffers   map[ACID]time.Time
+	CheckIns        map[av.ADSBCallsign]PendingCheckIn
+	ReleaseRequests map[av.ADSBCallsign]time.Time
+}
+
+type PendingCheckIn struct {
+	TCP  TCP
+	Time time.Time
+}
+
+func makeScoreTracker() *ScoreTracker {
+	return &ScoreTracker{
+		Score:           SessionScore{Positions: make(map[TCP]*PositionScore)},
+		HandoffOffers:   make(map[ACID]time.Time),
+		CheckIns:        make(map[av.ADSBCallsign]PendingCheckIn),
+		ReleaseRequests: make(map[av.ADSBCallsign]time.Time),
+	}
+}
+
+func (st *ScoreTracker) position(tcp TCP) *PositionScore {
+	ps, ok := st.Score.Positions[tcp]
+	if !ok {
+		ps = &PositionScore{}
+		st.Score.Positions[tcp] = ps
+	}
+	return ps
+}
+
+// GetSessionScore returns the current score for the sim.
+func (s *Sim) GetSessionScore() SessionScore {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	if s.Scoring == nil {
+		return SessionScore{}
+	}
+
+	score := s.Scoring.Score
+	score.Positions = make(map[TCP]*PositionScore)
+	for tcp, ps := range s.Scoring.Score.Positions {
+		psCopy := *ps
+		score.Positions[tcp] = &psCopy
+	}
+	return score
+}
+
+// updateScore processes the events posted since the last update and
+// updates the session score accordingly. Only human-allocated positions
+// are scored.
+func (s *Sim) updateScore() {
+	if s.scoreEvents == nil {
+		s.scoreEvents = s.eventStream.Subscribe()
+	}
+
+	events := s.scoreEvents.Get()
+	if s.prespawn {
+		return
+	}
+
+	st := s.Scoring
+	now := s.State.SimTime
+	if st.Score.Start.IsZero() {
+		st.Score.Start = now
+	}
+	st.Score.End = now
+
+	scored := func(tcp TCP) bool {
+		return tcp != "" && !s.isVirtualController(tcp)
+	}
+
+	for _, e := range events {
+		switch e.Type {
+		case OfferedHandoffEvent:
+			if e.ACID != "" {
+				st.HandoffOffers[e.ACID] = now
+			}
+
+		case AcceptedHandoffEvent, AcceptedRedirectedHandoffEvent:
+			if t, ok := st.HandoffOffers[e.ACID]; ok {
+				if scored(e.ToController) {
+					ps := st.position(e.ToController)
+					ps.HandoffsAccepted++
+					ps.HandoffAcceptLatency.Add(now.Sub(t))
+				}
+				delete(st.HandoffOffers, e.ACID)
+			}
+
+		case SpacingGoAroundEvent:
+			if scored(e.FromController) {
+				st.position(e.FromController).SpacingGoArounds++
+			}
+
+		case WakeEncounterEvent:
+			if scored(e.FromController) {
+				st.position(e.FromController).WakeEncounters++
+			}
+
+		case LossOfSeparationEvent:
+			if l := e.SeparationLoss; l != nil {
+				for i, tcp := range l.TCPs {
+					if scored(tcp) && (i == 0 || tcp != l.TCPs[0]) {
+						st.position(tcp).SeparationLosses++
+					}
+				}
+			}
+
+		case MSAWEvent:
+			if scored(e.ToController) {
+				st.position(e.ToController).MSAWAlerts++
+			}
+
+		case RadioTransmissionEvent:
+			if e.RadioTransmissionType == av.RadioTransmissionContact {
+				if _, ok := st.CheckIns[e.ADSBCallsign]; !ok && scored(e.ToController) {
+					st.position(e.ToController).CheckIns++
+					st.CheckIns[e.ADSBCallsign] = PendingCheckIn{TCP: e.ToController, Time: now}
+				}
+			} else if ci, ok := st.CheckIns[e.ADSBCallsign]; ok && e.Readback {
+				// Only a response to something the controller said counts;
+				// unsolicited transmissions (wake or TCAS reports, requests,
+				// etc.) don't.
+				st.position(ci.TCP).CheckInResponse.Add(now.Sub(ci.Time))
+				delete(st.CheckIns, e.ADSBCallsign)
+			}
+		}
+	}
+
+	// Check-ins that have gone unanswered for too long.
+	for callsign, ci := range util.SortedMap(st.CheckIns) {
+		if _, ok := s.Aircraft[callsign]; !ok {
+			delete(st.CheckIns, callsign)
+		} else if now.Sub(ci.Time) > checkInResponseTimeout {
+			st.position(ci.TCP).UnansweredCheckIns++
+			delete(st.CheckIns, callsign)
+		}
+	}
+
+	// Handoffs that were canceled or whose flight plans are gone.
+	for acid := range maps.Keys(st.HandoffOffers) {
+		if fp := s.STARSComputer.lookupFlightPlanByACID(acid); fp == nil || fp.HandoffController == "" {
+			delete(st.HandoffOffers, acid)
+		}
+	}
+
+	// Departure releases: note when aircraft first appear in the release
+	// list and then the delay until they're released.
+	for _, ac := range s.STARSComputer.HoldForRelease {
+		if _, ok := st.ReleaseRequests[ac.ADSBCallsign]; !ok && !ac.Released {
+			st.ReleaseRequests[ac.ADSBCallsign] = now
+		}
+	}
+	for callsign, t := range util.SortedMap(st.ReleaseRequests) {
+		ac, ok := s.Aircraft[callsign]
+		if !ok {
+			delete(st.ReleaseRequests, callsign)
+		} else if ac.Released {
+			if fp := s.STARSComputer.lookupFlightPlanByACID(ACID(callsign)); fp != nil &&
+				scored(fp.InboundHandoffController) {
+				st.position(fp.InboundHandoffController).ReleaseDelay.Add(ac.ReleaseTime.Sub(t))
+			}
+			delete(st.ReleaseRequests, callsign)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// sim/score_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"testing"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+)
+
+func TestUpdateScore(t *testing.T) {
+	s := makeTestSim(t, &Aircraft{ADSBCallsign: "AAL1"})
+	s.STARSComputer = makeSTARSComputer("TST")
+	s.Scoring = makeScoreTracker()
+	s.STARSComputer.FlightPlans = []*NASFlightPlan{{ACID: "AAL1", HandoffController: "2B"}}
+
+	step := func(d time.Duration, events ...Event) {
+		s.State.SimTime = s.State.SimTime.Add(d)
+		for _, e := range events {
+			s.eventStream.Post(e)
+		}
+		s.updateScore()
+	}
+
+	step(0) // subscribe to events
+	step(time.Second, Event{Type: OfferedHandoffEvent, ACID: "AAL1", FromController: "1A", ToController: "2B"})
+	step(10*time.Second, Event{Type: AcceptedHandoffEvent, ACID: "AAL1", FromController: "1A", ToController: "2B"})
+
+	contact := Event{Type: RadioTransmissionEvent, ADSBCallsign: "AAL1", ToController: "2B",
+		RadioTransmissionType: av.RadioTransmissionContact}
+	step(time.Second, contact)
+	// An unsolicited report from the pilot isn't a response.
+	step(2*time.Second, Event{Type: RadioTransmissionEvent, ADSBCallsign: "AAL1",
+		RadioTransmissionType: av.RadioTransmissionUnexpected})
+	step(2*time.Second, Event{Type: RadioTransmissionEvent, ADSBCallsign: "AAL1",
+		RadioTransmissionType: av.RadioTransmissionReadback, Readback: true})
+	// This one is never answered.
+	step(time.Second, contact)
+	step(checkInResponseTimeout + time.Second)
+
+	step(time.Second, Event{Type: MSAWEvent, ADSBCallsign: "AAL1", ACID: "AAL1", ToController: "2B"},
+		Event{Type: SpacingGoAroundEvent, ADSBCallsign: "AAL1", FromController: "2B"},
+		Event{Type: WakeEncounterEvent, ADSBCallsign: "AAL1", FromController: "2B"},
+		Event{Type: LossOfSeparationEvent, SeparationLoss: &SeparationLoss{TCPs: [2]TCP{"2B", "2B"}}})
+
+	ps := s.GetSessionScore().Positions["2B"]
+	if ps == nil {
+		t.Fatal("no score for 2B")
+	}
+	if ps.HandoffsAccepted != 1 || ps.HandoffAcceptLatency != (LatencyStats{Count: 1, MeanSeconds: 10, MaxSeconds: 10}) {
+		t.Errorf("unexpected handoff scoring: %+v", ps)
+	}
+	if ps.CheckIns != 2 || ps.UnansweredCheckIns != 1 ||
+		ps.CheckInResponse != (LatencyStats{Count: 1, MeanSeconds: 4, MaxSeconds: 4}) {
+		t.Errorf("unexpected check-in scoring: %+v", ps)
+	}
+	if ps.MSAWAlerts != 1 || ps.SpacingGoArounds != 1 || ps.WakeEncounters != 1 || ps.SeparationLosses != 1 {
+		t.Errorf("unexpected alert scoring: %+v", ps)
+	}
+}
@@ -0,0 +1,245 @@
+// sim/separation.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/math"
+	"github.com/mmp/vice/util"
+)
+
+const (
+	// Terminal radar separation minima, 7110.65 5-5-4: 3nm when within
+	// 40nm of the radar site, 5nm beyond that, and 1000' vertically.
+	separationLateralMinimum         = 3
+	separationLateralMinimumExtended = 5
+	separationExtendedRange          = 40
+	separationVerticalMinimum        = 1000
+)
+
+// SeparationLoss records a loss of separation between two IFR aircraft.
+// A LossOfSeparationEvent is posted when the loss starts. While it is
+// ongoing it is held in Sim.SeparationLosses; once separation is regained,
+// a SeparationRegainedEvent is posted with the final values.
+type SeparationLoss struct {
+	ADSBCallsigns [2]av.ADSBCallsign `json:"callsigns"`
+	// TCPs are the positions tracking the respective aircraft, as of the
+	// most recent update.
+	TCPs [2]TCP `json:"tcps"`
+
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+
+	// RequiredLateral is the lateral separation (nm) that applied when the
+	// aircraft were closest; it accounts for CWT wake separation.
+	RequiredLateral float32 `json:"required_lateral"`
+	// ClosestLateral and ClosestVertical (feet) are the separation at the
+	// point of closest lateral approach.
+	ClosestLateral  float32   `json:"closest_lateral"`
+	ClosestVertical float32   `json:"closest_vertical"`
+	ClosestTime     time.Time `json:"closest_time"`
+}
+
+func (l *SeparationLoss) Duration() time.Duration {
+	return l.End.Sub(l.Start)
+}
+
+// Ongoing returns true if separation hasn't yet been regained.
+func (l *SeparationLoss) Ongoing() bool {
+	return l.End.IsZero()
+}
+
+func (l *SeparationLoss) String() string {
+	tcp := func(i int) string {
+		return util.Select(l.TCPs[i] != "", string(l.TCPs[i]), "untracked")
+	}
+	if l.Ongoing() {
+		return fmt.Sprintf("Loss of separation: %s (%s) and %s (%s), %.1fnm/%dft (%.1fnm required)",
+			l.ADSBCallsigns[0], tcp(0), l.ADSBCallsigns[1], tcp(1), l.ClosestLateral,
+			int(l.ClosestVertical+0.5), l.RequiredLateral)
+	}
+	return fmt.Sprintf("Loss of separation: %s (%s) and %s (%s), closest %.1fnm/%dft (%.1fnm required), lasted %s",
+		l.ADSBCallsigns[0], tcp(0), l.ADSBCallsigns[1], tcp(1), l.ClosestLateral,
+		int(l.ClosestVertical+0.5), l.RequiredLateral, l.Duration())
+}
+
+// checkSeparation looks for pairs of IFR aircraft that are closer than
+// the applicable separation minima, tracking each loss of separation from
+// when it starts until the aircraft are separated again.
+func (s *Sim) checkSeparation() {
+	var aircraft []*Aircraft
+	for _, ac := range util.SortedMap(s.Aircraft) {
+		if s.separationMonitored(ac) {
+			aircraft = append(aircraft, ac)
+		}
+	}
+
+	now := s.State.SimTime
+	current := make(map[[2]av.ADSBCallsign]bool)
+	for i, a := range aircraft {
+		for _, b := range aircraft[i+1:] {
+			req, lost := s.separationLost(a, b)
+			if !lost {
+				continue
+			}
+
+			callsigns := [2]av.ADSBCallsign{a.ADSBCallsign, b.ADSBCallsign}
+			current[callsigns] = true
+
+			lateral := math.NMDistance2LL(a.Position(), b.Position())
+			vertical := math.Abs(a.Altitude() - b.Altitude())
+
+			idx := slices.IndexFunc(s.SeparationLosses, func(l *SeparationLoss) bool {
+				return l.ADSBCallsigns == callsigns
+			})
+			var loss *SeparationLoss
+			if idx == -1 {
+				loss = &SeparationLoss{ADSBCallsigns: callsigns, Start: now}
+				s.SeparationLosses = append(s.SeparationLosses, loss)
+			} else {
+				loss = s.SeparationLosses[idx]
+			}
+
+			loss.TCPs = [2]TCP{separationTCP(a), separationTCP(b)}
+			if idx == -1 || lateral < loss.ClosestLateral {
+				loss.ClosestLateral = lateral
+				loss.ClosestVertical = vertical
+				loss.ClosestTime = now
+				loss.RequiredLateral = req
+			}
+
+			if idx == -1 {
+				// Alert right away; the event gets a copy since the loss
+				// continues to be updated.
+				start := *loss
+				s.eventStream.Post(Event{
+					Type:           LossOfSeparationEvent,
+					ADSBCallsign:   callsigns[0],
+					WrittenText:    start.String(),
+					SeparationLoss: &start,
+				})
+			}
+		}
+	}
+
+	// Anything that wasn't seen this time around has been resolved (or
+	// one of the aircraft is gone).
+	s.SeparationLosses = slices.DeleteFunc(s.SeparationLosses, func(l *SeparationLoss) bool {
+		if current[l.ADSBCallsigns] {
+			return false
+		}
+		l.End = now
+		s.lg.Info("loss of separation", slog.Any("aircraft", l.ADSBCallsigns),
+			slog.Float64("closest_lateral", float64(l.ClosestLateral)),
+			slog.Float64("closest_vertical", float64(l.ClosestVertical)),
+			slog.Duration("duration", l.Duration()))
+		s.eventStream.Post(Event{
+			Type:           SeparationRegainedEvent,
+			ADSBCallsign:   l.ADSBCallsigns[0],
+			WrittenText:    l.String(),
+			SeparationLoss: l,
+		})
+		return true
+	})
+}
+
+// separationMonitored returns true if the aircraft is one that the
+// separation monitor should consider: an airborne IFR aircraft with an
+// associated flight plan and a mode C altitude.
+func (s *Sim) separationMonitored(ac *Aircraft) bool {
+	return ac.FlightPlan.Rules == av.FlightRulesIFR && ac.IsAssociated() && ac.IsAirborne() &&
+		!ac.WaitingForLaunch && ac.Mode == av.TransponderModeAltitude
+}
+
+func separationTCP(ac *Aircraft) TCP {
+	if ac.NASFlightPlan == nil {
+		return ""
+	}
+	return ac.NASFlightPlan.TrackingController
+}
+
+// separationLost returns the required lateral separation between the two
+// aircraft and whether they are currently in violation of it.
+func (s *Sim) separationLost(a, b *Aircraft) (float32, bool) {
+	vertical := math.Abs(a.Altitude() - b.Altitude())
+	if vertical >= separationVerticalMinimum-5 /* slop for fp error */ {
+		return 0, false
+	}
+
+	lateral := math.NMDistance2LL(a.Position(), b.Position())
+	if lateral > 10 {
+		// Quick out; nothing below requires more than this.
+		return 0, false
+	}
+
+	// The same areas where STARS inhibits conflict alerts (generally,
+	// close in to airports) are excluded; separation there is the
+	// tower's business.
+	inhibitCA := s.State.FacilityAdaptation.Filters.InhibitCA
+	if inhibitCA.Inside(a.Position(), int(a.Altitude())) || inhibitCA.Inside(b.Position(), int(b.Altitude())) {
+		return 0, false
+	}
+
+	// Aircraft established on approaches to different runways are
+	// separated by the approach procedures themselves.
+	aa, ba := a.Nav.Approach.Assigned, b.Nav.Approach.Assigned
+	if aa != nil && ba != nil && a.OnApproach(false) && b.OnApproach(false) &&
+		(a.FlightPlan.ArrivalAirport != b.FlightPlan.ArrivalAirport || aa.Runway != ba.Runway) {
+		return 0, false
+	}
+
+	req := float32(separationLateralMinimum)
+	if math.NMDistance2LL(a.Position(), s.State.Center) > separationExtendedRange ||
+		math.NMDistance2LL(b.Position(), s.State.Center) > separationExtendedRange {
+		req = separationLateralMinimumExtended
+	}
+
+	if front, trailing, ok := s.inTrail(a, b); ok {
+		fcwt, tcwt := front.CWT(), trailing.CWT()
+		if len(fcwt) == 1 && len(tcwt) == 1 { // skip NOWGT
+			if aa != nil && ba != nil && aa.Runway == ba.Runway && a.OnApproach(false) && b.OnApproach(false) {
+				vol := trailing.ATPAVolume()
+				eligible25nm := vol != nil && vol.Enable25nmApproach &&
+					s.State.IsATPAVolume25nmEnabled(vol.Id) &&
+					trailing.OnExtendedCenterline(0.2) && front.OnExtendedCenterline(0.2)
+				req = av.CWTRequiredApproachSeparation(fcwt, tcwt, eligible25nm)
+			} else {
+				req = max(req, av.CWTDirectlyBehindSeparation(fcwt, tcwt))
+			}
+		}
+	}
+
+	return req, lateral < req
+}
+
+// inTrail determines whether one of the two aircraft is following the
+// other such that wake turbulence separation applies: they are on
+// similar headings and the trailing one is behind and less than 1000'
+// below the leader. (7110.65 5-5-4)
+func (s *Sim) inTrail(a, b *Aircraft) (front, trailing *Aircraft, ok bool) {
+	if math.HeadingDifference(a.Heading(), b.Heading()) > 45 {
+		return nil, nil, false
+	}
+
+	behind := func(front, trailing *Aircraft) bool {
+		if trailing.Altitude() > front.Altitude()+100 || front.Altitude()-trailing.Altitude() >= separationVerticalMinimum {
+			return false
+		}
+		hdg := math.Heading2LL(trailing.Position(), front.Position(), s.State.NmPerLongitude, s.State.MagneticVariation)
+		return math.HeadingDifference(hdg, trailing.Heading()) < 45
+	}
+
+	if behind(a, b) {
+		return a, b, true
+	} else if behind(b, a) {
+		return b, a, true
+	}
+	return nil, nil, false
+}
@@ -0,0 +1,94 @@
+// sim/separation_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"testing"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/math"
+)
+
+func TestCheckSeparation(t *testing.T) {
+	makeAircraft := func(callsign av.ADSBCallsign, tcp TCP, east float32, alt float32) *Aircraft {
+		ac := makeTestAircraft(callsign, testPoint(east, 0), alt, 360, 250)
+		ac.NASFlightPlan = &NASFlightPlan{ACID: ACID(callsign), TrackingController: tcp}
+		return ac
+	}
+
+	a := makeAircraft("AAL1", "1A", 0, 8000)
+	b := makeAircraft("JBU2", "2B", 2, 8500)
+	s := makeTestSim(t, a, b)
+	start := s.State.SimTime
+
+	sub := s.eventStream.Subscribe()
+
+	step := func() {
+		s.State.SimTime = s.State.SimTime.Add(time.Second)
+		s.checkSeparation()
+	}
+
+	step()
+	if len(s.SeparationLosses) != 1 {
+		t.Fatalf("expected a loss of separation, got %d", len(s.SeparationLosses))
+	}
+	// The alert is posted as soon as separation is lost.
+	if events := sub.Get(); len(events) != 1 || events[0].Type != LossOfSeparationEvent ||
+		!events[0].SeparationLoss.Ongoing() {
+		t.Fatalf("expected a LossOfSeparationEvent for the start of the loss, got %v", events)
+	}
+
+	// Get closer...
+	b.Nav.FlightState.Position = testPoint(1.5, 0)
+	step()
+	// Then climb to get vertical separation.
+	b.Nav.FlightState.Altitude = 9000
+	step()
+
+	if len(s.SeparationLosses) != 0 {
+		t.Errorf("expected loss of separation to be resolved")
+	}
+
+	events := sub.Get()
+	if len(events) != 1 || events[0].Type != SeparationRegainedEvent {
+		t.Fatalf("expected a single SeparationRegainedEvent, got %v", events)
+	}
+	l := events[0].SeparationLoss
+	if l.ADSBCallsigns != [2]av.ADSBCallsign{"AAL1", "JBU2"} || l.TCPs != [2]TCP{"1A", "2B"} {
+		t.Errorf("unexpected aircraft or TCPs: %+v", l)
+	}
+	if l.Duration() != 2*time.Second {
+		t.Errorf("expected 2s duration, got %s", l.Duration())
+	}
+	if !l.ClosestTime.Equal(start.Add(2*time.Second)) || math.Abs(l.ClosestLateral-1.5) > 0.05 ||
+		l.ClosestVertical != 500 || l.RequiredLateral != 3 {
+		t.Errorf("unexpected closest approach: %+v", l)
+	}
+}
+
+func TestReportOngoingSeparationLoss(t *testing.T) {
+	s := makeTestSim(t)
+	start := s.State.SimTime
+
+	r := NewSessionReporter(s, "", 0)
+	s.SeparationLosses = []*SeparationLoss{{
+		ADSBCallsigns: [2]av.ADSBCallsign{"AAL1", "JBU2"},
+		Start:         start,
+	}}
+	s.State.SimTime = start.Add(30 * time.Second)
+	r.Update()
+
+	rep := r.Report()
+	if len(rep.SeparationLosses) != 1 {
+		t.Fatalf("expected the ongoing loss of separation in the report, got %+v", rep.SeparationLosses)
+	}
+	if d := rep.SeparationLosses[0].Duration(); d != 30*time.Second {
+		t.Errorf("expected 30s duration, got %s", d)
+	}
+	if !s.SeparationLosses[0].Ongoing() {
+		t.Errorf("reporting modified the sim's ongoing loss of separation")
+	}
+}
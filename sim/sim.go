@@ -57,6 +57,8 @@ type Sim struct {
 	METAR      map[string][]wx.METAR
 
 	ATISChangedTime map[string]time.Time
+	// NOTAM-style remarks to include in each airport's ATIS broadcast.
+	ATISRemarks map[string][]string
 
 	eventStream *EventStream
 	lg          *log.Logger
@@ -87,6 +89,22 @@ type Sim struct {
 
 	NextEmergencyTime time.Time
 
+	// When the tailwind on an active runway first exceeded the limit, or
+	// the zero time if it currently doesn't; see checkRunwayTailwinds().
+	ExcessTailwindStart time.Time
+	// RunwaysChanged is set once the runway configuration has changed
+	// from the scenario's.
+	RunwaysChanged bool
+
+	// Ongoing losses of separation; see checkSeparation().
+	SeparationLosses []*SeparationLoss
+
+	// Controller performance metrics; see updateScore().
+	Scoring     *ScoreTracker
+	scoreEvents *EventsSubscription
+
+	checkpoints []*checkpoint // see SaveCheckpoint()
+
 	PilotErrorInterval time.Duration
 	LastPilotError     time.Time
 
@@ -98,6 +116,8 @@ type Sim struct {
 
 	lastControlCommandTime time.Time
 
+	nextConflictProbe time.Time
+
 	prespawn                 bool
 	prespawnUncontrolledOnly bool
 
@@ -162,6 +182,8 @@ type Track struct {
 	ATPAVolume                *av.ATPAVolume
 	MVAsApply                 bool
 	HoldForRelease            bool
+	HoldFix                   string // Fix the aircraft is holding at, if any
+	Coasting                  bool   // No radar returns; the position is extrapolated
 	MissingFlightPlan         bool
 	Route                     []math.Point2LL
 	IsTentative               bool   // first 5 seconds after first contact
@@ -213,14 +235,18 @@ type NewSimConfiguration struct {
 	Facility    string
 	Description string
 
-	Airports           map[string]*av.Airport
-	PrimaryAirport     string
-	DepartureRunways   []DepartureRunway
-	ArrivalRunways     []ArrivalRunway
-	InboundFlows       map[string]*av.InboundFlow
-	LaunchConfig       LaunchConfig
-	Fixes              map[string]math.Point2LL
-	VFRReportingPoints []av.VFRReportingPoint
+	Airports         map[string]*av.Airport
+	PrimaryAirport   string
+	DepartureRunways []DepartureRunway
+	ArrivalRunways   []ArrivalRunway
+	InboundFlows     map[string]*av.InboundFlow
+	// RunwayConfigurations gives the runway configurations that the sim
+	// may switch to; RunwayConfiguration is the name of the initial one.
+	RunwayConfigurations []RunwayConfiguration
+	RunwayConfiguration  string
+	LaunchConfig         LaunchConfig
+	Fixes                map[string]math.Point2LL
+	VFRReportingPoints   []av.VFRReportingPoint
 
 	ControlPositions        map[TCP]*av.Controller
 	ControllerAirspace      map[TCP][]string
@@ -237,6 +263,8 @@ type NewSimConfiguration struct {
 	NmPerLongitude    float32
 	StartTime         time.Time
 	WindSpecifier     *wx.WindSpecifier
+	ScriptedWeather   *wx.ScriptedWeather
+	ATISRemarks       map[string][]string // airport -> remarks
 	Center            math.Point2LL
 	Range             float32
 	DefaultMaps       []string
@@ -252,9 +280,23 @@ type NewSimConfiguration struct {
 	HandoffIDs         []HandoffID
 	FixPairs           []FixPairDefinition
 	FixPairAssignments []FixPairAssignment
+
+	// Seed, if non-zero, is used to seed the sim's random number
+	// generator so that headless runs of a scenario are repeatable.
+	Seed uint64
 }
 
 func NewSim(config NewSimConfiguration, manifest *VideoMapManifest, lg *log.Logger) *Sim {
+	if config.ScriptedWeather != nil {
+		// Scripted weather takes the place of the real-world weather.
+		origin := config.Center
+		if ap, ok := config.Airports[config.PrimaryAirport]; ok {
+			origin = ap.Location
+		}
+		config.ScriptedWeather = config.ScriptedWeather.Anchor(config.StartTime, origin)
+		config.WXProvider = wx.MakeScriptedProvider(config.ScriptedWeather)
+	}
+
 	s := &Sim{
 		Aircraft: make(map[av.ADSBCallsign]*Aircraft),
 
@@ -274,14 +316,17 @@ func NewSim(config NewSimConfiguration, manifest *VideoMapManifest, lg *log.Logg
 
 		VFRReportingPoints: config.VFRReportingPoints,
 
-		wxModel: wx.MakeModel(config.WXProvider, config.Facility, config.PrimaryAirport, config.StartTime.UTC(), lg),
-		METAR:   make(map[string][]wx.METAR),
+		wxModel: wx.MakeModel(config.WXProvider, config.Facility, config.PrimaryAirport, config.StartTime.UTC(),
+			config.LaunchConfig.WeatherDeviations, lg),
+		METAR: make(map[string][]wx.METAR),
 
 		ATISChangedTime: make(map[string]time.Time),
 
 		eventStream: NewEventStream(lg),
 		lg:          lg,
 
+		Scoring: makeScoreTracker(),
+
 		ReportingPoints: config.ReportingPoints,
 
 		EnforceUniqueCallsignSuffix: config.EnforceUniqueCallsignSuffix,
@@ -316,11 +361,19 @@ func NewSim(config NewSimConfiguration, manifest *VideoMapManifest, lg *log.Logg
 		}(),
 	}
 
-	s.VoiceAssigner = NewVoiceAssigner(s.Rand)
+	if config.Seed != 0 {
+		s.Rand.Seed(config.Seed)
+	}
+	s.VoiceAssigner = NewVoiceAssigner()
 
-	// Load METAR data from local resources
-	apmetar, err := wx.GetMETAR(slices.Collect(maps.Keys(config.Airports)))
-	if err != nil {
+	// Load METAR data, either synthesized from the scripted weather or
+	// from local resources
+	if sw := config.ScriptedWeather; sw != nil {
+		for ap, airport := range config.Airports {
+			s.METAR[ap] = sw.METAR(ap, airport.Location)
+			s.ATISChangedTime[ap] = s.METAR[ap][0].Time
+		}
+	} else if apmetar, err := wx.GetMETAR(slices.Collect(maps.Keys(config.Airports))); err != nil {
 		lg.Errorf("%v", err)
 	} else {
 		for ap, msoa := range apmetar {
@@ -387,6 +440,11 @@ func NewSim(config NewSimConfiguration, manifest *VideoMapManifest, lg *log.Logg
 	s.State = newCommonState(config, config.StartTime.UTC(), manifest, s.wxModel, s.METAR, s.Rand, lg)
 	s.ScenarioDefaultConsolidation = config.ControllerConfiguration.DefaultConsolidation
 
+	s.ATISRemarks = config.ATISRemarks
+	for ap := range util.SortedMap(s.State.ATISLetter) {
+		s.updateATIS(ap)
+	}
+
 	return s
 }
 
@@ -481,6 +539,9 @@ func (s *Sim) Activate(lg *log.Logger, provider wx.Provider) {
 	if s.eventStream == nil {
 		s.eventStream = NewEventStream(lg)
 	}
+	if s.Scoring == nil {
+		s.Scoring = makeScoreTracker()
+	}
 
 	now := time.Now()
 	s.lastUpdateTime = now
@@ -490,14 +551,28 @@ func (s *Sim) Activate(lg *log.Logger, provider wx.Provider) {
 		s.Rand = rand.Make()
 	}
 
+	if sw := s.State.ScriptedWeather; sw != nil {
+		provider = wx.MakeScriptedProvider(sw)
+	}
 	s.wxProvider = provider
 	if s.wxModel == nil {
-		s.wxModel = wx.MakeModel(provider, s.State.Facility, s.State.PrimaryAirport, s.State.SimTime, s.lg)
+		s.wxModel = wx.MakeModel(provider, s.State.Facility, s.State.PrimaryAirport, s.State.SimTime,
+			s.State.LaunchConfig.WeatherDeviations, s.lg)
 	}
 
 	// Restore json:"-" fields that are lost during JSON config save/load.
 	restoreControllerFields(s.ControlPositions)
 	restoreControllerFields(s.State.Controllers)
+
+	// Serialization doesn't preserve pointer aliasing, so make sure that
+	// the departures held for release are the aircraft in s.Aircraft.
+	if s.STARSComputer != nil {
+		for i, ac := range s.STARSComputer.HoldForRelease {
+			if sac, ok := s.Aircraft[ac.ADSBCallsign]; ok {
+				s.STARSComputer.HoldForRelease[i] = sac
+			}
+		}
+	}
 }
 
 // restoreControllerFields reconstructs the json:"-" fields
@@ -588,6 +663,28 @@ func (s *Sim) FastForward() {
 	s.lastControlCommandTime = time.Now()
 }
 
+// Advance runs the sim forward by the given amount of time, regardless of
+// whether it is paused and independently of the wallclock. It is used to
+// drive replays of recorded sessions.
+func (s *Sim) Advance(d time.Duration) {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	for range int(d / time.Second) {
+		s.State.SimTime = s.State.SimTime.Add(time.Second)
+		s.updateState()
+	}
+	s.lastUpdateTime = time.Now()
+}
+
+// RunState returns whether the sim is paused and the current sim rate.
+func (s *Sim) RunState() (paused bool, rate float32) {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	return s.State.Paused || s.pausedByServer, s.State.SimRate
+}
+
 func (s *Sim) IdleTime() time.Duration {
 	s.mu.Lock(s.lg)
 	defer s.mu.Unlock(s.lg)
@@ -994,8 +1091,11 @@ func (s *Sim) GetTrafficCounts() (ifr, vfr int) {
 // prepareRadioTransmissions adds callsign/controller prefixes to radio transmissions.
 // (Multi-command batching is now handled at intent generation time in RunAircraftControlCommands.)
 // This is called for both main event subscriptions and TTS event subscriptions.
+// Those are fetched independently of the sim's updates, so the random choices
+// here come from r rather than s.Rand in order to not perturb the sim's
+// random number sequence (and thus the replay of recorded sessions).
 // Must be called with s.mu held.
-func (s *Sim) prepareRadioTransmissions(tcw TCW, events []Event) []Event {
+func (s *Sim) prepareRadioTransmissions(tcw TCW, events []Event, r *rand.Rand) []Event {
 	primaryTCP := s.State.PrimaryPositionForTCW(tcw)
 	ctrl := s.State.Controllers[primaryTCP]
 
@@ -1023,7 +1123,7 @@ func (s *Sim) prepareRadioTransmissions(tcw TCW, events []Event) []Event {
 		case av.RadioTransmissionContact:
 			// For emergency aircraft, 50% of the time add "emergency aircraft" after heavy/super.
 			// Only on initial contact, not subsequent transmissions.
-			if ac.EmergencyState != nil && s.Rand.Bool() {
+			if ac.EmergencyState != nil && r.Bool() {
 				heavySuper += " emergency aircraft"
 			}
 			csArg := av.CallsignArg{
@@ -1037,8 +1137,8 @@ func (s *Sim) prepareRadioTransmissions(tcw TCW, events []Event) []Event {
 			} else {
 				tr = av.MakeContactTransmission("{actrl}, {callsign}"+heavySuper+". ", ctrl, csArg)
 			}
-			events[i].WrittenText = tr.Written(s.Rand) + e.WrittenText
-			events[i].SpokenText = tr.Spoken(s.Rand) + e.SpokenText
+			events[i].WrittenText = tr.Written(r) + e.WrittenText
+			events[i].SpokenText = tr.Spoken(r) + e.SpokenText
 		case av.RadioTransmissionMixUp:
 			// No additional formatting for mix-up transmissions; the callsign is already in there.
 		case av.RadioTransmissionNoId:
@@ -1049,8 +1149,8 @@ func (s *Sim) prepareRadioTransmissions(tcw TCW, events []Event) []Event {
 				IsEmergency: ac.EmergencyState != nil,
 			}
 			tr := av.MakeReadbackTransmission(", {callsign}"+heavySuper+". ", csArg)
-			events[i].WrittenText = e.WrittenText + tr.Written(s.Rand)
-			events[i].SpokenText = e.SpokenText + tr.Spoken(s.Rand)
+			events[i].WrittenText = e.WrittenText + tr.Written(r)
+			events[i].SpokenText = e.SpokenText + tr.Spoken(r)
 		}
 	}
 
@@ -1064,10 +1164,53 @@ func (s *Sim) PrepareRadioTransmissionsForTCW(tcw TCW, events []Event) []Event {
 	s.mu.Lock(s.lg)
 	defer s.mu.Unlock(s.lg)
 
-	return s.prepareRadioTransmissions(tcw, events)
+	return s.prepareRadioTransmissions(tcw, events, rand.Make())
 }
 
-func (s *Sim) GetStateUpdate(tcw TCW) StateUpdate {
+// PrepareAllRadioTransmissions is like PrepareRadioTransmissionsForTCW,
+// but each radio transmission is prepared as it would be for the TCW it's
+// destined for. It's used for the event feed, which shows the
+// transmissions to all of the controllers.
+func (s *Sim) PrepareAllRadioTransmissions(events []Event) []Event {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	r := rand.Make()
+	tcws := make(map[TCW]struct{})
+	for _, e := range events {
+		if e.Type == RadioTransmissionEvent {
+			tcws[e.DestinationTCW] = struct{}{}
+		}
+	}
+	for tcw := range util.SortedMap(tcws) {
+		if _, ok := s.State.Controllers[s.State.PrimaryPositionForTCW(tcw)]; ok {
+			events = s.prepareRadioTransmissions(tcw, events, r)
+		}
+	}
+	return events
+}
+
+// GetTracks returns the current sim time and the radar tracks sorted by
+// callsign. Unlike GetStateUpdate, it doesn't assemble the rest of the
+// state, so it's cheap enough to call frequently.
+func (s *Sim) GetTracks() (time.Time, []*Track) {
+	s.mu.Lock(s.lg)
+	defer s.mu.Unlock(s.lg)
+
+	var tracks []*Track
+	for _, trk := range util.SortedMap(s.makeTracks()) {
+		tracks = append(tracks, trk)
+	}
+
+	// As in GetStateUpdate, copy the tracks so that they don't share
+	// anything with aircraft that may be updated once we return.
+	return s.State.SimTime, deep.MustCopy(tracks)
+}
+
+// GetStateUpdate returns the state update for the given TCW's client.
+// atisGeneration is the ATISGeneration that the client last received; the
+// ATIS is only included if it has changed since then.
+func (s *Sim) GetStateUpdate(tcw TCW, atisGeneration int) StateUpdate {
 	s.mu.Lock(s.lg)
 	defer s.mu.Unlock(s.lg)
 
@@ -1078,6 +1221,9 @@ func (s *Sim) GetStateUpdate(tcw TCW) StateUpdate {
 		DerivedState:     makeDerivedState(s),
 		FlightStripACIDs: s.flightStripACIDsForTCW(tcw),
 	}
+	if atisGeneration != s.State.ATISGeneration {
+		update.ATIS = &s.State.ATISState
+	}
 
 	if util.SizeOf(update, os.Stderr, false, 1024*1024) > 256*1024*1024 {
 		fn := fmt.Sprintf("update_dump%d.txt", time.Now().Unix())
@@ -1188,7 +1334,7 @@ func (s *Sim) Step(elapsed time.Duration) bool {
 func (s *Sim) updateState() {
 	now := s.State.SimTime
 
-	for acid, ho := range s.Handoffs {
+	for acid, ho := range util.SortedMap(s.Handoffs) {
 		if !now.After(ho.AutoAcceptTime) && !s.prespawn {
 			continue
 		}
@@ -1228,7 +1374,7 @@ func (s *Sim) updateState() {
 		delete(s.Handoffs, acid)
 	}
 
-	for acid, po := range s.PointOuts {
+	for acid, po := range util.SortedMap(s.PointOuts) {
 		if !now.After(po.AcceptTime) {
 			continue
 		}
@@ -1255,7 +1401,9 @@ func (s *Sim) updateState() {
 	if now.Sub(s.lastSimUpdate) >= time.Second {
 		s.lastSimUpdate = now
 
-		for callsign, ac := range s.Aircraft {
+		// Go through the aircraft in a consistent order so that runs are
+		// repeatable.
+		for callsign, ac := range util.SortedMap(s.Aircraft) {
 			if ac.HoldForRelease && !ac.Released {
 				// nvm...
 				continue
@@ -1281,7 +1429,7 @@ func (s *Sim) updateState() {
 			}
 
 			if passedWaypoint != nil {
-				for tcp, wpCommands := range s.waypointCommands {
+				for tcp, wpCommands := range util.SortedMap(s.waypointCommands) {
 					if cmds, ok := wpCommands[passedWaypoint.Fix]; ok {
 						// Moderately hacky: the mutex is held when we get here, but then RunAircraftControlCommands
 						// will end up calling methods like Sim AssignAltitude that in turn need to acquire the mutex.
@@ -1465,6 +1613,10 @@ func (s *Sim) updateState() {
 							}
 						}
 
+						s.eventStream.Post(Event{
+							Type:         AircraftLandedEvent,
+							ADSBCallsign: ac.ADSBCallsign,
+						})
 						s.deleteAircraft(ac)
 					} else {
 						s.goAround(ac)
@@ -1546,13 +1698,28 @@ func (s *Sim) updateState() {
 		// Check for spacing violations on final approach
 		s.checkFinalApproachSpacing()
 
+		if !s.prespawn {
+			s.checkSeparation()
+			s.checkFinalApproachWake()
+			s.checkAirborneWake()
+			s.checkTCAS()
+			s.updateWXDeviations()
+			s.checkWeatherRequests()
+			s.updatePilotRequests()
+			s.updateNORDO()
+			s.updateConflictProbe()
+			s.checkMSAW()
+		}
+		s.updateScore()
+
 		s.spawnAircraft()
 
+		s.updateCoastingTracks()
 		s.ERAMComputer.Update(s)
 		s.STARSComputer.Update(s)
 
 		// Advance METAR: drop old entries when sim time passes the next one's report time
-		for ap, metar := range s.METAR {
+		for ap, metar := range util.SortedMap(s.METAR) {
 			for len(metar) > 1 && s.State.SimTime.After(metar[1].Time) {
 				metar = metar[1:]
 			}
@@ -1562,15 +1729,33 @@ func (s *Sim) updateState() {
 					s.State.METAR = make(map[string]wx.METAR)
 				}
 				old := s.State.METAR[ap]
+				s.State.METAR[ap] = metar[0]
 				if old.Raw != "" && old.Raw != metar[0].Raw {
-					if cur, ok := s.State.ATISLetter[ap]; ok {
-						s.State.ATISLetter[ap] = string(rune((cur[0]-'A'+1)%26 + 'A'))
-						s.ATISChangedTime[ap] = s.State.SimTime
-					}
+					s.advanceATIS(ap)
 				}
-				s.State.METAR[ap] = metar[0]
 			}
 		}
+
+		if !s.prespawn {
+			s.checkRunwayTailwinds()
+		}
+	}
+}
+
+// advanceATIS moves the airport's ATIS to the next letter and generates
+// the new broadcast.
+func (s *Sim) advanceATIS(ap string) {
+	if cur, ok := s.State.ATISLetter[ap]; ok {
+		s.State.ATISLetter[ap] = string(rune((cur[0]-'A'+1)%26 + 'A'))
+		s.ATISChangedTime[ap] = s.State.SimTime
+		s.updateATIS(ap)
+
+		if atis, ok := s.State.ATIS[ap]; ok && !s.prespawn {
+			s.eventStream.Post(Event{
+				Type:        StatusMessageEvent,
+				WrittenText: "ATIS: " + atis.Text,
+			})
+		}
 	}
 }
 
@@ -1595,8 +1780,9 @@ func (s *Sim) TriggerEmergency(name string) {
 
 func (s *Sim) requestRandomFlightFollowing() error {
 	candidates := make(map[*Aircraft]TCP)
+	var candidateAircraft []*Aircraft // in a consistent order, for sampling
 
-	for _, ac := range s.Aircraft {
+	for _, ac := range util.SortedMap(s.Aircraft) {
 		if ac.IsAssociated() || ac.FlightPlan.Rules != av.FlightRulesVFR || ac.RequestedFlightFollowing || !ac.IsAirborne() {
 			continue
 		}
@@ -1614,14 +1800,15 @@ func (s *Sim) requestRandomFlightFollowing() error {
 			continue
 		}
 
-		for tcpStr, cc := range s.State.FacilityAdaptation.ControllerConfigs {
+		for tcpStr, cc := range util.SortedMap(s.State.FacilityAdaptation.ControllerConfigs) {
 			tcp := s.State.ResolveController(TCP(tcpStr))
 			if s.isVirtualController(tcp) {
 				continue
 			}
 			for _, vol := range cc.FlightFollowingAirspace {
-				if vol.Inside(ac.Position(), int(ac.Altitude())) {
+				if _, ok := candidates[ac]; !ok && vol.Inside(ac.Position(), int(ac.Altitude())) {
 					candidates[ac] = tcp // first come, first served
+					candidateAircraft = append(candidateAircraft, ac)
 					break
 				}
 			}
@@ -1632,7 +1819,7 @@ func (s *Sim) requestRandomFlightFollowing() error {
 		return ErrNoVFRAircraftForFlightFollowing
 	}
 
-	ac, _ := rand.SampleSeq(s.Rand, maps.Keys(candidates))
+	ac := rand.SampleSlice(s.Rand, candidateAircraft)
 
 	s.requestFlightFollowing(ac, candidates[ac])
 
@@ -1904,27 +2091,13 @@ func (s *Sim) getGoAroundProcedureForAircraft(ac *Aircraft) *GoAroundProcedure {
 // checkFinalApproachSpacing checks for spacing violations between IFR aircraft
 // on the same final approach and triggers go-arounds when separation is insufficient.
 func (s *Sim) checkFinalApproachSpacing() {
-	type runwayKey struct{ airport, runway string }
-	aircraftByRunway := make(map[runwayKey][]*Aircraft)
-
-	// Group IFR aircraft with assigned approaches by airport+runway
-	for _, ac := range s.Aircraft {
-		// Only tower sends aircraft around; don't include ones that have already been sent around
-		// since presumably we'll have vertical separation soon if not already.
-		if ac.Nav.Approach.Assigned != nil && ac.GotContactTower && !ac.SentAroundForSpacing {
-			key := runwayKey{ac.FlightPlan.ArrivalAirport, ac.Nav.Approach.Assigned.Runway}
-			aircraftByRunway[key] = append(aircraftByRunway[key], ac)
-		}
-	}
-
-	for _, aircraft := range aircraftByRunway {
-		// Sort by distance to threshold (closest first)
-		threshold := aircraft[0].Nav.Approach.Assigned.Threshold
-		slices.SortFunc(aircraft, func(a, b *Aircraft) int {
-			return cmp.Compare(math.NMDistance2LL(a.Position(), threshold),
-				math.NMDistance2LL(b.Position(), threshold))
-		})
+	// Only tower sends aircraft around; don't include ones that have already been sent around
+	// since presumably we'll have vertical separation soon if not already.
+	sequences := s.finalApproachSequences(func(ac *Aircraft) bool {
+		return ac.GotContactTower && !ac.SentAroundForSpacing
+	})
 
+	for _, aircraft := range sequences {
 		// Check each adjacent pair
 		for i := 1; i < len(aircraft); i++ {
 			front, trailing := aircraft[i-1], aircraft[i]
@@ -1953,9 +2126,47 @@ func (s *Sim) checkFinalApproachSpacing() {
 	}
 }
 
+// finalApproachSequences groups the IFR aircraft with assigned approaches
+// for which include returns true by airport and runway and returns the
+// groups, each sorted by distance to the threshold (closest first).
+func (s *Sim) finalApproachSequences(include func(*Aircraft) bool) [][]*Aircraft {
+	aircraftByRunway := make(map[string][]*Aircraft)
+	for _, ac := range util.SortedMap(s.Aircraft) {
+		if ac.Nav.Approach.Assigned != nil && include(ac) {
+			key := ac.FlightPlan.ArrivalAirport + "/" + ac.Nav.Approach.Assigned.Runway
+			aircraftByRunway[key] = append(aircraftByRunway[key], ac)
+		}
+	}
+
+	var sequences [][]*Aircraft
+	for _, aircraft := range util.SortedMap(aircraftByRunway) {
+		threshold := aircraft[0].Nav.Approach.Assigned.Threshold
+		slices.SortStableFunc(aircraft, func(a, b *Aircraft) int {
+			return cmp.Compare(math.NMDistance2LL(a.Position(), threshold),
+				math.NMDistance2LL(b.Position(), threshold))
+		})
+		sequences = append(sequences, aircraft)
+	}
+	return sequences
+}
+
 // goAroundForSpacing initiates a tower-commanded go-around for spacing violations.
 func (s *Sim) goAroundForSpacing(ac *Aircraft) {
 	ac.SentAroundForSpacing = true
+
+	e := Event{
+		Type:           SpacingGoAroundEvent,
+		ADSBCallsign:   ac.ADSBCallsign,
+		FromController: ac.ApproachTCP,
+	}
+	if fp := ac.NASFlightPlan; fp != nil {
+		e.ACID = fp.ACID
+		if e.FromController == "" {
+			e.FromController = fp.TrackingController
+		}
+	}
+	s.eventStream.Post(e)
+
 	s.goAround(ac)
 }
 
@@ -1967,6 +2178,10 @@ func (s *Sim) postReadbackTransmission(from av.ADSBCallsign, tr av.RadioTransmis
 	tr.Validate(s.lg)
 
 	if ac, ok := s.Aircraft[from]; ok {
+		if s.flownByPseudoPilot(ac) {
+			// The pseudo-pilot does their own readbacks.
+			return
+		}
 		ac.LastRadioTransmission = s.State.SimTime
 	}
 
@@ -1979,6 +2194,8 @@ func (s *Sim) postReadbackTransmission(from av.ADSBCallsign, tr av.RadioTransmis
 		WrittenText:           tr.Written(s.Rand),
 		SpokenText:            tr.Spoken(s.Rand),
 		RadioTransmissionType: tr.Type,
+		// A mixed-up pilot is responding to an instruction for someone else.
+		Readback: tr.Type != av.RadioTransmissionMixUp,
 	})
 }
 
@@ -7,12 +7,15 @@ package sim
 import (
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"time"
 
 	av "github.com/mmp/vice/aviation"
 	"github.com/mmp/vice/math"
 	"github.com/mmp/vice/nav"
 	"github.com/mmp/vice/rand"
+	"github.com/mmp/vice/util"
 
 	"github.com/goforj/godump"
 )
@@ -110,6 +113,15 @@ type LaunchConfig struct {
 	ArrivalPushLengthMinutes    int
 
 	EmergencyAircraftRate float32 // Aircraft per hour
+
+	// AutomaticRunwayChanges has a virtual TMU change the runway
+	// configuration if there is a persistent tailwind on the active
+	// runways.
+	AutomaticRunwayChanges bool
+
+	// WeatherDeviations has pilots request deviations around heavy
+	// precipitation; precipitation data is only fetched if it's set.
+	WeatherDeviations bool
 }
 
 func MakeLaunchConfig(dep []DepartureRunway, vfrRateScale float32, vfrAirports map[string]*av.Airport,
@@ -125,23 +137,14 @@ func MakeLaunchConfig(dep []DepartureRunway, vfrRateScale float32, vfrAirports m
 		ArrivalPushFrequencyMinutes: 20,
 		ArrivalPushLengthMinutes:    10,
 		EmergencyAircraftRate:       0,
+		WeatherDeviations:           true,
 	}
 
 	for icao, ap := range vfrAirports {
 		lc.VFRAirportRates[icao] = ap.VFRRateSum()
 	}
 
-	// Walk the departure runways to create the map for departures.
-	lc.DepartureRates = make(map[string]map[av.RunwayID]map[string]float32)
-	for _, rwy := range dep {
-		if _, ok := lc.DepartureRates[rwy.Airport]; !ok {
-			lc.DepartureRates[rwy.Airport] = make(map[av.RunwayID]map[string]float32)
-		}
-		if _, ok := lc.DepartureRates[rwy.Airport][rwy.Runway]; !ok {
-			lc.DepartureRates[rwy.Airport][rwy.Runway] = make(map[string]float32)
-		}
-		lc.DepartureRates[rwy.Airport][rwy.Runway][rwy.Category] = float32(rwy.DefaultRate)
-	}
+	lc.DepartureRates = makeDepartureRates(dep)
 
 	// Convert the inbound map from int to float32 rates
 	lc.InboundFlowRates = make(map[string]map[string]float32)
@@ -155,6 +158,55 @@ func MakeLaunchConfig(dep []DepartureRunway, vfrRateScale float32, vfrAirports m
 	return lc
 }
 
+// makeDepartureRates walks the departure runways to create the map of
+// default departure rates.
+func makeDepartureRates(dep []DepartureRunway) map[string]map[av.RunwayID]map[string]float32 {
+	rates := make(map[string]map[av.RunwayID]map[string]float32)
+	for _, rwy := range dep {
+		if _, ok := rates[rwy.Airport]; !ok {
+			rates[rwy.Airport] = make(map[av.RunwayID]map[string]float32)
+		}
+		if _, ok := rates[rwy.Airport][rwy.Runway]; !ok {
+			rates[rwy.Airport][rwy.Runway] = make(map[string]float32)
+		}
+		rates[rwy.Airport][rwy.Runway][rwy.Category] = float32(rwy.DefaultRate)
+	}
+	return rates
+}
+
+// RunwayConfigurationChanged returns true if the departure runways in
+// the two launch configs differ.
+func (lc *LaunchConfig) RunwayConfigurationChanged(other LaunchConfig) bool {
+	if len(lc.DepartureRates) != len(other.DepartureRates) {
+		return true
+	}
+	for ap, rwyRates := range lc.DepartureRates {
+		if !slices.Equal(util.SortedMapKeys(rwyRates), util.SortedMapKeys(other.DepartureRates[ap])) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeDepartureRates returns a copy of the current departure rates where
+// the rates for the airports, runways, and categories that are also in
+// edited are taken from it.
+func mergeDepartureRates(cur, edited map[string]map[av.RunwayID]map[string]float32) map[string]map[av.RunwayID]map[string]float32 {
+	merged := make(map[string]map[av.RunwayID]map[string]float32)
+	for ap, rwyRates := range cur {
+		merged[ap] = make(map[av.RunwayID]map[string]float32)
+		for rwy, categoryRates := range rwyRates {
+			merged[ap][rwy] = maps.Clone(categoryRates)
+			for category := range categoryRates {
+				if rate, ok := edited[ap][rwy][category]; ok {
+					merged[ap][rwy][category] = rate
+				}
+			}
+		}
+	}
+	return merged
+}
+
 // TotalDepartureRate returns the total departure rate (aircraft per hour) for all airports and runways
 func (lc *LaunchConfig) TotalDepartureRate() float32 {
 	var sum float32
@@ -265,6 +317,13 @@ func (s *Sim) SetLaunchConfig(tcw TCW, lc LaunchConfig) error {
 	s.mu.Lock(s.lg)
 	defer s.mu.Unlock(s.lg)
 
+	if s.State.LaunchConfig.RunwayConfigurationChanged(lc) {
+		// The runway configuration changed after the client's copy of the
+		// launch config was made; apply its edits to the runways that are
+		// still active.
+		lc.DepartureRates = mergeDepartureRates(s.State.LaunchConfig.DepartureRates, lc.DepartureRates)
+	}
+
 	// Update the next spawn time for any rates that changed.
 	for ap, rwyRates := range lc.DepartureRates {
 		for rwy, categoryRates := range rwyRates {
@@ -343,6 +402,13 @@ func (s *Sim) LaunchAircraft(ac Aircraft, departureRunway av.RunwayID) {
 	defer s.mu.Unlock(s.lg)
 
 	if departureRunway != "" && ac.HoldForRelease {
+		if s.DepartureState[ac.FlightPlan.DepartureAirport][departureRunway] == nil {
+			// The runway configuration changed after the client created
+			// the aircraft.
+			s.lg.Warn("launch from inactive runway", slog.String("adsb_callsign", string(ac.ADSBCallsign)),
+				slog.String("runway", string(departureRunway)))
+			return
+		}
 		s.addDepartureToPool(&ac, departureRunway, true /* manual launch */)
 	} else {
 		s.addAircraftNoLock(ac)
@@ -408,6 +474,13 @@ func (s *Sim) addAircraftNoLock(ac Aircraft) {
 		s.TotalVFR++
 	}
 
+	spawned := Event{Type: AircraftSpawnedEvent, ADSBCallsign: ac.ADSBCallsign}
+	if fp := ac.NASFlightPlan; fp != nil {
+		spawned.ACID = fp.ACID
+		spawned.ToController = fp.TrackingController
+	}
+	s.eventStream.Post(spawned)
+
 	if ac.IsDeparture() {
 		s.lg.Debug("launched departure", slog.String("adsb_callsign", string(ac.ADSBCallsign)),
 			slog.Any("aircraft", ac))
@@ -476,7 +549,7 @@ func (s *Sim) setInitialSpawnTimes(now time.Time) {
 	// or after the current time.
 	randomDelay := func(rate float32) time.Time {
 		if rate == 0 {
-			return time.Now().Add(365 * 24 * time.Hour)
+			return now.Add(365 * 24 * time.Hour)
 		}
 		avgWait := int(3600 / rate)
 		delta := s.Rand.Intn(avgWait) - avgWait/2
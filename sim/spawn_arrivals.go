@@ -111,10 +111,15 @@ func (s *Sim) createArrivalNoLock(group string, arrivalAirport string) (*Aircraf
 	ac.InitializeFlightPlan(av.FlightRulesIFR, acType, airline.Airport, arrivalAirport)
 
 	err := ac.InitializeArrival(s.State.Airports[arrivalAirport], &arr,
-		s.State.NmPerLongitude, s.State.MagneticVariation, s.wxModel, s.State.SimTime, s.lg)
+		s.State.NmPerLongitude, s.State.MagneticVariation, s.Rand, s.wxModel, s.State.SimTime, s.lg)
 	if err != nil {
 		return nil, err
 	}
+	if s.RunwaysChanged {
+		// The inbound flow's expected approach may be to a runway that
+		// is no longer active.
+		s.reassignExpectedApproach(ac)
+	}
 
 	nasFp := s.initNASFlightPlan(ac, av.FlightTypeArrival)
 	nasFp.Route = ac.FlightPlan.Route
@@ -184,7 +189,7 @@ func (s *Sim) sampleAircraft(al av.AirlineSpecifier, departureAirport, arrivalAi
 // assignSquawk allocates an enroute squawk code and assigns it to both the
 // aircraft and NAS flight plan.
 func (s *Sim) assignSquawk(ac *Aircraft, nasFp *NASFlightPlan) error {
-	sq, err := s.ERAMComputer.CreateSquawk()
+	sq, err := s.ERAMComputer.CreateSquawk(s.Rand)
 	if err != nil {
 		return err
 	}
@@ -272,7 +277,7 @@ func (s *Sim) createOverflightNoLock(group string) (*Aircraft, error) {
 	ac.InitializeFlightPlan(av.FlightRulesIFR, acType, airline.DepartureAirport, airline.ArrivalAirport)
 
 	if err := ac.InitializeOverflight(&of, s.State.NmPerLongitude, s.State.MagneticVariation,
-		s.wxModel, s.State.SimTime, s.lg); err != nil {
+		s.Rand, s.wxModel, s.State.SimTime, s.lg); err != nil {
 		return nil, err
 	}
 
@@ -192,6 +192,10 @@ func (s *Sim) launchSequencedDeparture(depState *RunwayLaunchState, airport stri
 
 	ac.WaitingForLaunch = false
 	dep.LaunchTime = now
+	s.eventStream.Post(Event{
+		Type:         AircraftDepartedEvent,
+		ADSBCallsign: ac.ADSBCallsign,
+	})
 	depState.LastDeparture = &dep
 	depState.Sequenced = depState.Sequenced[1:]
 
@@ -400,7 +404,7 @@ func (s *Sim) makeNewVFRDeparture(depart string, runway av.RunwayID) (ac *Aircra
 
 			if sampledRandoms != nil {
 				// Sample destination airport: may be where we started from.
-				arrive, ok := rand.SampleWeightedSeq(s.Rand, maps.Keys(s.State.DepartureAirports),
+				arrive, ok := rand.SampleWeighted(s.Rand, util.SortedMapKeys(s.State.DepartureAirports),
 					func(ap string) int { return s.State.Airports[ap].VFRRateSum() })
 				if !ok {
 					s.lg.Errorf("%s: unable to sample VFR destination airport???", depart)
@@ -592,7 +596,7 @@ func (s *Sim) createIFRDepartureNoLock(departureAirport string, runway av.Runway
 
 	exitRoute := exitRoutes[dep.Exit]
 	err := ac.InitializeDeparture(ap, departureAirport, dep, string(runway), *exitRoute, s.State.NmPerLongitude,
-		s.State.MagneticVariation, s.wxModel, s.State.SimTime, s.lg)
+		s.State.MagneticVariation, s.Rand, s.wxModel, s.State.SimTime, s.lg)
 	if err != nil {
 		return nil, err
 	}
@@ -645,7 +649,7 @@ func (s *Sim) CreateVFRDeparture(departureAirport string) (*Aircraft, error) {
 
 	for range 50 {
 		// Sample destination airport: may be where we started from.
-		arrive, ok := rand.SampleWeightedSeq(s.Rand, maps.Keys(s.State.DepartureAirports),
+		arrive, ok := rand.SampleWeighted(s.Rand, util.SortedMapKeys(s.State.DepartureAirports),
 			func(ap string) int { return s.State.Airports[ap].VFRRateSum() })
 		if !ok {
 			return nil, nil
@@ -388,6 +388,7 @@ type FacilityAdaptation struct {
 	HandoffAcceptFlashDuration int  `json:"handoff_acceptance_flash_duration" scope:"stars"`
 	DisplayHOFacilityOnly      bool `json:"display_handoff_facility_only" scope:"stars"`
 	HOSectorDisplayDuration    int  `json:"handoff_sector_display_duration" scope:"stars"`
+	CoastTimeout               int  `json:"coast_timeout" scope:"stars"` // seconds before coasting tracks are dropped
 
 	AirportCodes map[string]string `json:"airport_codes" scope:"eram"`
 
@@ -966,6 +967,7 @@ type NASFlightPlan struct {
 	CID                   string
 	EntryFix              string
 	ExitFix               string
+	DepartureAirport      string
 	ArrivalAirport        string // Technically not a string, but until the NAS system is fully integrated, we'll need this.
 	ExitFixIsIntermediate bool
 	Rules                 av.FlightRules
@@ -1039,6 +1041,9 @@ type NASFlightPlan struct {
 	// After fps are dropped, we hold on to them for a bit before they're
 	// actually deleted.
 	DeleteTime time.Time
+	// Set if the track was dropped after coasting, in which case it is
+	// reacquired if the aircraft is seen again before DeleteTime.
+	CoastDropped bool
 
 	// Used so that such FPs can associate regardless of acquisition filters.
 	ManuallyCreated bool
@@ -1098,6 +1103,12 @@ type FlightPlanSpecifier struct {
 
 	Location util.Optional[math.Point2LL]
 
+	// Fixes and airways separated by spaces, not including the departure
+	// and arrival airports.
+	Route            util.Optional[string]
+	DepartureAirport util.Optional[string]
+	ArrivalAirport   util.Optional[string]
+
 	PointOutHistory             util.Optional[[]string]
 	InhibitModeCAltitudeDisplay util.Optional[bool]
 	SPCOverride                 util.Optional[string]
@@ -1108,14 +1119,13 @@ type FlightPlanSpecifier struct {
 	QuickFlightPlan             util.Optional[bool]
 	HoldState                   util.Optional[bool]
 	Suspended                   util.Optional[bool]
-	CoastSuspendIndex           util.Optional[int]
 
 	InhibitACTypeDisplay      util.Optional[bool]
 	ForceACTypeDisplayEndTime util.Optional[time.Time]
 }
 
 func (s FlightPlanSpecifier) GetFlightPlan(localPool *av.LocalSquawkCodePool,
-	nasPool *av.EnrouteSquawkCodePool) (NASFlightPlan, error) {
+	nasPool *av.EnrouteSquawkCodePool, r *rand.Rand) (NASFlightPlan, error) {
 	sfp := NASFlightPlan{
 		ACID:                  s.ACID.GetOr(""),
 		EntryFix:              s.EntryFix.GetOr(""),
@@ -1143,6 +1153,10 @@ func (s FlightPlanSpecifier) GetFlightPlan(localPool *av.LocalSquawkCodePool,
 
 		Location: s.Location.GetOr(math.Point2LL{}),
 
+		Route:            s.Route.GetOr(""),
+		DepartureAirport: s.DepartureAirport.GetOr(""),
+		ArrivalAirport:   s.ArrivalAirport.GetOr(""),
+
 		PointOutHistory:             util.MapSlice(s.PointOutHistory.GetOr(nil), func(s string) ControlPosition { return ControlPosition(s) }),
 		InhibitModeCAltitudeDisplay: s.InhibitModeCAltitudeDisplay.GetOr(false),
 		SPCOverride:                 s.SPCOverride.GetOr(""),
@@ -1153,7 +1167,6 @@ func (s FlightPlanSpecifier) GetFlightPlan(localPool *av.LocalSquawkCodePool,
 		QuickFlightPlan:             s.QuickFlightPlan.GetOr(false),
 		HoldState:                   s.HoldState.GetOr(false),
 		Suspended:                   s.Suspended.GetOr(false),
-		CoastSuspendIndex:           s.CoastSuspendIndex.GetOr(0),
 
 		InhibitACTypeDisplay:      s.InhibitACTypeDisplay.GetOr(false),
 		ForceACTypeDisplayEndTime: s.ForceACTypeDisplayEndTime.GetOr(time.Time{}),
@@ -1171,7 +1184,7 @@ func (s FlightPlanSpecifier) GetFlightPlan(localPool *av.LocalSquawkCodePool,
 		sfp.AssignedSquawk = s.ImplicitSquawkAssignment.Get()
 	} else {
 		var rules av.FlightRules
-		sfp.AssignedSquawk, rules, err = assignCode(s.SquawkAssignment, sfp.PlanType, sfp.Rules, localPool, nasPool)
+		sfp.AssignedSquawk, rules, err = assignCode(s.SquawkAssignment, sfp.PlanType, sfp.Rules, localPool, nasPool, r)
 		sfp.Rules = s.Rules.GetOr(rules) // explicit rules from caller override squawk code pool rules
 	}
 
@@ -1210,11 +1223,11 @@ func (s *FlightPlanSpecifier) Merge(other FlightPlanSpecifier) {
 }
 
 func assignCode(assignment util.Optional[string], planType NASFlightPlanType, rules av.FlightRules,
-	localPool *av.LocalSquawkCodePool, nasPool *av.EnrouteSquawkCodePool) (av.Squawk, av.FlightRules, error) {
+	localPool *av.LocalSquawkCodePool, nasPool *av.EnrouteSquawkCodePool, r *rand.Rand) (av.Squawk, av.FlightRules, error) {
 	if planType == LocalEnroute {
 		// Squawk assignment is either empty or a straight up code (for a quick flight plan, 5-141)
 		if !assignment.IsSet || assignment.Get() == "" {
-			sq, err := nasPool.Get(rand.Make())
+			sq, err := nasPool.Get(r)
 			return sq, rules, err
 		} else {
 			sq, err := av.ParseSquawk(assignment.Get())
@@ -1224,7 +1237,7 @@ func assignCode(assignment util.Optional[string], planType NASFlightPlanType, ru
 			return sq, rules, err
 		}
 	} else {
-		return localPool.Get(assignment.GetOr(""), rules, rand.Make())
+		return localPool.Get(assignment.GetOr(""), rules, r)
 	}
 }
 
@@ -1267,7 +1280,7 @@ func (fp *NASFlightPlan) Update(spec FlightPlanSpecifier, sim *Sim) (err error)
 	} else if spec.SquawkAssignment.IsSet {
 		var rules av.FlightRules
 		fp.AssignedSquawk, rules, err = assignCode(spec.SquawkAssignment, fp.PlanType, fp.Rules, sim.LocalCodePool,
-			sim.ERAMComputer.SquawkCodePool)
+			sim.ERAMComputer.SquawkCodePool, sim.Rand)
 		if !spec.Rules.IsSet {
 			// Only take the rules from the pool if no rules were given in spec.
 			fp.Rules = rules
@@ -1349,6 +1362,15 @@ func (fp *NASFlightPlan) Update(spec FlightPlanSpecifier, sim *Sim) (err error)
 	if spec.Location.IsSet {
 		fp.Location = spec.Location.Get()
 	}
+	if spec.Route.IsSet {
+		fp.Route = spec.Route.Get()
+	}
+	if spec.DepartureAirport.IsSet {
+		fp.DepartureAirport = spec.DepartureAirport.Get()
+	}
+	if spec.ArrivalAirport.IsSet {
+		fp.ArrivalAirport = spec.ArrivalAirport.Get()
+	}
 	if spec.PointOutHistory.IsSet {
 		fp.PointOutHistory = util.MapSlice(spec.PointOutHistory.Get(), func(s string) ControlPosition { return ControlPosition(s) })
 	}
@@ -1379,9 +1401,6 @@ func (fp *NASFlightPlan) Update(spec FlightPlanSpecifier, sim *Sim) (err error)
 	if spec.Suspended.IsSet {
 		fp.Suspended = spec.Suspended.Get()
 	}
-	if spec.CoastSuspendIndex.IsSet {
-		fp.CoastSuspendIndex = spec.CoastSuspendIndex.Get()
-	}
 	if spec.InhibitACTypeDisplay.IsSet {
 		fp.InhibitACTypeDisplay = spec.InhibitACTypeDisplay.Get()
 	}
@@ -1737,9 +1756,9 @@ func (fa *FacilityAdaptation) PostDeserialize(loc av.Locator, controlledAirports
 
 	e.Push(`"coast_suspend_list"`)
 	if fa.CoastSuspendList.Format == "" {
-		fa.CoastSuspendList.Format = "[INDEX] [ACID] S [BEACON] [ALT]"
+		fa.CoastSuspendList.Format = "[INDEX] [ACID] [STATUS] [BEACON] [ALT]"
 	}
-	if err := validateListFormat(fa.CoastSuspendList.Format, "ALT"); err != nil {
+	if err := validateListFormat(fa.CoastSuspendList.Format, "ALT", "STATUS"); err != nil {
 		e.ErrorString("Invalid format string %q: %v", fa.CoastSuspendList.Format, err)
 	}
 	e.Pop()
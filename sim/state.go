@@ -30,8 +30,7 @@ type DynamicState struct {
 
 	SimTime time.Time // this is our fake time--accounting for pauses & simRate..
 
-	METAR      map[string]wx.METAR
-	ATISLetter map[string]string // airport ICAO -> single letter "A"-"Z"
+	METAR map[string]wx.METAR
 
 	LaunchConfig LaunchConfig
 
@@ -42,6 +41,26 @@ type DynamicState struct {
 
 	ATPAEnabled     bool                                   // True if ATPA is enabled system-wide
 	ATPAVolumeState map[string]map[string]*ATPAVolumeState // airport -> volumeId -> state
+
+	PseudoPilots map[TCW]*PseudoPilot // Signed-on pseudo-pilots
+
+	// The active runways may change as the sim runs; RunwayConfiguration
+	// gives the name of the corresponding entry in RunwayConfigurations.
+	RunwayConfiguration string
+	DepartureRunways    []DepartureRunway
+	ArrivalRunways      []ArrivalRunway
+
+	// Predicted conflicts from the ERAM conflict probe.
+	ProbeConflicts []ProbeConflict
+}
+
+// ATISState holds the airports' current ATIS information. It only changes
+// when an airport's ATIS advances, so rather than being included in every
+// StateUpdate, it is only sent to clients after ATISGeneration changes.
+type ATISState struct {
+	ATISGeneration int               // incremented whenever any ATIS changes
+	ATISLetter     map[string]string // airport ICAO -> single letter "A"-"Z"
+	ATIS           map[string]ATIS   // airport ICAO -> current broadcast
 }
 
 type ATPAVolumeState struct {
@@ -52,6 +71,7 @@ type ATPAVolumeState struct {
 // CommonState represents the sim state that is both used server side and client-side.
 type CommonState struct {
 	DynamicState
+	ATISState
 
 	Airports          map[string]*av.Airport
 	Controllers       map[ControlPosition]*av.Controller
@@ -64,10 +84,9 @@ type CommonState struct {
 
 	Airspace map[ControlPosition]map[string][]av.ControllerAirspaceVolume // position -> vol name -> definition
 
-	DepartureRunways []DepartureRunway
-	ArrivalRunways   []ArrivalRunway
-	InboundFlows     map[string]*av.InboundFlow
-	Emergencies      []Emergency
+	RunwayConfigurations []RunwayConfiguration
+	InboundFlows         map[string]*av.InboundFlow
+	Emergencies          []Emergency
 
 	Center                    math.Point2LL
 	Range                     float32
@@ -81,6 +100,10 @@ type CommonState struct {
 	NmPerLongitude    float32
 	PrimaryAirport    string
 
+	// ScriptedWeather is non-nil if the sim's weather is synthetic rather
+	// than real-world weather.
+	ScriptedWeather *wx.ScriptedWeather
+
 	SimDescription string
 
 	HandoffIDs []HandoffID
@@ -118,6 +141,10 @@ type StateUpdate struct {
 	DynamicState
 	DerivedState
 	FlightStripACIDs []ACID
+
+	// ATIS is only non-nil if the ATIS has changed since the generation
+	// the client last received.
+	ATIS *ATISState
 }
 
 ///////////////////////////////////////////////////////////////////////////
@@ -150,8 +177,15 @@ func makeDerivedState(s *Sim) DerivedState {
 			})
 	}
 
-	// Build Tracks from Aircraft
-	ds.Tracks = make(map[av.ADSBCallsign]*Track)
+	ds.Tracks = s.makeTracks()
+
+	return ds
+}
+
+// makeTracks returns the radar tracks for all of the radar-visible
+// aircraft as well as fake tracks for unsupported datablocks.
+func (s *Sim) makeTracks() map[av.ADSBCallsign]*Track {
+	tracks := make(map[av.ADSBCallsign]*Track)
 	for callsign, ac := range util.SortedMap(s.Aircraft) {
 		if !s.isRadarVisible(ac) {
 			continue
@@ -188,6 +222,14 @@ func makeDerivedState(s *Sim) DerivedState {
 			IsTentative:               s.State.SimTime.Sub(ac.FirstSeen) < 5*time.Second,
 		}
 
+		if hold := ac.Nav.Heading.Hold; hold != nil {
+			rt.HoldFix = hold.Hold.Fix
+		}
+		if ac.IsAssociated() && !ac.CoastStart.IsZero() {
+			rt.RadarTrack = s.coastingRadarTrack(ac)
+			rt.Coasting = true
+		}
+
 		if perf, ok := av.DB.AircraftPerformance[ac.FlightPlan.AircraftType]; ok {
 			rt.CWTCategory = perf.Category.CWT
 		}
@@ -196,7 +238,7 @@ func makeDerivedState(s *Sim) DerivedState {
 			rt.Route = append(rt.Route, wp.Location)
 		}
 
-		ds.Tracks[callsign] = &rt
+		tracks[callsign] = &rt
 	}
 
 	// Make up fake tracks for unsupported datablocks
@@ -205,7 +247,7 @@ func makeDerivedState(s *Sim) DerivedState {
 			continue
 		}
 		callsign := av.ADSBCallsign("__" + string(fp.ACID))
-		ds.Tracks[callsign] = &Track{
+		tracks[callsign] = &Track{
 			RadarTrack: av.RadarTrack{
 				ADSBCallsign: callsign,
 				Location:     fp.Location,
@@ -214,7 +256,7 @@ func makeDerivedState(s *Sim) DerivedState {
 		}
 	}
 
-	return ds
+	return tracks
 }
 
 func newCommonState(config NewSimConfiguration, startTime time.Time, manifest *VideoMapManifest, model *wx.Model,
@@ -226,8 +268,7 @@ func newCommonState(config NewSimConfiguration, startTime time.Time, manifest *V
 		DynamicState: DynamicState{
 			CurrentConsolidation: make(map[TCW]*TCPConsolidation),
 
-			METAR:      make(map[string]wx.METAR),
-			ATISLetter: make(map[string]string),
+			METAR: make(map[string]wx.METAR),
 
 			LaunchConfig: config.LaunchConfig,
 
@@ -236,6 +277,13 @@ func newCommonState(config NewSimConfiguration, startTime time.Time, manifest *V
 
 			ATPAEnabled:     true,
 			ATPAVolumeState: initATPAVolumeState(config.Airports),
+
+			RunwayConfiguration: config.RunwayConfiguration,
+			DepartureRunways:    config.DepartureRunways,
+			ArrivalRunways:      config.ArrivalRunways,
+		},
+		ATISState: ATISState{
+			ATISLetter: make(map[string]string),
 		},
 
 		Airports:    config.Airports,
@@ -245,10 +293,9 @@ func newCommonState(config NewSimConfiguration, startTime time.Time, manifest *V
 
 		ConfigurationId: config.ControllerConfiguration.ConfigId,
 
-		DepartureRunways: config.DepartureRunways,
-		ArrivalRunways:   config.ArrivalRunways,
-		InboundFlows:     config.InboundFlows,
-		Emergencies:      config.Emergencies,
+		RunwayConfigurations: config.RunwayConfigurations,
+		InboundFlows:         config.InboundFlows,
+		Emergencies:          config.Emergencies,
 
 		Center:                    config.Center,
 		Range:                     config.Range,
@@ -261,6 +308,7 @@ func newCommonState(config NewSimConfiguration, startTime time.Time, manifest *V
 		MagneticVariation: config.MagneticVariation,
 		NmPerLongitude:    config.NmPerLongitude,
 		PrimaryAirport:    config.PrimaryAirport,
+		ScriptedWeather:   config.ScriptedWeather,
 		SimDescription:    config.Description,
 
 		HandoffIDs: config.HandoffIDs,
@@ -379,6 +427,14 @@ func (ss *CommonState) Locate(s string) (math.Point2LL, bool) {
 	return math.Point2LL{}, false
 }
 
+func (ss *CommonState) Similar(fix string) []string {
+	d1, d2 := util.SelectInTwoEdits(fix, maps.Keys(ss.Fixes), nil, nil)
+	d1, d2 = util.SelectInTwoEdits(fix, maps.Keys(av.DB.Navaids), d1, d2)
+	d1, d2 = util.SelectInTwoEdits(fix, maps.Keys(av.DB.Airports), d1, d2)
+	d1, d2 = util.SelectInTwoEdits(fix, maps.Keys(av.DB.Fixes), d1, d2)
+	return util.Select(len(d1) > 0, d1, d2)
+}
+
 ///////////////////////////////////////////////////////////////////////////
 // CommonState methods for controller/consolidation management
 
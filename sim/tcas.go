@@ -0,0 +1,112 @@
+// sim/tcas.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"log/slog"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/math"
+	"github.com/mmp/vice/nav"
+	"github.com/mmp/vice/util"
+)
+
+// HasTCAS returns true if the aircraft is equipped with TCAS II; we
+// assume that jets and turboprops are and piston aircraft aren't.
+func (ac *Aircraft) HasTCAS() bool {
+	return ac.Nav.Perf.Engine.AircraftType != "P"
+}
+
+// checkTCAS runs the TCAS logic for all pairs of nearby airborne aircraft
+// with altitude-reporting transponders. Equipped aircraft that get a
+// resolution advisory follow it, overriding their assigned altitude, and
+// report it to their controller; once clear of conflict, they return to
+// the altitude they were flying to before.
+func (s *Sim) checkTCAS() {
+	var aircraft []*Aircraft
+	for _, ac := range util.SortedMap(s.Aircraft) {
+		if ac.IsAirborne() && !ac.WaitingForLaunch && ac.Mode == av.TransponderModeAltitude {
+			aircraft = append(aircraft, ac)
+		}
+	}
+
+	// The most severe threat for each equipped aircraft and the intruder
+	// responsible for it.
+	type threat struct {
+		nav.TCASThreat
+		intruder av.ADSBCallsign
+	}
+	threats := make(map[av.ADSBCallsign]threat)
+	// Aircraft following an RA that are still closing on the intruder
+	// that caused it.
+	closing := make(map[av.ADSBCallsign]bool)
+
+	evaluate := func(own, intruder *Aircraft) {
+		t := nav.CheckTCAS(&own.Nav.FlightState, &intruder.Nav.FlightState)
+		if t.Advisory > threats[own.ADSBCallsign].Advisory {
+			threats[own.ADSBCallsign] = threat{TCASThreat: t, intruder: intruder.ADSBCallsign}
+		}
+		if own.TCASIntruder == intruder.ADSBCallsign && t.Closing {
+			closing[own.ADSBCallsign] = true
+		}
+	}
+
+	for i, a := range aircraft {
+		for _, b := range aircraft[i+1:] {
+			// Quick out; no TCAS thresholds are anywhere near this.
+			if math.Abs(a.Altitude()-b.Altitude()) > 3000 || math.NMDistance2LL(a.Position(), b.Position()) > 10 {
+				continue
+			}
+			// Pseudo-pilots respond to RAs themselves.
+			if a.HasTCAS() && !s.flownByPseudoPilot(a) {
+				evaluate(a, b)
+			}
+			if b.HasTCAS() && !s.flownByPseudoPilot(b) {
+				evaluate(b, a)
+			}
+		}
+	}
+
+	// Go through all of the aircraft so that ones that are no longer
+	// eligible (e.g., they have landed) have their advisories cleared.
+	for _, ac := range util.SortedMap(s.Aircraft) {
+		t := threats[ac.ADSBCallsign]
+
+		if ac.TCASAdvisory == nav.TCASResolutionAdvisory {
+			if t.Advisory == nav.TCASResolutionAdvisory || closing[ac.ADSBCallsign] {
+				// Continue following the RA.
+				continue
+			}
+
+			ac.Nav.ClearOfConflict()
+			ac.TCASAdvisory, ac.TCASIntruder = t.Advisory, t.intruder
+			s.lg.Info("TCAS clear of conflict", slog.String("callsign", string(ac.ADSBCallsign)))
+			if ac.IsAirborne() {
+				s.enqueueTCASTransmission(ac, PendingTransmissionTCASClearOfConflict)
+			}
+		} else if t.Advisory == nav.TCASResolutionAdvisory {
+			climb := t.Climb
+			// RAs are coordinated between equipped aircraft so that
+			// they maneuver in opposite directions.
+			if other := s.Aircraft[t.intruder]; other.TCASIntruder == ac.ADSBCallsign && other.Nav.TCAS != nil {
+				climb = !other.Nav.TCAS.Climb
+			}
+
+			ac.Nav.FollowRA(climb)
+			ac.TCASAdvisory, ac.TCASIntruder = t.Advisory, t.intruder
+			s.lg.Info("TCAS RA", slog.String("callsign", string(ac.ADSBCallsign)),
+				slog.String("intruder", string(t.intruder)), slog.Bool("climb", climb))
+			s.enqueueTCASTransmission(ac, PendingTransmissionTCASRA)
+		} else {
+			ac.TCASAdvisory, ac.TCASIntruder = t.Advisory, t.intruder
+		}
+	}
+}
+
+func (s *Sim) enqueueTCASTransmission(ac *Aircraft, ty PendingTransmissionType) {
+	if freq := ac.ControllerFrequency; freq != "" {
+		s.enqueuePilotTransmission(ac.ADSBCallsign, TCP(freq), ty)
+	}
+}
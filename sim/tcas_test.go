@@ -0,0 +1,80 @@
+// sim/tcas_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"testing"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/nav"
+)
+
+func TestTCAS(t *testing.T) {
+	makeAircraft := func(callsign av.ADSBCallsign, east, heading float32) *Aircraft {
+		ac := makeTestAircraft(callsign, testPoint(east, 0), 8000, heading, 250)
+		ac.Nav.Perf = makeTestPerformance()
+		return ac
+	}
+
+	// Head-on at the same altitude, 3nm apart and closing at 500 knots.
+	a := makeAircraft("AAL1", 0, 90)
+	b := makeAircraft("JBU2", 3, 270)
+	s := makeTestSim(t, a, b)
+
+	s.checkTCAS()
+	for _, ac := range []*Aircraft{a, b} {
+		if ac.TCASAdvisory != nav.TCASResolutionAdvisory || ac.Nav.TCAS == nil {
+			t.Fatalf("%s: expected an RA, got advisory %d", ac.ADSBCallsign, ac.TCASAdvisory)
+		}
+	}
+	if a.TCASIntruder != "JBU2" || b.TCASIntruder != "AAL1" {
+		t.Errorf("unexpected intruders %q and %q", a.TCASIntruder, b.TCASIntruder)
+	}
+	if a.Nav.TCAS.Climb == b.Nav.TCAS.Climb {
+		t.Errorf("RAs weren't coordinated; both have climb=%v", a.Nav.TCAS.Climb)
+	}
+
+	climber := a
+	if !a.Nav.TCAS.Climb {
+		climber = b
+	}
+
+	// The target altitude is set when the RA is issued and doesn't move
+	// as the aircraft climbs.
+	for _, alt := range []float32{8000, 8600, 9000} {
+		climber.Nav.FlightState.Altitude = alt
+		if target, rate := climber.Nav.TargetAltitude(); target != 9000 || rate != 1500 {
+			t.Errorf("at %.0f: expected the RA to target 9000 at 1500 ft/min, got %.0f at %.0f", alt, target, rate)
+		}
+	}
+
+	// The RA isn't changed while the conflict continues.
+	s.checkTCAS()
+	if climber.Nav.TCAS == nil || climber.Nav.TCAS.Altitude != 9000 {
+		t.Errorf("RA target changed while in conflict: %+v", climber.Nav.TCAS)
+	}
+
+	// Once they have passed each other and are diverging, they're clear
+	// of conflict.
+	a.Nav.FlightState.Position = testPoint(4, 0)
+	b.Nav.FlightState.Position = testPoint(-1, 0)
+	s.checkTCAS()
+	for _, ac := range []*Aircraft{a, b} {
+		if ac.Nav.TCAS != nil || ac.TCASAdvisory == nav.TCASResolutionAdvisory {
+			t.Errorf("%s: expected clear of conflict, still has advisory %d", ac.ADSBCallsign, ac.TCASAdvisory)
+		}
+	}
+
+	// Aircraft without TCAS don't get RAs.
+	a = makeAircraft("AAL1", 0, 90)
+	b = makeAircraft("N123", 3, 270)
+	a.Nav.Perf.Engine.AircraftType = "P"
+	b.Nav.Perf.Engine.AircraftType = "P"
+	s.Aircraft = map[av.ADSBCallsign]*Aircraft{"AAL1": a, "N123": b}
+	s.checkTCAS()
+	if a.Nav.TCAS != nil || b.Nav.TCAS != nil {
+		t.Errorf("RA issued to an aircraft without TCAS")
+	}
+}
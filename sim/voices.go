@@ -0,0 +1,152 @@
+// sim/voices.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"unicode"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/util"
+)
+
+// Voice specifies how a pilot's transmissions are synthesized.
+type Voice struct {
+	Name        string  // TTS voice (e.g., "am_adam")
+	Speed       float32 // Speaking rate multiplier; 0 for the voice's default
+	RadioEffect float32 // Radio noise intensity; 1 is typical radio quality
+}
+
+// VoiceProfile is an entry in resources/voices.json; it specifies the set
+// of voices, the speaking rate, and the radio quality for pilots of a
+// group of airlines or for general aviation aircraft.
+type VoiceProfile struct {
+	Name            string   `json:"name"`
+	Airlines        []string `json:"airlines"` // ICAO codes
+	GeneralAviation bool     `json:"general_aviation"`
+	Default         bool     `json:"default"` // Used for callsigns no other profile matches
+	Voices          []string `json:"voices"`
+	Speed           float32  `json:"speed"`
+	RadioEffect     float32  `json:"radio_effect"`
+}
+
+// UnmarshalJSON fills in the default radio effect for profiles that don't
+// specify one.
+func (p *VoiceProfile) UnmarshalJSON(b []byte) error {
+	type profile VoiceProfile
+	vp := profile{RadioEffect: 1}
+	if err := json.Unmarshal(b, &vp); err != nil {
+		return err
+	}
+	*p = VoiceProfile(vp)
+	return nil
+}
+
+// getVoiceProfiles returns the voice profiles from resources/voices.json,
+// loading and validating them the first time it's called.
+var getVoiceProfiles = sync.OnceValues(func() ([]VoiceProfile, error) {
+	var vp struct {
+		Profiles []VoiceProfile `json:"profiles"`
+	}
+	if err := json.Unmarshal(util.LoadResourceBytes("voices.json"), &vp); err != nil {
+		return nil, fmt.Errorf("voices.json: %w", err)
+	}
+	if err := validateVoiceProfiles(vp.Profiles); err != nil {
+		return nil, fmt.Errorf("voices.json: %w", err)
+	}
+	return vp.Profiles, nil
+})
+
+// validateVoiceProfiles returns an error if the profiles are incomplete
+// or specify invalid settings.
+func validateVoiceProfiles(profiles []VoiceProfile) error {
+	if !slices.ContainsFunc(profiles, func(p VoiceProfile) bool { return p.Default }) {
+		return errors.New("no default profile specified")
+	}
+	for _, p := range profiles {
+		if len(p.Voices) == 0 {
+			return fmt.Errorf("%s: no voices specified", p.Name)
+		} else if p.Speed < 0 {
+			return fmt.Errorf("%s: speed %.2f must not be negative", p.Name, p.Speed)
+		} else if p.RadioEffect < 0 {
+			return fmt.Errorf("%s: radio_effect %.2f must not be negative", p.Name, p.RadioEffect)
+		}
+	}
+	return nil
+}
+
+// voiceProfileForCallsign returns the voice profile for the given callsign:
+// N-numbers are general aviation and otherwise the profile is chosen
+// based on the airline's ICAO code.
+func voiceProfileForCallsign(callsign av.ADSBCallsign) (VoiceProfile, error) {
+	profiles, err := getVoiceProfiles()
+	if err != nil {
+		return VoiceProfile{}, err
+	}
+
+	match := func(pred func(p VoiceProfile) bool) (VoiceProfile, bool) {
+		if idx := slices.IndexFunc(profiles, pred); idx != -1 {
+			return profiles[idx], true
+		}
+		return VoiceProfile{}, false
+	}
+
+	cs := string(callsign)
+	if len(cs) > 1 && cs[0] == 'N' && unicode.IsDigit(rune(cs[1])) {
+		if p, ok := match(func(p VoiceProfile) bool { return p.GeneralAviation }); ok {
+			return p, nil
+		}
+	} else if len(cs) > 3 {
+		if p, ok := match(func(p VoiceProfile) bool { return slices.Contains(p.Airlines, cs[:3]) }); ok {
+			return p, nil
+		}
+	}
+
+	p, _ := match(func(p VoiceProfile) bool { return p.Default })
+	return p, nil
+}
+
+// VoiceAssigner assigns TTS voices to aircraft callsigns using the voice
+// profiles. The voice is chosen based solely on a hash of the callsign so
+// that an aircraft sounds the same across frequencies and sessions.
+type VoiceAssigner struct {
+	// Callsign -> voice mapping
+	AircraftVoices map[av.ADSBCallsign]Voice
+}
+
+// NewVoiceAssigner creates a new VoiceAssigner.
+func NewVoiceAssigner() *VoiceAssigner {
+	return &VoiceAssigner{AircraftVoices: make(map[av.ADSBCallsign]Voice)}
+}
+
+// GetVoice returns the voice assigned to an aircraft, assigning one if
+// needed. An error is returned if the voice profiles are invalid.
+func (va *VoiceAssigner) GetVoice(callsign av.ADSBCallsign) (Voice, error) {
+	if v, ok := va.AircraftVoices[callsign]; ok {
+		return v, nil
+	}
+
+	p, err := voiceProfileForCallsign(callsign)
+	if err != nil {
+		return Voice{}, err
+	}
+	hash := util.HashString64(string(callsign))
+	name := p.Voices[hash%uint64(len(p.Voices))]
+
+	// Vary the rate a little (+/-5%) so that pilots sharing a profile
+	// don't all sound alike.
+	speed := p.Speed
+	if speed != 0 {
+		speed *= 0.95 + 0.1*float32((hash>>32)%1000)/1000
+	}
+
+	v := Voice{Name: name, Speed: speed, RadioEffect: p.RadioEffect}
+	va.AircraftVoices[callsign] = v
+	return v, nil
+}
@@ -0,0 +1,122 @@
+// sim/voices_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	av "github.com/mmp/vice/aviation"
+)
+
+func TestVoiceAssigner(t *testing.T) {
+	for cs, profile := range map[av.ADSBCallsign]string{
+		"BAW117":  "British",
+		"AAL1234": "American",
+		"N123AB":  "General aviation",
+		"RCH871":  "Military",
+		"CCA981":  "Chinese",
+	} {
+		p, err := voiceProfileForCallsign(cs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Name != profile {
+			t.Errorf("%s: got profile %q, expected %q", cs, p.Name, profile)
+		}
+
+		v, err := NewVoiceAssigner().GetVoice(cs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Contains(p.Voices, v.Name) {
+			t.Errorf("%s: voice %q not in profile %q", cs, v.Name, p.Name)
+		}
+		if v.RadioEffect != p.RadioEffect || v.Speed < 0.95*p.Speed || v.Speed > 1.05*p.Speed {
+			t.Errorf("%s: voice %+v doesn't match profile %+v", cs, v, p)
+		}
+	}
+
+	// The voice only depends on the callsign, so the same aircraft gets
+	// the same one in another session regardless of which other aircraft
+	// have been assigned voices.
+	va := NewVoiceAssigner()
+	for _, cs := range []av.ADSBCallsign{"BAW1", "BAW2", "BAW3", "BAW4", "VIR5", "VIR6"} {
+		va.GetVoice(cs)
+	}
+	for _, cs := range []av.ADSBCallsign{"BAW7", "VIR8", "AAL9"} {
+		v, _ := va.GetVoice(cs)
+		if v2, _ := NewVoiceAssigner().GetVoice(cs); v2 != v {
+			t.Errorf("%s: inconsistent voices %+v and %+v", cs, v, v2)
+		}
+	}
+}
+
+func TestVoiceProfileAirlines(t *testing.T) {
+	// Airlines are only given a non-default profile if their pilots
+	// would sound like its voices; the rest use the default one.
+	for airline, profile := range map[string]string{
+		"BAW": "British",
+		"VIR": "British",
+		"IBE": "Spanish",
+		"AMX": "Spanish",
+		"AFR": "French",
+		"ITY": "Italian",
+		"TAP": "Portuguese",
+		"GLO": "Portuguese",
+		"AIC": "Indian",
+		"JAL": "Japanese",
+		"ANA": "Japanese",
+		"CCA": "Chinese",
+		"CES": "Chinese",
+		"DAL": "American",
+		"DLH": "American",
+		"EIN": "American",
+		"KQA": "American",
+		"LOT": "American",
+		"ELY": "American",
+		"SIA": "American",
+		"CPA": "American",
+		"EVA": "American",
+		"KAL": "American",
+		"AAR": "American",
+	} {
+		p, err := voiceProfileForCallsign(av.ADSBCallsign(airline + "123"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Name != profile {
+			t.Errorf("%s: got profile %q, expected %q", airline, p.Name, profile)
+		}
+	}
+}
+
+func TestVoiceProfiles(t *testing.T) {
+	var profiles []VoiceProfile
+	if err := json.Unmarshal([]byte(`[{"name": "Quiet", "voices": ["am_adam"], "radio_effect": 0},
+{"name": "Unspecified", "voices": ["am_adam"], "default": true}]`), &profiles); err != nil {
+		t.Fatal(err)
+	}
+	if profiles[0].RadioEffect != 0 {
+		t.Errorf("explicit radio_effect of 0 not used, got %.2f", profiles[0].RadioEffect)
+	}
+	if profiles[1].RadioEffect != 1 {
+		t.Errorf("expected a default radio_effect of 1, got %.2f", profiles[1].RadioEffect)
+	}
+	if err := validateVoiceProfiles(profiles); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	for _, bad := range [][]VoiceProfile{
+		{{Name: "No default", Voices: []string{"am_adam"}}},
+		{{Name: "No voices", Default: true}},
+		{{Name: "Negative", Voices: []string{"am_adam"}, Default: true, RadioEffect: -1}},
+	} {
+		if err := validateVoiceProfiles(bad); err == nil {
+			t.Errorf("%s: expected an error", bad[0].Name)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// sim/wake.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/math"
+	"github.com/mmp/vice/util"
+)
+
+const (
+	// Probability per second that an aircraft encounters the wake of the
+	// one it's following, scaled by how much closer than the CWT wake
+	// separation minimum it is. At half the minimum, the chance of an
+	// encounter over a minute is roughly 25%.
+	wakeEncounterRate = 0.01
+	// Once an aircraft has encountered wake, it won't again for at least
+	// this long.
+	wakeEncounterInterval = 2 * time.Minute
+)
+
+// wakeSeparation returns the CWT wake turbulence separation minimum for
+// trailing when it is following front, or 0 if there is none. If
+// onApproach is true, the minima for aircraft on final to the same
+// runway are used. (7110.126B TBL 5-5-1, 5-5-2)
+func wakeSeparation(front, trailing *Aircraft, onApproach bool) float32 {
+	fcwt, tcwt := front.CWT(), trailing.CWT()
+	if len(fcwt) != 1 || len(tcwt) != 1 { // NOWGT
+		return 0
+	}
+	if onApproach {
+		return av.CWTApproachSeparation(fcwt, tcwt)
+	}
+	return av.CWTDirectlyBehindSeparation(fcwt, tcwt)
+}
+
+// checkFinalApproachWake looks for aircraft on final that are closer than
+// the CWT wake separation minimum to the one in front of them. Unlike
+// checkFinalApproachSpacing, aircraft that are established on the
+// approach but haven't yet contacted the tower are included.
+func (s *Sim) checkFinalApproachWake() {
+	sequences := s.finalApproachSequences(func(ac *Aircraft) bool {
+		return (ac.GotContactTower || ac.OnApproach(false)) && !ac.SentAroundForSpacing
+	})
+
+	for _, aircraft := range sequences {
+		for i := 1; i < len(aircraft); i++ {
+			front, trailing := aircraft[i-1], aircraft[i]
+			s.checkWakeEncounter(front, trailing, wakeSeparation(front, trailing, true))
+		}
+	}
+}
+
+// checkAirborneWake looks for aircraft following others closer than the
+// CWT wake separation minimum away from final approach; aircraft on
+// final are handled by checkFinalApproachWake.
+func (s *Sim) checkAirborneWake() {
+	var aircraft []*Aircraft
+	for _, ac := range util.SortedMap(s.Aircraft) {
+		if s.separationMonitored(ac) {
+			aircraft = append(aircraft, ac)
+		}
+	}
+
+	for i, a := range aircraft {
+		for _, b := range aircraft[i+1:] {
+			if math.NMDistance2LL(a.Position(), b.Position()) > 10 {
+				continue
+			}
+			if front, trailing, ok := s.inTrail(a, b); ok && !trailing.OnApproach(false) {
+				s.checkWakeEncounter(front, trailing, wakeSeparation(front, trailing, false))
+			}
+		}
+	}
+}
+
+// checkWakeEncounter randomly decides whether trailing encounters the
+// wake of front, given the wake separation minimum that applies between
+// them; the closer it is, the more likely an encounter is.
+func (s *Sim) checkWakeEncounter(front, trailing *Aircraft, wakeSep float32) {
+	if wakeSep == 0 {
+		return
+	}
+	if !trailing.LastWakeEncounter.IsZero() && s.State.SimTime.Sub(trailing.LastWakeEncounter) < wakeEncounterInterval {
+		return
+	}
+
+	sep := math.NMDistance2LL(front.Position(), trailing.Position())
+	if sep >= wakeSep || s.Rand.Float32() >= wakeEncounterRate*(1-sep/wakeSep) {
+		return
+	}
+
+	s.wakeEncounter(front, trailing, sep, wakeSep)
+}
+
+// wakeEncounter handles trailing encountering front's wake: if it's on
+// final with the tower, it goes around; otherwise its pilot reports it to
+// the controller.
+func (s *Sim) wakeEncounter(front, trailing *Aircraft, sep, wakeSep float32) {
+	trailing.LastWakeEncounter = s.State.SimTime
+
+	s.lg.Info("wake encounter", slog.String("callsign", string(trailing.ADSBCallsign)),
+		slog.String("front", string(front.ADSBCallsign)), slog.Float64("separation", float64(sep)),
+		slog.Float64("required", float64(wakeSep)))
+
+	// As with spacing go-arounds, the controller who cleared the aircraft
+	// for the approach is responsible.
+	e := Event{
+		Type:           WakeEncounterEvent,
+		ADSBCallsign:   trailing.ADSBCallsign,
+		FromController: trailing.ApproachTCP,
+		WrittenText: fmt.Sprintf("%s encountered wake turbulence %.1fnm behind %s (%.1fnm required)",
+			trailing.ADSBCallsign, sep, front.ADSBCallsign, wakeSep),
+	}
+	if fp := trailing.NASFlightPlan; fp != nil {
+		e.ACID = fp.ACID
+		if e.FromController == "" {
+			e.FromController = fp.TrackingController
+		}
+	}
+	s.eventStream.Post(e)
+
+	if trailing.GotContactTower && trailing.Nav.Approach.Assigned != nil {
+		trailing.WakeGoAround = true
+		s.goAround(trailing)
+	} else if freq := trailing.ControllerFrequency; freq != "" && !s.isVirtualController(freq) {
+		s.enqueuePilotTransmission(trailing.ADSBCallsign, TCP(freq), PendingTransmissionWakeEncounter)
+	}
+}
@@ -0,0 +1,125 @@
+// sim/wake_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package sim
+
+import (
+	"testing"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+)
+
+// setWakeTestDB sets up an aircraft performance database with a CWT
+// category B (B744), F (A320), and I (C172) type.
+func setWakeTestDB(t *testing.T) {
+	db := av.DB
+	t.Cleanup(func() { av.DB = db })
+
+	perf := func(cwt string) av.AircraftPerformance {
+		var p av.AircraftPerformance
+		p.Category.CWT = cwt
+		return p
+	}
+	av.DB = &av.StaticDatabase{
+		AircraftPerformance: map[string]av.AircraftPerformance{
+			"B744": perf("B"),
+			"A320": perf("F"),
+			"C172": perf("I"),
+		},
+	}
+}
+
+func makeWakeTestAircraft(callsign av.ADSBCallsign, acType string, north float32) *Aircraft {
+	ac := makeTestAircraft(callsign, testPoint(0, north), 3000, 180, 0)
+	ac.FlightPlan.AircraftType = acType
+	return ac
+}
+
+func TestWakeSeparation(t *testing.T) {
+	setWakeTestDB(t)
+
+	heavy := makeWakeTestAircraft("BAW1", "B744", 0)
+	large := makeWakeTestAircraft("AAL2", "A320", 0)
+	small := makeWakeTestAircraft("N123", "C172", 0)
+	unknown := makeWakeTestAircraft("XXX4", "ZZZZ", 0)
+
+	for _, test := range []struct {
+		front, trailing *Aircraft
+		onApproach      bool
+		want            float32
+	}{
+		{heavy, large, true, 5},
+		{heavy, small, true, 6},
+		{heavy, small, false, 5},
+		{large, small, true, 4},
+		{large, small, false, 0},
+		{large, heavy, true, 0},
+		{small, large, true, 0},
+		// NOWGT aircraft don't have wake separation minima.
+		{heavy, unknown, true, 0},
+		{unknown, large, true, 0},
+	} {
+		if got := wakeSeparation(test.front, test.trailing, test.onApproach); got != test.want {
+			t.Errorf("%s behind %s (on approach %v): got %.1f, expected %.1f",
+				test.trailing.FlightPlan.AircraftType, test.front.FlightPlan.AircraftType,
+				test.onApproach, got, test.want)
+		}
+	}
+}
+
+func TestWakeEncounter(t *testing.T) {
+	setWakeTestDB(t)
+
+	front := makeWakeTestAircraft("BAW1", "B744", 0)
+	trailing := makeWakeTestAircraft("AAL2", "A320", 2)
+
+	s := makeTestSim(t, front, trailing)
+
+	sub := s.eventStream.Subscribe()
+
+	// Runs the check for the given number of seconds and returns the
+	// times of the encounters.
+	run := func(seconds int, wakeSep float32) []time.Time {
+		var encounters []time.Time
+		for range seconds {
+			s.State.SimTime = s.State.SimTime.Add(time.Second)
+			s.checkWakeEncounter(front, trailing, wakeSep)
+			for _, e := range sub.Get() {
+				if e.Type == WakeEncounterEvent && e.ADSBCallsign == "AAL2" {
+					encounters = append(encounters, s.State.SimTime)
+				}
+			}
+		}
+		return encounters
+	}
+
+	// There's no chance of an encounter when there's no minimum or when
+	// the aircraft are farther apart than it.
+	if enc := run(3600, 0); len(enc) != 0 {
+		t.Errorf("unexpected wake encounters with no minimum: %v", enc)
+	}
+	if enc := run(3600, 1.5); len(enc) != 0 {
+		t.Errorf("unexpected wake encounters beyond the minimum: %v", enc)
+	}
+
+	// 2nm behind with a 5nm minimum, the chance is .6% per second, so
+	// over a few hours there should be a number of them.
+	enc := run(4*3600, wakeSeparation(front, trailing, true))
+	if len(enc) < 5 {
+		t.Fatalf("expected multiple wake encounters, got %d", len(enc))
+	}
+	if trailing.LastWakeEncounter != enc[len(enc)-1] {
+		t.Errorf("LastWakeEncounter %s doesn't match the last encounter at %s", trailing.LastWakeEncounter,
+			enc[len(enc)-1])
+	}
+	for i := 1; i < len(enc); i++ {
+		if d := enc[i].Sub(enc[i-1]); d < wakeEncounterInterval {
+			t.Errorf("encounters only %s apart", d)
+		}
+	}
+	if trailing.WakeGoAround {
+		t.Errorf("aircraft that hasn't contacted the tower went around")
+	}
+}
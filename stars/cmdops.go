@@ -77,7 +77,7 @@ func registerOpsCommands() {
 	// ** Handled with **[SLEW] for QL to self under 6.12.6 in cmdtools.go **
 
 	// 5.1.12 Accept handoff of track closest to range ring default center (p. 5-23)
-	registerCommand(CommandModeHandOff, "", func(sp *STARSPane, ctx *panes.Context, ps *Preferences) CommandStatus {
+	registerCommand(CommandModeHandOff, "", func(sp *STARSPane, ctx *panes.Context, ps *Preferences) (CommandStatus, error) {
 		var closest *sim.Track
 		var closestDistance float32
 		for _, trk := range sp.visibleTracks {
@@ -93,11 +93,14 @@ func registerOpsCommands() {
 			}
 		}
 		if closest != nil {
-			// TODO: ILL TRK if AMB / TRK / OLD, or CST...
+			// TODO: ILL TRK if AMB / TRK / OLD
+			if closest.Coasting {
+				return CommandStatus{}, ErrSTARSIllegalTrack
+			}
 			ctx.Client.AcceptHandoff(closest.FlightPlan.ACID, func(err error) { sp.displayError(err, ctx, "") })
-			return CommandStatus{}
+			return CommandStatus{}, nil
 		}
-		return CommandStatus{Output: "NO FLIGHT"}
+		return CommandStatus{Output: "NO FLIGHT"}, nil
 	})
 
 	// 5.1.13 Initiate handoff to ARTCC
@@ -454,13 +457,11 @@ func registerOpsCommands() {
 			} else {
 				var spec sim.FlightPlanSpecifier
 				spec.Suspended.Set(true)
-				spec.CoastSuspendIndex.Set(sp.CoastSuspendIndex % 100)
 				if trk.FlightPlan.Rules == av.FlightRulesIFR {
 					// Suspending the flight plan clears these
 					spec.DisableMSAW.Set(false)
 					spec.DisableCA.Set(false)
 				}
-				sp.CoastSuspendIndex++
 				modifyFlightPlan(sp, ctx, trk.FlightPlan.ACID, spec, false /* don't display */)
 				return nil
 			}
@@ -92,6 +92,10 @@ func registerSlewCommands() {
 				state.MSAWAcknowledged = true
 				return CommandStatus{}
 			}
+			if (state.FMAWarning || state.FMACaution) && !state.FMAAcknowledged {
+				state.FMAAcknowledged = true
+				return CommandStatus{}
+			}
 			if state.SPCAlert && !state.SPCAcknowledged {
 				// Acknowledged SPC alert part 1
 				state.SPCAcknowledged = true
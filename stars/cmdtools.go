@@ -1058,7 +1058,44 @@ func registerToolsCommands() {
 
 	// 6.15 Workstation note commands...
 
-	// 6.16 Final monitoring aid (FMA) commands...
+	// 6.16 Final monitoring aid (FMA) commands
+	toggleFMA := func(sp *STARSPane, ctx *panes.Context, ps *Preferences, ap string, num int) (CommandStatus, error) {
+		icao, idx, err := lookupFMAPair(ctx, ap, num)
+		if err != nil {
+			return CommandStatus{}, err
+		}
+		if ps.FMA.Airport == icao && ps.FMA.Pair == idx {
+			ps.FMA.Airport = ""
+			return CommandStatus{Output: "FMA OFF"}, nil
+		}
+		ps.FMA.Airport, ps.FMA.Pair = icao, idx
+		pair := ctx.Client.State.Airports[icao].FMAPairs[idx]
+		return CommandStatus{Output: "FMA " + ap + " " + pair.Runways[0] + "/" + pair.Runways[1]}, nil
+	}
+	registerCommand(CommandModeFMA, "[AIRPORT_ID] [NUM]", toggleFMA)
+	registerCommand(CommandModeFMA, "[NUM]",
+		func(sp *STARSPane, ctx *panes.Context, ps *Preferences, num int) (CommandStatus, error) {
+			// Use default airport from area config
+			da := ctx.FacilityAdaptation.DefaultAirportForArea(ctx.UserController().Area)
+			if da == "" {
+				return CommandStatus{}, ErrSTARSIllegalFunction
+			}
+			return toggleFMA(sp, ctx, ps, da[1:], num)
+		})
+	registerCommand(CommandModeFMA, "", func(ps *Preferences) error {
+		if ps.FMA.Airport == "" {
+			return ErrSTARSIllegalFunction
+		}
+		ps.FMA.Airport = ""
+		return nil
+	})
+	registerCommand(CommandModeFMA, "S[NUM]", func(ps *Preferences, stretch int) error {
+		if stretch < 1 || stretch > 8 {
+			return ErrSTARSIllegalParam
+		}
+		ps.FMA.Stretch = stretch
+		return nil
+	})
 
 	// 6.17 Military operations area (MOA) commands
 
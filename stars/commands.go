@@ -49,6 +49,7 @@ const (
 	CommandModeRestrictionArea
 	CommandModeDrawRoute
 	CommandModeDrawWind
+	CommandModeFMA
 
 	// These correspond to buttons on the main DCB menu.
 	CommandModeRange
@@ -118,6 +119,8 @@ func (c CommandMode) PreviewString(sp *STARSPane) string {
 		} else {
 			return "WIND"
 		}
+	case CommandModeFMA:
+		return "FMA"
 	case CommandModeRange:
 		return "RANGE"
 	case CommandModePlaceCenter:
@@ -357,8 +360,7 @@ func (sp *STARSPane) processKeyboardInput(ctx *panes.Context) {
 			if ctx.Keyboard.KeyControl() && ps.DisplayDCB {
 				sp.dcbShowAux = !sp.dcbShowAux
 			} else {
-				// TODO: FMA alerts / TSAS
-				sp.setCommandMode(ctx, CommandModeNone)
+				sp.setCommandMode(ctx, CommandModeFMA)
 			}
 
 		case imgui.KeyF9:
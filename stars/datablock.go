@@ -529,7 +529,9 @@ func (sp *STARSPane) getDatablock(ctx *panes.Context, trk sim.Track, sfp *sim.NA
 	var pilotReportedAltitude bool
 	if !trk.IsUnsupportedDB() {
 		haveTransponderAltitude := trk.Mode == av.TransponderModeAltitude && (sfp == nil || !sfp.InhibitModeCAltitudeDisplay)
-		if haveTransponderAltitude && state.UnreasonableModeC {
+		if trk.Coasting {
+			altitude = "CST"
+		} else if haveTransponderAltitude && state.UnreasonableModeC {
 			altitude = "XXX"
 		} else if haveTransponderAltitude {
 			if trk.TransponderAltitude < 0 {
@@ -1317,6 +1319,9 @@ func (sp *STARSPane) haveActiveWarnings(ctx *panes.Context, trk sim.Track) bool
 	if state.MSAW && !state.InhibitMSAW && !sfp.DisableMSAW && !ps.DisableMSAW {
 		return true
 	}
+	if state.FMAWarning || state.FMACaution {
+		return true
+	}
 	if trk.IsAssociated() {
 		if spc := sfp.SPCOverride; spc != "" && av.StringIsSPC(spc) /* only alerts, not custom warning SPCs */ {
 			return true
@@ -1398,6 +1403,11 @@ func (sp *STARSPane) getDatablockAlerts(ctx *panes.Context, trk sim.Track, dbtyp
 		if state.MSAW && !state.InhibitMSAW && !sfp.DisableMSAW && !ps.DisableMSAW {
 			addAlert("LA", !state.MSAWAcknowledged, true)
 		}
+		if state.FMAWarning || state.FMACaution {
+			// Cautions for predicted NTZ entry are yellow; actual
+			// transgressions are red.
+			addAlert("NTZ", !state.FMAAcknowledged, state.FMAWarning)
+		}
 		if spc := sfp.SPCOverride; spc != "" {
 			// squawked SPC takes priority
 			if sqspc, _ := trk.Squawk.IsSPC(); !sqspc || trk.Mode == av.TransponderModeStandby {
@@ -0,0 +1,152 @@
+// stars/fma.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+// Final monitor aid (FMA): a display mode for monitoring simultaneous
+// independent approaches to parallel runways. The scope is centered on
+// the no transgression zone (NTZ) between the two final approach courses
+// with the courses pointing up; the lateral scale is stretched so that
+// deviations from the courses are easily seen. Tracks on the finals that
+// are predicted to enter the NTZ get a caution and those inside of it get
+// a warning, both with an aural alert.
+
+package stars
+
+import (
+	"fmt"
+	"time"
+
+	av "github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/math"
+	"github.com/mmp/vice/panes"
+	"github.com/mmp/vice/radar"
+	"github.com/mmp/vice/renderer"
+	"github.com/mmp/vice/util"
+)
+
+// Tracks that are predicted to enter the NTZ within this time get an FMA
+// caution.
+const fmaLookahead = 10 * time.Second
+
+// Additional distance shown beyond either end of the NTZ.
+const fmaMarginNM = 2
+
+// activeFMAPair returns the runway pair being monitored with the FMA
+// display, if any.
+func (sp *STARSPane) activeFMAPair(ctx *panes.Context) *av.FMAPair {
+	ps := sp.currentPrefs()
+	if ps.FMA.Airport == "" {
+		return nil
+	}
+	if ap, ok := ctx.Client.State.Airports[ps.FMA.Airport]; ok && ps.FMA.Pair < len(ap.FMAPairs) {
+		return &ap.FMAPairs[ps.FMA.Pair]
+	}
+	return nil
+}
+
+// lookupFMAPair returns the ICAO code of the airport with the given FAA
+// identifier and the index of its given 1-based FMA pair.
+func lookupFMAPair(ctx *panes.Context, airport string, num int) (string, int, error) {
+	for icao, ap := range util.SortedMap(ctx.Client.State.Airports) {
+		if icao[1:] != airport { // drop the ICAO prefix ("K", "P", or "T")
+			continue
+		}
+		if num < 1 || num > len(ap.FMAPairs) {
+			return "", 0, ErrSTARSIllegalParam
+		}
+		return icao, num - 1, nil
+	}
+	return "", 0, ErrSTARSIllegalAirport
+}
+
+// fmaTransformations returns the scope transformations for the FMA
+// display of the given runway pair.
+func (sp *STARSPane) fmaTransformations(ctx *panes.Context, pair *av.FMAPair) radar.ScopeTransformations {
+	ps := sp.currentPrefs()
+	origin, along, _ := pair.Frame(ctx.NmPerLongitude, ctx.MagneticVariation)
+	ctr := math.NM2LL(math.Add2f(origin, math.Scale2f(along, pair.NTZLength/2)), ctx.NmPerLongitude)
+	return radar.GetStretchedScopeTransformations(ctx.PaneExtent, ctx.MagneticVariation, ctx.NmPerLongitude,
+		ctr, pair.NTZLength/2+fmaMarginNM, -pair.Heading, float32(ps.FMA.Stretch))
+}
+
+func (sp *STARSPane) updateFMAAlerts(ctx *panes.Context) {
+	pair := sp.activeFMAPair(ctx)
+
+	for _, trk := range sp.visibleTracks {
+		state := sp.TrackState[trk.ADSBCallsign]
+
+		warn, caution := false, false
+		if pair != nil && trk.IsAssociated() && trk.Mode == av.TransponderModeAltitude &&
+			pair.Monitored(state.track.Location, state.track.TransponderAltitude, ctx.NmPerLongitude,
+				ctx.MagneticVariation) {
+			if pair.InNTZ(state.track.Location) {
+				warn = true
+			} else if state.HaveHeading() {
+				v := state.HeadingVector(ctx.NmPerLongitude, ctx.MagneticVariation)
+				p := math.Add2f(state.track.Location, math.Scale2f(v, float32(fmaLookahead.Minutes())))
+				caution = pair.InNTZ(p)
+			}
+		}
+
+		if (warn && !state.FMAWarning) || (caution && !state.FMACaution && !state.FMAWarning) {
+			// It's a new alert or a caution has become a warning.
+			state.FMAAcknowledged = false
+			state.FMASoundEnd = time.Now().Add(AlertAudioDuration)
+		}
+		state.FMAWarning, state.FMACaution = warn, caution
+	}
+}
+
+// drawFMA draws the NTZ and the final approach courses with a tick mark
+// every mile and range labels every five.
+func (sp *STARSPane) drawFMA(ctx *panes.Context, pair *av.FMAPair, transforms radar.ScopeTransformations,
+	cb *renderer.CommandBuffer) {
+	ps := sp.currentPrefs()
+
+	ld := renderer.GetLinesDrawBuilder()
+	defer renderer.ReturnLinesDrawBuilder(ld)
+	td := renderer.GetTextDrawBuilder()
+	defer renderer.ReturnTextDrawBuilder(td)
+
+	// NTZ
+	ntz := make([][2]float32, len(pair.NTZ))
+	for i, p := range pair.NTZ {
+		ntz[i] = p
+	}
+	ld.AddLineLoop(ntz)
+	transforms.LoadLatLongViewingMatrices(cb)
+	cb.LineWidth(1, ctx.DPIScale)
+	cb.SetRGB(ps.Brightness.Lines.ScaleRGB(sp.Colors.TextAlert))
+	ld.GenerateCommands(cb)
+	ld.Reset()
+
+	// Final approach courses
+	_, along, across := pair.Frame(ctx.NmPerLongitude, ctx.MagneticVariation)
+	const tickNM float32 = .05 // before stretching
+	style := renderer.TextStyle{
+		Font:  sp.systemFont(ctx, ps.CharSize.Tools),
+		Color: ps.Brightness.Lines.ScaleRGB(sp.Colors.RangeRing),
+	}
+	for _, thr := range pair.Thresholds {
+		t := math.LL2NM(thr, ctx.NmPerLongitude)
+		pt := func(d, offset float32) math.Point2LL {
+			return math.NM2LL(math.Add2f(t, math.Add2f(math.Scale2f(along, d), math.Scale2f(across, offset))),
+				ctx.NmPerLongitude)
+		}
+
+		ld.AddLine(thr, pt(pair.NTZLength+fmaMarginNM, 0))
+		for d := 1; d <= int(pair.NTZLength+fmaMarginNM); d++ {
+			w := util.Select(d%5 == 0, 2*tickNM, tickNM)
+			ld.AddLine(pt(float32(d), -w), pt(float32(d), w))
+			if d%5 == 0 {
+				pw := transforms.WindowFromLatLongP(pt(float32(d), 0))
+				td.AddText(fmt.Sprintf("%d", d), math.Add2f(pw, [2]float32{8, 0}), style)
+			}
+		}
+	}
+	cb.SetRGB(ps.Brightness.Lines.ScaleRGB(sp.Colors.RangeRing))
+	ld.GenerateCommands(cb)
+
+	transforms.LoadWindowViewingMatrices(cb)
+	td.GenerateCommands(cb)
+}
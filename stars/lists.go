@@ -450,8 +450,14 @@ func (sp *STARSPane) drawSSAList(ctx *panes.Context, pw [2]float32, listStyle re
 
 	// ATIS/GI text. (Note that per 4-44 filter.All does not apply to GI text.)
 	for i := range ps.GIText {
-		if filter.GIText[i] && (ps.ATIS[i] != "" || ps.GIText[i] != "") {
-			pw = td.AddText(ps.ATIS[i]+" "+rewriteDelta(ps.GIText[i]), pw, listStyle)
+		atis := ps.ATIS[i]
+		if i == 0 && atis == "" {
+			// Until a code is entered, the main line shows the primary
+			// airport's current ATIS.
+			atis = ctx.Client.State.ATISLetter[ctx.Client.State.PrimaryAirport]
+		}
+		if filter.GIText[i] && (atis != "" || ps.GIText[i] != "") {
+			pw = td.AddText(atis+" "+rewriteDelta(ps.GIText[i]), pw, listStyle)
 			newline()
 		}
 	}
@@ -1009,9 +1015,9 @@ func (sp *STARSPane) drawAlertList(ctx *panes.Context, paneExtent math.Extent2D,
 
 func (sp *STARSPane) drawCoastList(ctx *panes.Context, paneExtent math.Extent2D, style renderer.TextStyle, td *renderer.TextDrawBuilder,
 	ld *renderer.ColoredLinesDrawBuilder) math.Extent2D {
-	// Get suspended tracks (coast not yet supported)
+	// Get coasting and suspended tracks
 	tracks := slices.Collect(util.FilterSeq(maps.Values(ctx.Client.State.Tracks),
-		func(t *sim.Track) bool { return t.IsAssociated() && t.FlightPlan.Suspended }))
+		func(t *sim.Track) bool { return t.IsAssociated() && (t.FlightPlan.Suspended || t.Coasting) }))
 	// Sort by list index
 	slices.SortFunc(tracks,
 		func(a, b *sim.Track) int { return a.FlightPlan.CoastSuspendIndex - b.FlightPlan.CoastSuspendIndex })
@@ -1020,7 +1026,7 @@ func (sp *STARSPane) drawCoastList(ctx *panes.Context, paneExtent math.Extent2D,
 	return sp.drawSystemList(ctx, paneExtent, &ps.CoastList.Position, style, td, ld, ListFormatter{
 		Title:      "COAST/SUSPEND",
 		FrameTitle: "COAST/SUSPEND (TC)",
-		Lines:      len(tracks), // Show all suspended tracks
+		Lines:      len(tracks), // Show all coasting and suspended tracks
 		Entries:    len(tracks),
 		FormatLine: func(idx int, sb *strings.Builder) {
 			trk := tracks[idx]
@@ -1040,6 +1046,9 @@ func (sp *STARSPane) drawCoastList(ctx *panes.Context, paneExtent math.Extent2D,
 					"INDEX": func() string {
 						return fmt.Sprintf("%2d", fp.CoastSuspendIndex)
 					},
+					"STATUS": func() string {
+						return util.Select(fp.Suspended, "S", "C")
+					},
 				}))
 		},
 	})
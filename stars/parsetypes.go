@@ -268,7 +268,8 @@ func (h *trackSuspendedIndexParser) Parse(sp *STARSPane, ctx *panes.Context, inp
 
 	trk, ok := util.SeqLookupFunc(maps.Values(ctx.Client.State.Tracks),
 		func(trk *sim.Track) bool {
-			return trk.IsAssociated() && trk.FlightPlan.Suspended && trk.FlightPlan.CoastSuspendIndex == listIndex
+			return trk.IsAssociated() && (trk.FlightPlan.Suspended || trk.Coasting) &&
+				trk.FlightPlan.CoastSuspendIndex == listIndex
 		})
 	if !ok {
 		return nil, text, false, nil
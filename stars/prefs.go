@@ -126,6 +126,13 @@ type Preferences struct {
 		ForceAllGhosts  bool
 	}
 
+	// Final monitor aid display; Airport is empty if it is off.
+	FMA struct {
+		Airport string
+		Pair    int // index into the airport's FMAPairs
+		Stretch int // lateral scale expansion factor
+	}
+
 	DisplayLDBBeaconCodes bool // TODO: default?
 	SelectedBeacons       []av.Squawk
 
@@ -313,6 +320,9 @@ func (p *Preferences) Reset(ss client.SimState, sp *STARSPane) {
 		p.CRDA.RunwayPairState = append(p.CRDA.RunwayPairState, state)
 	}
 
+	p.FMA.Airport = ""
+	p.FMA.Pair = 0
+
 	clear(p.RestrictionAreaSettings)
 
 	// Make the scenario's default video maps visible
@@ -356,6 +366,8 @@ func makeDefaultPreferences() *Preferences {
 
 	//prefs.DisplayUncorrelatedTargets = true
 
+	prefs.FMA.Stretch = 4
+
 	prefs.DisplayTPASize = true
 	prefs.DisplayATPAWarningAlertCones = true
 
@@ -458,6 +470,9 @@ func (p *Preferences) Activate(pl platform.Platform, sp *STARSPane) {
 	for len(p.AudioEffectEnabled) < AudioNumTypes {
 		p.AudioEffectEnabled = append(p.AudioEffectEnabled, false)
 	}
+	if p.FMA.Stretch == 0 {
+		p.FMA.Stretch = 4
+	}
 }
 
 func (p *Preferences) Upgrade(from, to int) {
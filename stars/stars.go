@@ -102,8 +102,6 @@ type STARSPane struct {
 	RejectedPointOuts map[sim.ACID]any
 	ForceQLACIDs      map[sim.ACID]any
 
-	CoastSuspendIndex int // Next index to assign
-
 	// Hold for release callsigns we have seen but not released. (We need
 	// to track this since auto release only applies to new ones seen after
 	// it is enabled.)
@@ -254,6 +252,7 @@ const (
 	AudioInboundHandoff
 	AudioCommandError
 	AudioHandoffAccepted
+	AudioFinalMonitorAid
 	AudioNumTypes
 )
 
@@ -267,6 +266,7 @@ func (ae AudioType) String() string {
 		"Inbound Handoff",
 		"Command Error",
 		"Handoff Accepted",
+		"Final Monitor Aid",
 	}[ae]
 }
 
@@ -1144,6 +1144,10 @@ func (sp *STARSPane) Draw(ctx *panes.Context, cb *renderer.CommandBuffer) {
 	ctr := util.Select(ps.UseUserCenter, ps.UserCenter, ps.DefaultCenter)
 	transforms := radar.GetScopeTransformations(ctx.PaneExtent, ctx.MagneticVariation, ctx.NmPerLongitude,
 		ctr, float32(ps.Range), 0)
+	fmaPair := sp.activeFMAPair(ctx)
+	if fmaPair != nil {
+		transforms = sp.fmaTransformations(ctx, fmaPair)
+	}
 
 	scopeExtent := ctx.PaneExtent
 	if ps.DisplayDCB {
@@ -1157,7 +1161,11 @@ func (sp *STARSPane) Draw(ctx *panes.Context, cb *renderer.CommandBuffer) {
 
 	sp.drawWX(ctx, transforms, cb)
 
-	sp.drawRangeRings(ctx, transforms, cb)
+	if fmaPair == nil {
+		// Range rings and the compass don't make sense with the
+		// rotated and stretched FMA display.
+		sp.drawRangeRings(ctx, transforms, cb)
+	}
 
 	sp.drawTRACONBoundary(ctx, transforms, cb)
 
@@ -1170,7 +1178,11 @@ func (sp *STARSPane) Draw(ctx *panes.Context, cb *renderer.CommandBuffer) {
 	sp.drawPlotPoints(ctx, transforms, cb)
 	sp.drawWind(ctx, transforms, cb)
 
-	sp.drawCompass(ctx, scopeExtent, transforms, cb)
+	if fmaPair != nil {
+		sp.drawFMA(ctx, fmaPair, transforms, cb)
+	} else {
+		sp.drawCompass(ctx, scopeExtent, transforms, cb)
+	}
 
 	sp.drawRestrictionAreas(ctx, transforms, cb)
 
@@ -1764,7 +1776,9 @@ func (sp *STARSPane) updateVisibleTracks(ctx *panes.Context) {
 		visible := false
 		state := sp.TrackState[trk.ADSBCallsign]
 
-		if trk.IsUnsupportedDB() {
+		if trk.IsUnsupportedDB() || trk.Coasting {
+			// Coasting tracks are shown at their extrapolated positions
+			// even though no radar can see them.
 			visible = true
 		} else if sp.radarMode(ctx.FacilityAdaptation.RadarSites) == RadarModeFused {
 			// If it hasn't been culled server-side due to e.g. the surface tracking filters,
@@ -1883,6 +1897,7 @@ func (sp *STARSPane) initializeAudio(p platform.Platform, lg *log.Logger) {
 		sp.audioEffects[AudioInboundHandoff] = loadMP3("263124__pan14__sine-octaves-up-beep.mp3")
 		sp.audioEffects[AudioCommandError] = loadMP3("ERROR.mp3")
 		sp.audioEffects[AudioHandoffAccepted] = loadMP3("321104__nsstudios__blip2.mp3")
+		sp.audioEffects[AudioFinalMonitorAid] = loadMP3("CA_1000ms.mp3")
 	}
 }
 
@@ -1954,6 +1969,18 @@ func (sp *STARSPane) updateAudio(ctx *panes.Context) {
 	}()
 	updateContinuous(playMSAWSound, AudioMinimumSafeAltitudeWarning)
 
+	playFMASound := func() bool {
+		for _, trk := range sp.visibleTracks {
+			state := sp.TrackState[trk.ADSBCallsign]
+			if (state.FMAWarning || state.FMACaution) && !state.FMAAcknowledged &&
+				ctx.Now.Before(state.FMASoundEnd) {
+				return true
+			}
+		}
+		return false
+	}()
+	updateContinuous(playFMASound, AudioFinalMonitorAid)
+
 	// 2-100: play sound if:
 	// - There is an unacknowledged SPC in a track's datablock
 	// - [todo]: track is unassociated or is associated and was displaying FDB
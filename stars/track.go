@@ -97,6 +97,13 @@ type TrackState struct {
 	SPCAcknowledged bool
 	SPCSoundEnd     time.Time
 
+	// Final monitor aid: a caution if the track is predicted to enter the
+	// NTZ and a warning if it is inside it.
+	FMACaution      bool
+	FMAWarning      bool
+	FMAAcknowledged bool
+	FMASoundEnd     time.Time
+
 	MissingFlightPlanAcknowledged bool
 
 	// record the code when it was ack'ed so that if it happens again with
@@ -497,6 +504,7 @@ func (sp *STARSPane) updateRadarTracks(ctx *panes.Context) {
 
 	// Update low altitude alerts now that we have updated tracks
 	sp.updateMSAWs(ctx)
+	sp.updateFMAAlerts(ctx)
 
 	// History tracks are updated after a radar track update, only if
 	// H_RATE seconds have elapsed (4-94).
@@ -633,6 +641,8 @@ func (sp *STARSPane) drawTracks(ctx *panes.Context, transforms radar.ScopeTransf
 					positionSymbol = "+"
 				}
 			}
+		} else if trk.Coasting {
+			positionSymbol = "#"
 		} else {
 			positionSymbol = "?"
 			// Use the owning TCW's primary TCP to determine the position symbol.
@@ -797,7 +807,7 @@ func (sp *STARSPane) drawTrack(trk sim.Track, state *TrackState, ctx *panes.Cont
 	pw := transforms.WindowFromLatLongP(pos)
 	primaryTargetBrightness := ps.Brightness.PrimarySymbols
 
-	drawPrimarySymbol := !isUnsupported && primaryTargetBrightness > 0 && !trk.IsTentative
+	drawPrimarySymbol := !isUnsupported && primaryTargetBrightness > 0 && !trk.IsTentative && !trk.Coasting
 	if drawPrimarySymbol {
 		switch mode := sp.radarMode(ctx.FacilityAdaptation.RadarSites); mode {
 		case RadarModeSingle:
@@ -1204,7 +1214,13 @@ func (sp *STARSPane) updateInTrailDistance(ctx *panes.Context) {
 			}
 			vol := ss.Airports[icao].ATPAVolumes[id]
 
-			// Get all aircraft on approach to this runway
+			// Get all aircraft on approach to this runway. Along with the
+			// ones inside the volume, this includes ones that are lined up
+			// on the extended centerline but outside of it (e.g., below
+			// its floor) so that an aircraft in the volume that is
+			// following a heavier one still gets the wake separation it
+			// requires.
+			inVolume := make(map[av.ADSBCallsign]bool)
 			runwayAircraft := util.FilterSlice(sp.visibleTracks, func(trk sim.Track) bool {
 				if !trk.IsAssociated() {
 					return false
@@ -1220,9 +1236,13 @@ func (sp *STARSPane) updateInTrailDistance(ctx *panes.Context) {
 				}
 
 				state := sp.TrackState[trk.ADSBCallsign]
-				return vol.Inside(state.track.Location, state.track.TransponderAltitude,
+				if vol.Inside(state.track.Location, state.track.TransponderAltitude,
 					state.TrackHeading(nmPerLongitude)+magneticVariation,
-					nmPerLongitude, magneticVariation)
+					nmPerLongitude, magneticVariation) {
+					inVolume[trk.ADSBCallsign] = true
+					return true
+				}
+				return trk.OnExtendedCenterline
 			})
 
 			// Sort by distance to threshold (there will be some redundant lookups of TrackState
@@ -1240,6 +1260,9 @@ func (sp *STARSPane) updateInTrailDistance(ctx *panes.Context) {
 					continue
 				}
 				leading, trailing := runwayAircraft[i-1], runwayAircraft[i]
+				if !inVolume[trailing.ADSBCallsign] {
+					continue
+				}
 				leadingState, trailingState := sp.TrackState[leading.ADSBCallsign], sp.TrackState[trailing.ADSBCallsign]
 				trailingState.IntrailDistance =
 					math.NMDistance2LL(leadingState.track.Location, trailingState.track.Location)
@@ -101,3 +101,30 @@ func commandEquivalent(expected, actual string, ac Aircraft, hasAircraftContext
 
 	return false
 }
+
+// CommandEquivalent checks if two individual commands are equivalent for
+// the given aircraft, allowing the same altitude-aware flexibility as
+// CommandsEquivalent.
+func CommandEquivalent(expected, actual string, ac Aircraft) bool {
+	return commandEquivalent(expected, actual, ac, true)
+}
+
+// CommandType returns a short name for the kind of command, e.g.
+// "altitude" for "D40", "navigation" for "DMERIT", or "SAYAGAIN" for
+// "SAYAGAIN/HEADING". It is used to summarize parsing accuracy by the
+// type of command.
+func CommandType(cmd string) string {
+	for _, prefix := range []string{"SAYAGAIN/", "TRAFFIC/", "ATIS/"} {
+		if strings.HasPrefix(cmd, prefix) {
+			return strings.TrimSuffix(prefix, "/")
+		}
+	}
+	if cat := getCommandCategory(cmd); cat != "" {
+		return cat
+	}
+	// Otherwise the leading letters, e.g. "L" for L270 or "TO" for contact tower.
+	if i := strings.IndexAny(cmd, "0123456789/"); i > 0 {
+		return cmd[:i]
+	}
+	return cmd
+}
@@ -174,6 +174,25 @@ func registerAllCommands() {
 		WithSayAgainOnFail(),
 	)
 
+	// Break-outs from a final approach course: "traffic alert, turn left
+	// immediately heading 180, climb and maintain 4000". The altitude is
+	// parsed as a separate climb/descend command.
+	registerSTTCommand(
+		"traffic alert [turn] left [immediately] heading {heading}",
+		func(hdg int) string { return fmt.Sprintf("BOL%03d", hdg) },
+		WithName("breakout_left"),
+		WithPriority(15),
+		WithSayAgainOnFail(),
+	)
+
+	registerSTTCommand(
+		"traffic alert [turn] right [immediately] heading {heading}",
+		func(hdg int) string { return fmt.Sprintf("BOR%03d", hdg) },
+		WithName("breakout_right"),
+		WithPriority(15),
+		WithSayAgainOnFail(),
+	)
+
 	registerSTTCommand(
 		"[fly] present heading",
 		func() string { return "H" },
@@ -880,6 +899,36 @@ func registerAllCommands() {
 		WithPriority(15),
 	)
 
+	// === WEATHER DEVIATIONS ===
+	registerSTTCommand(
+		"deviation[s] approved",
+		func() string { return "DEV" },
+		WithName("deviation_approved"),
+		WithPriority(15),
+	)
+
+	registerSTTCommand(
+		"deviation[s] not approved|unable deviation[s]",
+		func() string { return "NODEV" },
+		WithName("deviation_not_approved"),
+		WithPriority(20),
+	)
+
+	// === PILOT REQUESTS ===
+	registerSTTCommand(
+		"[request] approved as requested|request approved",
+		func() string { return "REQ" },
+		WithName("request_approved"),
+		WithPriority(15),
+	)
+
+	registerSTTCommand(
+		"unable [request|higher|lower|direct] [at this time]",
+		func() string { return "UNABLE" },
+		WithName("request_unable"),
+		WithPriority(10),
+	)
+
 	// === ATIS INFORMATION ===
 	registerSTTCommand(
 		"information {atis_letter} [is] [current]",
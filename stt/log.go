@@ -3,6 +3,7 @@ package stt
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 )
 
 // LogBuffer captures STT processing logs for bug reports.
@@ -14,8 +15,16 @@ type LogBuffer struct {
 var (
 	currentLogBuffer *LogBuffer
 	logBufferMu      sync.Mutex
+	quietLogs        atomic.Bool
 )
 
+// SetQuiet controls whether STT processing logs are printed to stdout;
+// they are still captured if capture is active. Batch tools that process
+// many transcripts use this to keep their output readable.
+func SetQuiet(quiet bool) {
+	quietLogs.Store(quiet)
+}
+
 // StartCapture begins capturing log lines to a buffer.
 // Returns the buffer that will collect the logs.
 func StartCapture() *LogBuffer {
@@ -44,7 +53,9 @@ func StopCapture() []string {
 // Also captures to buffer if capture is active.
 func logLocalStt(format string, args ...any) {
 	line := fmt.Sprintf("[local-stt] "+format, args...)
-	fmt.Println(line)
+	if !quietLogs.Load() {
+		fmt.Println(line)
+	}
 
 	logBufferMu.Lock()
 	buf := currentLogBuffer
@@ -338,7 +338,8 @@ func (p *Transcriber) decodeInternal(
 
 // DecodeFromState decodes a transcript using the simulation state directly.
 // It builds the aircraft context internally from the provided state.
-// Only tracks on the user's frequency (ControllerFrequency) are included in the context.
+// Only tracks on the user's frequency (ControllerFrequency) or on finals the
+// user is monitoring are included in the context.
 func (p *Transcriber) DecodeFromState(
 	state *sim.UserState,
 	userTCW sim.TCW,
@@ -366,8 +367,10 @@ func (p *Transcriber) BuildAircraftContext(
 	acCtx := make(map[string]Aircraft)
 
 	for _, trk := range state.Tracks {
-		// Check if the aircraft is on the user's frequency
-		if !state.TCWControlsPosition(userTCW, trk.ControllerFrequency) {
+		// Check if the aircraft is on the user's frequency or on a final
+		// that the user is monitoring, in which case it can be given
+		// break-outs.
+		if !state.TCWControlsPosition(userTCW, trk.ControllerFrequency) && !state.TCWMonitorsTrack(userTCW, trk) {
 			continue
 		}
 
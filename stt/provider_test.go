@@ -180,6 +180,14 @@ func TestBasicHeadingCommands(t *testing.T) {
 			},
 			expected: "JBU100 H180",
 		},
+		{
+			name:       "break-out",
+			transcript: "Delta 456 traffic alert turn left immediately heading one eight zero climb and maintain four thousand",
+			aircraft: map[string]Aircraft{
+				"Delta 456": {Callsign: "DAL456", State: "cleared approach", Altitude: 3000},
+			},
+			expected: "DAL456 BOL180 C40",
+		},
 	}
 
 	provider := NewTranscriber(nil)
@@ -3386,3 +3394,28 @@ func TestNegativeThatWasForFullParse(t *testing.T) {
 		})
 	}
 }
+
+func TestCommandType(t *testing.T) {
+	for cmd, expected := range map[string]string{
+		"D40":              "altitude",
+		"C120":             "altitude",
+		"DMERIT":           "navigation",
+		"DPUCKY/H":         "depart_heading",
+		"CI2L":             "cleared_approach",
+		"CNOLEY/A40":       "altitude",
+		"EI22L":            "expect_approach",
+		"S210":             "speed",
+		"TS180":            "speed",
+		"H270":             "heading",
+		"L160":             "L",
+		"T10R":             "T",
+		"TO":               "TO",
+		"SAYAGAIN/HEADING": "SAYAGAIN",
+		"TRAFFIC/3/1/30":   "TRAFFIC",
+		"ATIS/A":           "ATIS",
+	} {
+		if ty := CommandType(cmd); ty != expected {
+			t.Errorf("CommandType(%q) = %q, expected %q", cmd, ty, expected)
+		}
+	}
+}
@@ -91,6 +91,12 @@ func validateCommand(cmd string, ac Aircraft) string {
 			// Direct to fix - no state validation needed
 		}
 
+	case 'B':
+		// BO - break-out from a final approach course
+		if strings.HasPrefix(cmd, "BO") {
+			return validateBreakout(ac)
+		}
+
 	case 'C':
 		// C could be Climb (C{ALT}), Cleared approach (C{APPR}), or Cross fix
 		if len(cmd) > 1 {
@@ -320,6 +326,16 @@ func validateContactTower(_ Aircraft) string {
 	return ""
 }
 
+func validateBreakout(ac Aircraft) string {
+	// Break-outs are only issued to aircraft on final approach. Don't
+	// require an approach clearance, though: "traffic alert" is
+	// unambiguous and dropping the turn would be worse than issuing it.
+	if ac.State == "departure" || ac.State == "overflight" {
+		return "break-out only valid for arrivals"
+	}
+	return ""
+}
+
 func validateClimbViaSID(ac Aircraft) string {
 	// Climb via SID only for departures
 	if ac.State != "departure" {
@@ -144,7 +144,7 @@ func CheckTTSLoadError() (error, bool) {
 }
 
 func (t *localTTS) synthesize(mu *sync.Mutex, ttsEngine *OfflineTts,
-	text, voice string, radioSeed uint32) ([]int16, error) {
+	text, voice string, speed, radioEffect float32, radioSeed uint32) ([]int16, error) {
 	<-t.done
 
 	if ttsEngine == nil || text == "" {
@@ -159,7 +159,10 @@ func (t *localTTS) synthesize(mu *sync.Mutex, ttsEngine *OfflineTts,
 	mu.Lock()
 	defer mu.Unlock()
 
-	audio := ttsEngine.Generate(text, voiceID, t.voiceSpeed(voice))
+	if speed == 0 {
+		speed = t.voiceSpeed(voice)
+	}
+	audio := ttsEngine.Generate(text, voiceID, speed)
 	if audio == nil || len(audio.Samples) == 0 {
 		return nil, fmt.Errorf("TTS generation failed for text: %q", text)
 	}
@@ -173,43 +176,46 @@ func (t *localTTS) synthesize(mu *sync.Mutex, ttsEngine *OfflineTts,
 	tailSamples := t.targetSampleRate * (100 + r.Intn(200)) / 1000 // 0.1-0.3s
 	pcm = append(pcm, make([]int16, tailSamples)...)
 
-	addRadioEffect(pcm, t.targetSampleRate, radioSeed, 1)
+	addRadioEffect(pcm, t.targetSampleRate, radioSeed, radioEffect)
 	return pcm, nil
 }
 
 // synthesizeReadback generates speech using the high-priority TTS instance.
-func (t *localTTS) synthesizeReadback(text, voice string, radioSeed uint32) ([]int16, error) {
-	return t.synthesize(&t.readbackMu, t.readbackTTS, text, voice, radioSeed)
+func (t *localTTS) synthesizeReadback(text, voice string, speed, radioEffect float32, radioSeed uint32) ([]int16, error) {
+	return t.synthesize(&t.readbackMu, t.readbackTTS, text, voice, speed, radioEffect, radioSeed)
 }
 
 // synthesizeContact generates speech using the low-priority TTS instance.
-func (t *localTTS) synthesizeContact(text, voice string, radioSeed uint32) ([]int16, error) {
-	return t.synthesize(&t.contactMu, t.contactTTS, text, voice, radioSeed)
+func (t *localTTS) synthesizeContact(text, voice string, speed, radioEffect float32, radioSeed uint32) ([]int16, error) {
+	return t.synthesize(&t.contactMu, t.contactTTS, text, voice, speed, radioEffect, radioSeed)
 }
 
 // SynthesizeReadbackTTS generates PCM audio for a readback using the
-// high-priority TTS instance. The radioSeed determines per-aircraft radio
-// characteristics so the same aircraft has a consistent sound.
-func SynthesizeReadbackTTS(text, voice string, radioSeed uint32) ([]int16, error) {
+// high-priority TTS instance. The speed is the speaking rate multiplier (0
+// for the voice's default) and radioEffect scales the radio noise (1 is
+// typical). The radioSeed determines per-aircraft radio characteristics
+// so the same aircraft has a consistent sound.
+func SynthesizeReadbackTTS(text, voice string, speed, radioEffect float32, radioSeed uint32) ([]int16, error) {
 	start := time.Now()
 	defer func() {
 		fmt.Printf("readback %s: %q in %s\n", voice, text, time.Since(start))
 	}()
-	return globalTTS.synthesizeReadback(text, voice, radioSeed)
+	return globalTTS.synthesizeReadback(text, voice, speed, radioEffect, radioSeed)
 }
 
 // SynthesizeContactTTS generates PCM audio for a contact using the
-// low-priority TTS instance. The radioSeed determines per-aircraft radio
-// characteristics so the same aircraft has a consistent sound.
-func SynthesizeContactTTS(text, voice string, radioSeed uint32) ([]int16, error) {
+// low-priority TTS instance; the parameters are as for
+// SynthesizeReadbackTTS.
+func SynthesizeContactTTS(text, voice string, speed, radioEffect float32, radioSeed uint32) ([]int16, error) {
 	start := time.Now()
 	defer func() {
 		fmt.Printf("contact %s: %q in %s\n", voice, text, time.Since(start))
 	}()
-	return globalTTS.synthesizeContact(text, voice, radioSeed)
+	return globalTTS.synthesizeContact(text, voice, speed, radioEffect, radioSeed)
 }
 
-// voiceSpeed returns the TTS speed multiplier for the given voice name.
+// voiceSpeed returns the default TTS speed multiplier for the given voice
+// name, used when the voice profile doesn't specify one.
 func (t *localTTS) voiceSpeed(voice string) float32 {
 	if strings.HasPrefix(voice, "zf_") || strings.HasPrefix(voice, "zm_") {
 		return 1.3 // Chinese voices work better with a slower speed
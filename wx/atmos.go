@@ -945,12 +945,31 @@ func MakeFallbackAtmosFromMETAR(metar METAR, location math.Point2LL) (*AtmosByPo
 	}
 	windSpeed := float32(metar.WindSpeed)
 
+	stack := makeUniformWindSampleStack(windDir, windSpeed, metar.Temperature, metar.Dewpoint)
+
+	// Create AtmosByPoint with single point
+	ap := MakeAtmosByPoint()
+	ap.SampleStacks[location] = stack
+
+	// Convert to SOA format
+	soa, err := ap.ToSOA()
+	if err != nil {
+		return nil, err
+	}
+
+	return &soa, nil
+}
+
+// makeUniformWindSampleStack returns a sample stack with the given wind at
+// all altitude levels and temperatures that follow the ISA lapse rate
+// from the given surface temperature and dewpoint (in Celsius).
+func makeUniformWindSampleStack(windDir, windSpeed, temperature, dewpoint float32) *AtmosSampleStack {
 	// Convert wind direction and speed to U/V components
 	u, v := dirSpeedToUV(windDir, windSpeed)
 
-	// Use METAR temperature and dewpoint (convert to Kelvin)
-	tempK := metar.Temperature + 273.15
-	dewpointK := metar.Dewpoint + 273.15
+	// Convert temperature and dewpoint to Kelvin
+	tempK := temperature + 273.15
+	dewpointK := dewpoint + 273.15
 
 	// Create a sample stack with uniform wind at all levels
 	stack := &AtmosSampleStack{}
@@ -976,17 +995,7 @@ func MakeFallbackAtmosFromMETAR(metar METAR, location math.Point2LL) (*AtmosByPo
 		}
 	}
 
-	// Create AtmosByPoint with single point
-	ap := MakeAtmosByPoint()
-	ap.SampleStacks[location] = stack
-
-	// Convert to SOA format
-	soa, err := ap.ToSOA()
-	if err != nil {
-		return nil, err
-	}
-
-	return &soa, nil
+	return stack
 }
 
 // pressureToHeight converts pressure in millibars to geopotential height in meters
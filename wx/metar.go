@@ -114,7 +114,12 @@ func (m METAR) IsVMC() bool {
 
 // Visibility extracts visibility in statute miles from the raw METAR
 func (m METAR) Visibility() (float32, error) {
+	whole := 0 // for visibilities like "1 1/2SM"; only set if the previous field was whole miles
 	for f := range strings.FieldsSeq(m.Raw) {
+		if n, err := strconv.Atoi(f); err == nil && n < 10 {
+			whole = n
+			continue
+		}
 		if before, ok := strings.CutSuffix(f, "SM"); ok {
 			f = before
 			f = strings.TrimPrefix(f, "M") // there if 1/4 or less
@@ -126,7 +131,7 @@ func (m METAR) Visibility() (float32, error) {
 				} else if denom, err := strconv.Atoi(sdenom); err != nil {
 					return -1, err
 				} else {
-					return float32(num) / float32(denom), nil
+					return float32(whole) + float32(num)/float32(denom), nil
 				}
 			} else if vis, err := strconv.Atoi(f); err != nil {
 				return -1, err
@@ -134,6 +139,7 @@ func (m METAR) Visibility() (float32, error) {
 				return float32(vis), nil
 			}
 		}
+		whole = 0
 	}
 	return -1, fmt.Errorf("%s: no visibility found", m.Raw)
 }
@@ -0,0 +1,33 @@
+// wx/metar_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package wx
+
+import "testing"
+
+func TestMETARVisibility(t *testing.T) {
+	for _, test := range []struct {
+		raw  string
+		want float32
+	}{
+		{"KJFK 011851Z 22412KT 10SM FEW250 M02/M05 A2992", 10},
+		{"KJFK 011851Z 22412G22KT 1 1/2SM -SHRA BR OVC008 M02/M05 A2992", 1.5},
+		{"KJFK 011851Z 22412KT M1/4SM FG VV002 M02/M05 A2992", 0.25},
+		// A bare number that isn't right before the fraction isn't part
+		// of the visibility.
+		{"KJFK 011851Z 3 22412KT 3/4SM BR OVC004 M02/M05 A2992", 0.75},
+		{"KJFK 011851Z 22412KT 5 2 1/4SM BR OVC004 M02/M05 A2992", 2.25},
+		{"KJFK 011851Z 4 22412KT 7SM OVC040 M02/M05 A2992", 7},
+	} {
+		if vis, err := (METAR{Raw: test.raw}).Visibility(); err != nil {
+			t.Errorf("%s: %v", test.raw, err)
+		} else if vis != test.want {
+			t.Errorf("%s: got visibility %.2f, expected %.2f", test.raw, vis, test.want)
+		}
+	}
+
+	if _, err := (METAR{Raw: "KJFK 011851Z 22412KT 3 FEW250 M02/M05 A2992"}).Visibility(); err == nil {
+		t.Errorf("expected an error for a METAR without visibility")
+	}
+}
@@ -13,6 +13,14 @@ import (
 	"github.com/mmp/vice/math"
 )
 
+// Model provides atmospheric and precipitation data to the sim. Data is
+// fetched from the provider asynchronously, ahead of when it's needed, and
+// is only used once it has arrived; the sim is never held up waiting for
+// it. Until the first atmospheric grid arrives, the standard atmosphere is
+// used, and if a later one is late, the previous one continues to be used.
+// Data from providers that have it locally (e.g., scripted weather) is
+// used immediately, so what the sim sees with them doesn't depend on
+// timing.
 type Model struct {
 	provider       Provider
 	facility       string
@@ -23,6 +31,12 @@ type Model struct {
 	nextFetch time.Time
 	ch        <-chan AtmosResult
 
+	precip          *Precip
+	precipTime      time.Time
+	nextPrecipFetch time.Time // zero if there are no more to fetch
+	precipCh        <-chan PrecipResult
+	precipRequested bool // whether precipitation has been fetched at all
+
 	mu sync.Mutex
 	lg *log.Logger
 }
@@ -34,7 +48,21 @@ type AtmosResult struct {
 	Err             error
 }
 
-func MakeModel(provider Provider, facility string, primaryAirport string, startTime time.Time, lg *log.Logger) *Model {
+type PrecipResult struct {
+	Precip   *Precip
+	Time     time.Time
+	NextTime time.Time
+	Err      error
+}
+
+// If precipitation can't be fetched, wait this long before trying again.
+const precipRetryInterval = 15 * time.Minute
+
+// MakeModel returns a model that starts fetching atmospheric data for the
+// given start time. Precipitation is only fetched ahead of time if precip
+// is true; otherwise it's fetched the first time PrecipDBZ is called.
+func MakeModel(provider Provider, facility string, primaryAirport string, startTime time.Time, precip bool,
+	lg *log.Logger) *Model {
 	m := &Model{
 		provider:       provider,
 		facility:       facility,
@@ -46,10 +74,35 @@ func MakeModel(provider Provider, facility string, primaryAirport string, startT
 	}
 
 	m.ch = m.fetchAtmos(startTime)
+	if precip {
+		m.startPrecipFetch(startTime)
+	}
 
 	return m
 }
 
+// Restore prepares the model for the sim's time having been set back to
+// t, as when a checkpoint is restored. Data that has already been fetched
+// is kept if it covers t; otherwise it's fetched again.
+func (m *Model) Restore(t time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !aviation.DB.IsFacility(m.facility) {
+		return
+	}
+
+	if m.grids[0] != nil && t.Before(m.times[0]) {
+		m.grids, m.times, m.nextFetch = [2]*AtmosGrid{}, [2]time.Time{}, time.Time{}
+		m.ch = m.fetchAtmos(t)
+	}
+
+	if m.precipRequested && t.Before(m.precipTime) {
+		m.precip, m.precipTime, m.nextPrecipFetch = nil, time.Time{}, time.Time{}
+		m.startPrecipFetch(t)
+	}
+}
+
 func (m *Model) fetchAtmos(t time.Time) <-chan AtmosResult {
 	if m.provider == nil {
 		return nil
@@ -57,7 +110,7 @@ func (m *Model) fetchAtmos(t time.Time) <-chan AtmosResult {
 
 	ch := make(chan AtmosResult, 1)
 
-	go func() {
+	m.fetch(func() {
 		defer close(ch)
 		atmos, atmosTime, nextTime, err := m.provider.GetAtmosGrid(m.facility, t, m.primaryAirport)
 		ch <- AtmosResult{
@@ -66,11 +119,112 @@ func (m *Model) fetchAtmos(t time.Time) <-chan AtmosResult {
 			NextTime:        nextTime,
 			Err:             err,
 		}
-	}()
+	})
+
+	return ch
+}
+
+// fetch runs f, which gets data from the provider and sends it on a
+// buffered channel. It runs in the background unless the provider
+// generates the data locally, in which case it's available immediately.
+func (m *Model) fetch(f func()) {
+	if lp, ok := m.provider.(LocalProvider); ok && lp.IsLocal() {
+		f()
+	} else {
+		go f()
+	}
+}
+
+func (m *Model) fetchPrecip(t time.Time) <-chan PrecipResult {
+	if m.provider == nil {
+		return nil
+	}
+
+	ch := make(chan PrecipResult, 1)
+
+	m.fetch(func() {
+		defer close(ch)
+		if pp, ok := m.provider.(PrecipProvider); ok {
+			precip, nextTime, err := pp.GetPrecip(m.facility, t)
+			ch <- PrecipResult{Precip: precip, Time: t, NextTime: nextTime, Err: err}
+			return
+		}
+
+		url, nextTime, err := m.provider.GetPrecipURL(m.facility, t)
+		if err != nil {
+			ch <- PrecipResult{Err: err}
+			return
+		}
+		precip, err := FetchPrecip(url)
+		ch <- PrecipResult{Precip: precip, Time: t, NextTime: nextTime, Err: err}
+	})
 
 	return ch
 }
 
+func (m *Model) startPrecipFetch(t time.Time) {
+	m.precipRequested = true
+	m.precipCh = m.fetchPrecip(t)
+}
+
+// PrecipDBZ returns the reflectivity of the precipitation at the given
+// point at time t, in dBZ. 0 is returned if precipitation data isn't
+// available (e.g., when running offline or before the first fetch has
+// finished); as with Lookup, it never waits for a fetch.
+func (m *Model) PrecipDBZ(p math.Point2LL, t time.Time) byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.precipRequested && aviation.DB.IsFacility(m.facility) {
+		m.startPrecipFetch(t)
+	}
+	if m.precip == nil {
+		// Pick up the first one as soon as it arrives.
+		m.pollPrecip(t)
+	}
+	if !m.nextPrecipFetch.IsZero() && t.After(m.nextPrecipFetch) {
+		if m.precipCh == nil {
+			// Retrying after an error.
+			m.precipCh = m.fetchPrecip(t)
+		}
+		// The current one is used until the next one arrives.
+		m.pollPrecip(t)
+	}
+
+	if m.precip == nil {
+		return 0
+	}
+	return m.precip.Lookup(p)
+}
+
+// pollPrecip takes the result of the pending precipitation fetch if it
+// has arrived and otherwise returns immediately.
+func (m *Model) pollPrecip(t time.Time) {
+	if m.precipCh == nil {
+		return
+	}
+	select {
+	case pr := <-m.precipCh:
+		m.updatePrecip(pr, t)
+	default:
+	}
+}
+
+// updatePrecip takes the result of a precipitation fetch and starts
+// fetching the one after it.
+func (m *Model) updatePrecip(pr PrecipResult, t time.Time) {
+	m.precipCh = nil
+	if pr.Err != nil {
+		m.lg.Infof("precip: %v", pr.Err)
+		m.nextPrecipFetch = t.Add(precipRetryInterval)
+	} else {
+		m.precip, m.precipTime, m.nextPrecipFetch = pr.Precip, pr.Time, pr.NextTime
+		if !m.nextPrecipFetch.IsZero() {
+			m.precipCh = m.fetchPrecip(m.nextPrecipFetch)
+		}
+	}
+}
+
 func (m *Model) Lookup(p math.Point2LL, alt float32, t time.Time) Sample {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -95,15 +249,24 @@ func (m *Model) Lookup(p math.Point2LL, alt float32, t time.Time) Sample {
 }
 
 func (m *Model) checkFetches(t time.Time) {
-	if !aviation.DB.IsFacility(m.facility) {
+	if !aviation.DB.IsFacility(m.facility) || m.ch == nil {
 		return
 	}
 
-	// Kick off the next fetch if we've passed the second grid's time.
-	if !m.times[1].IsZero() && t.After(m.times[1]) && !m.nextFetch.IsZero() && m.ch == nil {
-		m.ch = m.fetchAtmos(m.nextFetch)
+	if m.grids[0] == nil {
+		// Pick up the first one as soon as it arrives.
+		m.pollAtmos()
+	}
+	// The next grid has been prefetched and is picked up once we've
+	// passed the second grid's time, if it has arrived by then.
+	if m.ch != nil && !m.times[1].IsZero() && t.After(m.times[1]) {
+		m.pollAtmos()
 	}
+}
 
+// pollAtmos takes the result of the pending atmos fetch if it has arrived
+// and otherwise returns immediately.
+func (m *Model) pollAtmos() {
 	select {
 	case ar := <-m.ch:
 		m.updateAtmos(ar)
@@ -112,6 +275,7 @@ func (m *Model) checkFetches(t time.Time) {
 }
 
 func (m *Model) updateAtmos(ar AtmosResult) {
+	m.ch = nil
 	if ar.Err != nil {
 		m.lg.Errorf("%v", ar.Err)
 		return
@@ -133,11 +297,11 @@ func (m *Model) updateAtmos(ar AtmosResult) {
 			// code elsewhere can assume that either none or both are
 			// present.
 			m.grids[0], m.times[0] = m.grids[1], m.times[1]
+		}
 
-			// And get started on fetching the next one.
+		// And get started on fetching the next one.
+		if !m.nextFetch.IsZero() {
 			m.ch = m.fetchAtmos(m.nextFetch)
-		} else {
-			m.ch = nil
 		}
 	}
 }
@@ -0,0 +1,175 @@
+// wx/model_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package wx
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mmp/vice/aviation"
+	"github.com/mmp/vice/log"
+	"github.com/mmp/vice/math"
+)
+
+// countingProvider wraps a ScriptedProvider and records the times that
+// precipitation is fetched for.
+type countingProvider struct {
+	*ScriptedProvider
+	mu      sync.Mutex
+	fetches []time.Time
+}
+
+func (p *countingProvider) GetPrecip(facility string, t time.Time) (*Precip, time.Time, error) {
+	p.mu.Lock()
+	p.fetches = append(p.fetches, t)
+	p.mu.Unlock()
+	return p.ScriptedProvider.GetPrecip(facility, t)
+}
+
+func (p *countingProvider) fetched() []time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.fetches)
+}
+
+func TestModelPrecipFetches(t *testing.T) {
+	db := aviation.DB
+	t.Cleanup(func() { aviation.DB = db })
+	aviation.DB = &aviation.StaticDatabase{TRACONs: map[string]aviation.TRACON{"TST": {}}}
+
+	start := time.Date(2025, time.November, 1, 12, 0, 0, 0, time.UTC)
+	origin := math.Point2LL{-73, 41}
+	sw := (&ScriptedWeather{Conditions: []ScriptedConditions{{At: 0}}}).Anchor(start, origin)
+	provider := &countingProvider{ScriptedProvider: MakeScriptedProvider(sw)}
+	lg := log.New(false, "error", "")
+
+	// Precipitation isn't fetched until it's needed if it wasn't asked for.
+	m := MakeModel(provider, "TST", "", start, false, lg)
+	m.GetAtmosGrid()
+	if n := len(provider.fetched()); n != 0 {
+		t.Fatalf("precipitation fetched %d times before it was needed", n)
+	}
+	m.PrecipDBZ(origin, start)
+	if n := len(provider.fetched()); n == 0 {
+		t.Fatalf("precipitation wasn't fetched when needed")
+	}
+
+	// Advance past the first one so that it's replaced by the next.
+	t1 := start.Add(scriptedUpdateInterval + time.Minute)
+	m.PrecipDBZ(origin, t1)
+	n := len(provider.fetched())
+
+	// Restoring to a time that's covered by what's been fetched doesn't
+	// refetch... (The one after it may have been prefetched in the
+	// meantime, however.)
+	t2 := t1.Add(time.Minute)
+	m.Restore(t2)
+	m.PrecipDBZ(origin, t2)
+	if f := provider.fetched(); slices.ContainsFunc(f[n:], t2.Equal) {
+		t.Errorf("precipitation refetched after restoring to a time it covered")
+	}
+
+	// ...but going back before it does.
+	m.Restore(start)
+	m.PrecipDBZ(origin, start)
+	if f := provider.fetched(); !slices.ContainsFunc(f[n:], start.Equal) {
+		t.Errorf("expected a fetch for %s after restoring to it, got %v", start, f[n:])
+	}
+}
+
+// blockingProvider provides scripted weather, but its atmospheric and
+// precipitation data isn't returned until release is closed, as if the
+// fetches were slow.
+type blockingProvider struct {
+	sp      *ScriptedProvider
+	release chan struct{}
+}
+
+func (p *blockingProvider) GetPrecipURL(facility string, t time.Time) (string, time.Time, error) {
+	return p.sp.GetPrecipURL(facility, t)
+}
+
+func (p *blockingProvider) GetPrecip(facility string, t time.Time) (*Precip, time.Time, error) {
+	<-p.release
+	return p.sp.GetPrecip(facility, t)
+}
+
+func (p *blockingProvider) GetAtmosGrid(facility string, t time.Time, primaryAirport string) (*AtmosByPointSOA, time.Time, time.Time, error) {
+	<-p.release
+	return p.sp.GetAtmosGrid(facility, t, primaryAirport)
+}
+
+func TestModelDoesntWait(t *testing.T) {
+	db := aviation.DB
+	t.Cleanup(func() { aviation.DB = db })
+	aviation.DB = &aviation.StaticDatabase{TRACONs: map[string]aviation.TRACON{"TST": {}}}
+
+	start := time.Date(2025, time.November, 1, 12, 0, 0, 0, time.UTC)
+	origin := math.Point2LL{-73, 41}
+	temp := float32(35)
+	sw := (&ScriptedWeather{
+		Conditions: []ScriptedConditions{{At: 0, Temperature: &temp}},
+		Precip: []ScriptedPrecip{{
+			Polygon: []math.Point2LL{{-73.1, 40.9}, {-72.9, 40.9}, {-72.9, 41.1}, {-73.1, 41.1}},
+			DBZ:     52,
+		}},
+	}).Anchor(start, origin)
+	provider := &blockingProvider{sp: MakeScriptedProvider(sw), release: make(chan struct{})}
+	m := MakeModel(provider, "TST", "", start, true, log.New(false, "error", ""))
+
+	// The standard atmosphere is used until the first grid arrives.
+	lookup := make(chan Sample)
+	go func() { lookup <- m.Lookup(origin, 5000, start) }()
+	select {
+	case s := <-lookup:
+		if s != MakeStandardSampleForAltitude(5000) {
+			t.Errorf("expected the standard atmosphere before the grid arrived, got %s", s)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Lookup waited for the fetch")
+	}
+
+	// Likewise, there's no precipitation until it arrives.
+	dbz := make(chan byte)
+	go func() { dbz <- m.PrecipDBZ(origin, start) }()
+	select {
+	case d := <-dbz:
+		if d != 0 {
+			t.Errorf("expected no precipitation before it arrived, got %d dBZ", d)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("PrecipDBZ waited for the fetch")
+	}
+
+	// And the grid and precipitation are used once they have arrived.
+	close(provider.release)
+	gotGrid, gotPrecip := false, false
+	for range 1000 {
+		if s := m.Lookup(origin, 5000, start); !gotGrid && s != MakeStandardSampleForAltitude(5000) {
+			if s.Temperature() < 25 {
+				t.Errorf("expected scripted temperature, got %s", s)
+			}
+			gotGrid = true
+		}
+		if d := m.PrecipDBZ(origin, start); !gotPrecip && d != 0 {
+			if d < 40 {
+				t.Errorf("expected scripted precipitation, got %d dBZ", d)
+			}
+			gotPrecip = true
+		}
+		if gotGrid && gotPrecip {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !gotGrid {
+		t.Errorf("grid wasn't used after it arrived")
+	}
+	if !gotPrecip {
+		t.Errorf("precipitation wasn't used after it arrived")
+	}
+}
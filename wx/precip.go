@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/mmp/vice/math"
 	"github.com/mmp/vice/util"
@@ -44,6 +45,26 @@ func DecodePrecip(r io.Reader) (*Precip, error) {
 	return &precip, nil
 }
 
+// Fetches of precipitation are abandoned if they take longer than this so
+// that the sim doesn't wait indefinitely for them.
+var precipClient = &http.Client{Timeout: 30 * time.Second}
+
+// FetchPrecip downloads and decodes the precipitation at the given URL,
+// as returned by Provider.GetPrecipURL.
+func FetchPrecip(url string) (*Precip, error) {
+	resp, err := precipClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
+	}
+
+	return DecodePrecip(resp.Body)
+}
+
 // lat-long bounds
 func (p Precip) BoundsLL() math.Extent2D {
 	centerLL := math.Point2LL{p.Longitude, p.Latitude}
@@ -53,6 +74,43 @@ func (p Precip) BoundsLL() math.Extent2D {
 	return math.BoundLatLongCircle(centerLL, widthNM/2 /* radius */)
 }
 
+// Lookup returns the reflectivity in dBZ at the given point; 0 is returned
+// for points outside of the precipitation's extent.
+func (p *Precip) Lookup(pos math.Point2LL) byte {
+	bounds := p.BoundsLL()
+	if !bounds.Inside(pos) {
+		return 0
+	}
+
+	// Row 0 is the northern edge; see radar.makeWeatherCommandBuffers.
+	n := p.Resolution
+	x := int((pos[0] - bounds.P0[0]) / bounds.Width() * float32(n))
+	y := n - 1 - int((pos[1]-bounds.P0[1])/bounds.Height()*float32(n))
+	x, y = math.Clamp(x, 0, n-1), math.Clamp(y, 0, n-1)
+	if idx := x + y*n; idx < len(p.DBZ) {
+		return p.DBZ[idx]
+	}
+	return 0
+}
+
+// DBZToLevel maps a reflectivity value to a STARS weather level, 0-6.
+func DBZToLevel(dbz byte) int {
+	if dbz > 55 {
+		return 6
+	} else if dbz > 50 {
+		return 5
+	} else if dbz > 45 {
+		return 4
+	} else if dbz > 40 {
+		return 3
+	} else if dbz > 30 {
+		return 2
+	} else if dbz > 20 {
+		return 1
+	}
+	return 0
+}
+
 func FetchRadarImage(center math.Point2LL, radius float32, resolution int) (image.Image, math.Extent2D, error) {
 	// The weather radar image comes via a WMS GetMap request from the NOAA.
 	//
@@ -23,6 +23,21 @@ type Provider interface {
 	GetAtmosGrid(facility string, t time.Time, primaryAirport string) (*AtmosByPointSOA, time.Time, time.Time, error)
 }
 
+// PrecipProvider is implemented by providers that generate precipitation
+// directly rather than providing a URL for it.
+type PrecipProvider interface {
+	// GetPrecip returns the precipitation at-or-before the given time and
+	// the time of the next one.
+	GetPrecip(facility string, t time.Time) (*Precip, time.Time, error)
+}
+
+// LocalProvider is implemented by providers that may have their data
+// available locally rather than needing to fetch it; if IsLocal returns
+// true, the Model gets data from them synchronously.
+type LocalProvider interface {
+	IsLocal() bool
+}
+
 const (
 	metarIntervalTolerance  = 75 * time.Minute
 	precipIntervalTolerance = 40 * time.Minute
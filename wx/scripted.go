@@ -0,0 +1,493 @@
+// wx/scripted.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package wx
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mmp/vice/math"
+	"github.com/mmp/vice/util"
+)
+
+// ScriptedWeather specifies synthetic weather for a scenario, for use in
+// place of archived real-world weather. Surface conditions are given as a
+// series of keyframes at the primary airport; they change linearly from
+// one keyframe to the next (e.g., a ceiling trending below minimums)
+// unless the later one is marked as abrupt (e.g., a frontal passage). If
+// a motion is given, the weather moves across the area so that
+// conditions elsewhere lead or lag those at the primary airport.
+type ScriptedWeather struct {
+	Conditions []ScriptedConditions `json:"conditions"`
+	Motion     *ScriptedMotion      `json:"motion,omitempty"`
+	Precip     []ScriptedPrecip     `json:"precip,omitempty"`
+
+	// Start and Origin are set when a sim is created; see Anchor.
+	Start  time.Time     `json:"-"`
+	Origin math.Point2LL `json:"-"`
+}
+
+// ScriptedMotion describes how weather moves across the area.
+type ScriptedMotion struct {
+	Heading float32 `json:"heading"` // true heading it is moving toward
+	Speed   float32 `json:"speed"`   // knots
+}
+
+// ScriptedConditions gives the surface conditions at a time given in
+// minutes after the start of the sim. Values that are omitted carry
+// forward from the previous keyframe.
+type ScriptedConditions struct {
+	At     int  `json:"at"`
+	Abrupt bool `json:"abrupt,omitempty"`
+
+	WindDirection *float32 `json:"wind_direction,omitempty"` // true
+	WindSpeed     *float32 `json:"wind_speed,omitempty"`     // knots
+	WindGust      *float32 `json:"wind_gust,omitempty"`      // knots; 0 for no gusts
+	Visibility    *float32 `json:"visibility,omitempty"`     // statute miles
+	Ceiling       *float32 `json:"ceiling,omitempty"`        // feet AGL; 0 for no ceiling
+	Temperature   *float32 `json:"temperature,omitempty"`    // Celsius
+	Dewpoint      *float32 `json:"dewpoint,omitempty"`       // Celsius
+	Altimeter     *float32 `json:"altimeter,omitempty"`      // inHg
+}
+
+// ScriptedPrecip is an area of precipitation of uniform intensity that
+// exists for a span of time and may be moving.
+type ScriptedPrecip struct {
+	Polygon []math.Point2LL `json:"polygon"`
+	DBZ     int             `json:"dbz"`
+	Start   int             `json:"start,omitempty"` // minutes after the start of the sim
+	End     int             `json:"end,omitempty"`   // minutes after the start; 0 if it persists
+	Motion  *ScriptedMotion `json:"motion,omitempty"`
+}
+
+const (
+	// Ceilings are represented with this value when there is none so
+	// that they can be interpolated; it matches METAR.Ceiling.
+	scriptedNoCeiling = 12000
+	// Synthetic precipitation covers a square this many nm on a side,
+	// centered at the origin.
+	scriptedPrecipExtent = 200
+	// Spacing of the atmospheric samples, in nm, and the number of them
+	// along each side of the origin.
+	scriptedAtmosSpacing = 10
+	scriptedAtmosCount   = 6
+	// Synthetic atmos and precip are regenerated at this interval.
+	scriptedUpdateInterval = 5 * time.Minute
+)
+
+// scriptedSurface holds fully-specified surface conditions.
+type scriptedSurface struct {
+	windDir, windSpeed, windGust float32
+	visibility, ceiling          float32
+	temperature, dewpoint        float32
+	altimeter                    float32 // inHg
+}
+
+type scriptedKeyframe struct {
+	at     float32
+	abrupt bool
+	scriptedSurface
+}
+
+func (sw *ScriptedWeather) Validate() error {
+	if len(sw.Conditions) == 0 {
+		return errors.New(`at least one entry must be given in "conditions"`)
+	}
+	for i, c := range sw.Conditions {
+		if i > 0 && c.At <= sw.Conditions[i-1].At {
+			return fmt.Errorf(`"conditions" entry %d: "at" times must be increasing`, i)
+		}
+		if c.WindDirection != nil && (*c.WindDirection < 0 || *c.WindDirection > 360) {
+			return fmt.Errorf(`"conditions" entry %d: wind direction %.0f out of range [0, 360]`, i, *c.WindDirection)
+		}
+		if (c.WindSpeed != nil && *c.WindSpeed < 0) || (c.WindGust != nil && *c.WindGust < 0) {
+			return fmt.Errorf(`"conditions" entry %d: wind speeds must be non-negative`, i)
+		}
+		if c.Visibility != nil && *c.Visibility <= 0 {
+			return fmt.Errorf(`"conditions" entry %d: visibility must be positive`, i)
+		}
+		if c.Ceiling != nil && *c.Ceiling < 0 {
+			return fmt.Errorf(`"conditions" entry %d: ceiling must be non-negative`, i)
+		}
+	}
+	if err := sw.Motion.validate(); err != nil {
+		return err
+	}
+
+	for i, p := range sw.Precip {
+		if len(p.Polygon) < 3 {
+			return fmt.Errorf(`"precip" entry %d: at least 3 points must be given in "polygon"`, i)
+		}
+		if p.DBZ <= 0 || p.DBZ > 80 {
+			return fmt.Errorf(`"precip" entry %d: dbz %d out of range (0, 80]`, i, p.DBZ)
+		}
+		if p.End != 0 && p.End <= p.Start {
+			return fmt.Errorf(`"precip" entry %d: "end" must be after "start"`, i)
+		}
+		if err := p.Motion.validate(); err != nil {
+			return fmt.Errorf(`"precip" entry %d: %w`, i, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *ScriptedMotion) validate() error {
+	if m == nil {
+		return nil
+	}
+	if m.Heading < 0 || m.Heading > 360 {
+		return fmt.Errorf("motion heading %.0f out of range [0, 360]", m.Heading)
+	}
+	if m.Speed < 0 {
+		return errors.New("motion speed must be non-negative")
+	}
+	return nil
+}
+
+// lag returns the number of minutes it takes for weather moving with the
+// motion to get from the point from to the point to; it is negative if
+// the weather reaches to first.
+func (m *ScriptedMotion) lag(from, to math.Point2LL) float32 {
+	if m == nil || m.Speed == 0 {
+		return 0
+	}
+	nmPerLongitude := math.NMPerLongitudeAt(from)
+	d := math.Sub2f(math.LL2NM(to, nmPerLongitude), math.LL2NM(from, nmPerLongitude))
+	return math.Dot(d, math.SinCos(math.Radians(m.Heading))) / m.Speed * 60
+}
+
+// offset returns the distance in nm that weather with the motion moves
+// in the given number of minutes.
+func (m *ScriptedMotion) offset(minutes float32) [2]float32 {
+	if m == nil {
+		return [2]float32{}
+	}
+	return math.Scale2f(math.SinCos(math.Radians(m.Heading)), m.Speed*minutes/60)
+}
+
+// Anchor returns a copy of the scripted weather with its keyframe times
+// relative to the given start time and at the given location, which
+// should be the primary airport's.
+func (sw *ScriptedWeather) Anchor(start time.Time, origin math.Point2LL) *ScriptedWeather {
+	a := *sw
+	a.Start, a.Origin = start.UTC(), origin
+	return &a
+}
+
+func (sw *ScriptedWeather) minutes(t time.Time) float32 {
+	return float32(t.Sub(sw.Start).Minutes())
+}
+
+// keyframes returns the keyframes with omitted values filled in.
+func (sw *ScriptedWeather) keyframes() []scriptedKeyframe {
+	cur := scriptedSurface{visibility: 10, ceiling: scriptedNoCeiling, temperature: 15, dewpoint: 5, altimeter: 29.92}
+	set := func(v *float32, p *float32) {
+		if p != nil {
+			*v = *p
+		}
+	}
+
+	var kf []scriptedKeyframe
+	for _, c := range sw.Conditions {
+		set(&cur.windDir, c.WindDirection)
+		set(&cur.windSpeed, c.WindSpeed)
+		set(&cur.windGust, c.WindGust)
+		set(&cur.visibility, c.Visibility)
+		set(&cur.temperature, c.Temperature)
+		set(&cur.dewpoint, c.Dewpoint)
+		set(&cur.altimeter, c.Altimeter)
+		if c.Ceiling != nil {
+			cur.ceiling = util.Select(*c.Ceiling == 0, float32(scriptedNoCeiling), min(*c.Ceiling, scriptedNoCeiling))
+		}
+		kf = append(kf, scriptedKeyframe{at: float32(c.At), abrupt: c.Abrupt, scriptedSurface: cur})
+	}
+	return kf
+}
+
+// surfaceAt returns the surface conditions at the given point and time.
+func (sw *ScriptedWeather) surfaceAt(p math.Point2LL, t time.Time) scriptedSurface {
+	m := sw.minutes(t) - sw.Motion.lag(sw.Origin, p)
+
+	kf := sw.keyframes()
+	i := slices.IndexFunc(kf, func(k scriptedKeyframe) bool { return k.at > m })
+	if i == 0 {
+		return kf[0].scriptedSurface
+	} else if i == -1 {
+		return kf[len(kf)-1].scriptedSurface
+	}
+
+	a, b := kf[i-1], kf[i]
+	if b.abrupt {
+		return a.scriptedSurface
+	}
+	x := (m - a.at) / (b.at - a.at)
+	lerp := func(a, b float32) float32 { return math.Lerp(x, a, b) }
+	return scriptedSurface{
+		windDir:     math.NormalizeHeading(a.windDir + x*math.HeadingSignedTurn(a.windDir, b.windDir)),
+		windSpeed:   lerp(a.windSpeed, b.windSpeed),
+		windGust:    lerp(a.windGust, b.windGust),
+		visibility:  lerp(a.visibility, b.visibility),
+		ceiling:     lerp(a.ceiling, b.ceiling),
+		temperature: lerp(a.temperature, b.temperature),
+		dewpoint:    lerp(a.dewpoint, b.dewpoint),
+		altimeter:   lerp(a.altimeter, b.altimeter),
+	}
+}
+
+// precipDBZ returns the reflectivity of the scripted precipitation at the
+// given point and time.
+func (sw *ScriptedWeather) precipDBZ(p math.Point2LL, t time.Time) byte {
+	m := sw.minutes(t)
+	nmPerLongitude := math.NMPerLongitudeAt(sw.Origin)
+	pnm := math.LL2NM(p, nmPerLongitude)
+
+	var dbz byte
+	for _, pr := range sw.Precip {
+		if m < float32(pr.Start) || (pr.End != 0 && m > float32(pr.End)) {
+			continue
+		}
+		// Move the point back to where the precipitation started.
+		q := math.NM2LL(math.Sub2f(pnm, pr.Motion.offset(m-float32(pr.Start))), nmPerLongitude)
+		if math.PointInPolygon2LL(q, pr.Polygon) {
+			dbz = max(dbz, byte(pr.DBZ))
+		}
+	}
+	return dbz
+}
+
+// UpdateTimes returns the time of the most recent update of the
+// synthetic atmos and precipitation at or before t and the time of the
+// one after it. Both the server and clients that draw the precipitation
+// should generate it for those times so that they agree.
+func (sw *ScriptedWeather) UpdateTimes(t time.Time) (time.Time, time.Time) {
+	t = t.Truncate(scriptedUpdateInterval)
+	return t, t.Add(scriptedUpdateInterval)
+}
+
+// PrecipAt returns the scripted precipitation at time t over the area
+// around the origin.
+func (sw *ScriptedWeather) PrecipAt(t time.Time) *Precip {
+	precip := &Precip{
+		Resolution: 2 * scriptedPrecipExtent, // 0.5nm per pixel
+		Latitude:   sw.Origin[1],
+		Longitude:  sw.Origin[0],
+	}
+	n := precip.Resolution
+	precip.DBZ = make([]byte, n*n)
+	if len(sw.Precip) == 0 {
+		return precip
+	}
+
+	// Row 0 is the northern edge; see Precip.Lookup.
+	bounds := precip.BoundsLL()
+	for y := range n {
+		lat := bounds.P1[1] - (float32(y)+0.5)/float32(n)*bounds.Height()
+		for x := range n {
+			lon := bounds.P0[0] + (float32(x)+0.5)/float32(n)*bounds.Width()
+			precip.DBZ[x+y*n] = sw.precipDBZ(math.Point2LL{lon, lat}, t)
+		}
+	}
+	return precip
+}
+
+// AtmosAt returns atmospheric samples over the area around the origin at
+// time t. As with MakeFallbackAtmosFromMETAR, the surface wind is used at
+// all altitudes.
+func (sw *ScriptedWeather) AtmosAt(t time.Time) (*AtmosByPointSOA, error) {
+	nmPerLongitude := math.NMPerLongitudeAt(sw.Origin)
+	o := math.LL2NM(sw.Origin, nmPerLongitude)
+
+	ap := MakeAtmosByPoint()
+	for y := -scriptedAtmosCount; y <= scriptedAtmosCount; y++ {
+		for x := -scriptedAtmosCount; x <= scriptedAtmosCount; x++ {
+			d := [2]float32{float32(x * scriptedAtmosSpacing), float32(y * scriptedAtmosSpacing)}
+			p := math.NM2LL(math.Add2f(o, d), nmPerLongitude)
+			s := sw.surfaceAt(p, t)
+			ap.SampleStacks[p] = makeUniformWindSampleStack(s.windDir, s.windSpeed, s.temperature, s.dewpoint)
+		}
+	}
+
+	soa, err := ap.ToSOA()
+	if err != nil {
+		return nil, err
+	}
+	return &soa, nil
+}
+
+// METAR returns synthetic METARs for the airport at the given location
+// covering the 24 hours after the start of the sim. Routine reports are
+// issued hourly and specials are issued when the ceiling, visibility, or
+// wind change significantly.
+func (sw *ScriptedWeather) METAR(icao string, loc math.Point2LL) []METAR {
+	// Start with the last routine report before the start time.
+	t := sw.Start.Truncate(time.Hour).Add(-7 * time.Minute)
+	end := sw.Start.Add(24 * time.Hour)
+
+	reported := sw.surfaceAt(loc, t).reported()
+	metar := []METAR{sw.makeMETAR(icao, loc, t, false)}
+
+	for t = t.Add(time.Minute); t.Before(end); t = t.Add(time.Minute) {
+		s := sw.surfaceAt(loc, t).reported()
+		if routine := t.Minute() == 53; routine || specialCriteriaMet(reported, s) {
+			metar = append(metar, sw.makeMETAR(icao, loc, t, !routine))
+			reported = s
+		}
+	}
+	return metar
+}
+
+// reported returns the conditions rounded to the values that are
+// reported in METARs.
+func (s scriptedSurface) reported() scriptedSurface {
+	s.visibility = reportableVisibility(s.visibility).sm
+	if s.ceiling < scriptedNoCeiling {
+		s.ceiling = 100 * max(1, math.Round(s.ceiling/100))
+	}
+	s.dewpoint = min(s.dewpoint, s.temperature)
+	return s
+}
+
+// specialCriteriaMet returns true if a SPECI should be issued given the
+// conditions in the last report and the current conditions. This is a
+// subset of the criteria in FMH-1 3.2.3.
+func specialCriteriaMet(prev, cur scriptedSurface) bool {
+	crossed := func(a, b float32, thresholds ...float32) bool {
+		for _, th := range thresholds {
+			if (a < th) != (b < th) {
+				return true
+			}
+		}
+		return false
+	}
+	if crossed(prev.ceiling, cur.ceiling, 3000, 1500, 1000, 500, 200) ||
+		crossed(prev.visibility, cur.visibility, 3, 2, 1, 0.5) {
+		return true
+	}
+	// Wind shift
+	return max(prev.windSpeed, cur.windSpeed) >= 10 && math.HeadingDifference(prev.windDir, cur.windDir) >= 45
+}
+
+func (sw *ScriptedWeather) makeMETAR(icao string, loc math.Point2LL, t time.Time, special bool) METAR {
+	s := sw.surfaceAt(loc, t).reported()
+
+	m := METAR{
+		ICAO:        icao,
+		Time:        t,
+		Temperature: math.Round(s.temperature),
+		Dewpoint:    math.Round(s.dewpoint),
+		Altimeter:   s.altimeter / 0.02953, // hPa
+		WindSpeed:   int(math.Round(s.windSpeed)),
+	}
+
+	var raw []string
+	if special {
+		raw = append(raw, "SPECI")
+	}
+	raw = append(raw, icao, t.Format("021504Z"))
+
+	if m.WindSpeed == 0 {
+		raw = append(raw, "00000KT")
+	} else {
+		dir := int(math.Round(s.windDir/10)) * 10
+		if dir == 0 {
+			dir = 360
+		}
+		m.WindDir = &dir
+		wind := fmt.Sprintf("%03d%02d", dir, m.WindSpeed)
+		if gust := int(math.Round(s.windGust)); gust > m.WindSpeed {
+			m.WindGust = &gust
+			wind += fmt.Sprintf("G%02d", gust)
+		}
+		raw = append(raw, wind+"KT")
+	}
+
+	raw = append(raw, reportableVisibility(s.visibility).str+"SM")
+
+	if dbz := sw.precipDBZ(loc, t); dbz >= 50 {
+		raw = append(raw, "+TSRA")
+	} else if dbz >= 40 {
+		raw = append(raw, "TSRA")
+	} else if dbz >= 30 {
+		raw = append(raw, "RA")
+	} else if dbz >= 20 {
+		raw = append(raw, "-RA")
+	} else if s.visibility < 0.625 {
+		raw = append(raw, "FG")
+	} else if s.visibility < 7 {
+		raw = append(raw, "BR")
+	}
+
+	if s.ceiling >= scriptedNoCeiling {
+		raw = append(raw, "CLR")
+	} else {
+		raw = append(raw, fmt.Sprintf("%s%03d", util.Select(s.visibility < 3, "OVC", "BKN"), int(s.ceiling/100)))
+	}
+
+	formatTemp := func(t float32) string {
+		if t < 0 {
+			return fmt.Sprintf("M%02d", int(-t))
+		}
+		return fmt.Sprintf("%02d", int(t))
+	}
+	raw = append(raw, formatTemp(m.Temperature)+"/"+formatTemp(m.Dewpoint),
+		fmt.Sprintf("A%04d", int(math.Round(s.altimeter*100))))
+
+	m.Raw = strings.Join(raw, " ")
+	return m
+}
+
+type reportedVisibility struct {
+	sm  float32
+	str string
+}
+
+// reportableVisibility returns the largest reportable visibility that is
+// less than or equal to the given visibility, along with its METAR
+// encoding.
+func reportableVisibility(vis float32) reportedVisibility {
+	for _, v := range []reportedVisibility{{10, "10"}, {7, "7"}, {5, "5"}, {4, "4"}, {3, "3"}, {2, "2"},
+		{1, "1"}, {0.75, "3/4"}, {0.5, "1/2"}, {0.25, "1/4"}} {
+		if vis >= v.sm {
+			return v
+		}
+	}
+	return reportedVisibility{0.25, "M1/4"}
+}
+
+///////////////////////////////////////////////////////////////////////////
+// ScriptedProvider
+
+// ScriptedProvider implements the Provider interface, generating weather
+// from a ScriptedWeather specification.
+type ScriptedProvider struct {
+	sw *ScriptedWeather
+}
+
+func MakeScriptedProvider(sw *ScriptedWeather) *ScriptedProvider {
+	return &ScriptedProvider{sw: sw}
+}
+
+func (p *ScriptedProvider) IsLocal() bool {
+	return true
+}
+
+func (p *ScriptedProvider) GetPrecipURL(facility string, t time.Time) (string, time.Time, error) {
+	return "", time.Time{}, errors.New("no precipitation URL for scripted weather")
+}
+
+func (p *ScriptedProvider) GetPrecip(facility string, t time.Time) (*Precip, time.Time, error) {
+	t, next := p.sw.UpdateTimes(t)
+	return p.sw.PrecipAt(t), next, nil
+}
+
+func (p *ScriptedProvider) GetAtmosGrid(facility string, t time.Time, primaryAirport string) (*AtmosByPointSOA, time.Time, time.Time, error) {
+	t, next := p.sw.UpdateTimes(t)
+	soa, err := p.sw.AtmosAt(t)
+	return soa, t, next, err
+}
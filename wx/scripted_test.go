@@ -0,0 +1,109 @@
+// wx/scripted_test.go
+// Copyright(c) 2022-2025 vice contributors, licensed under the GNU Public License, Version 3.
+// SPDX: GPL-3.0-only
+
+package wx
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mmp/vice/math"
+)
+
+func TestScriptedWeather(t *testing.T) {
+	spec := `{
+  "conditions": [
+    { "at": 0, "wind_direction": 200, "wind_speed": 12, "temperature": 24, "dewpoint": 18, "altimeter": 29.82 },
+    { "at": 45, "abrupt": true, "wind_direction": 310, "wind_speed": 18, "wind_gust": 28,
+      "temperature": 16, "altimeter": 29.98, "ceiling": 2500, "visibility": 5 },
+    { "at": 105, "ceiling": 400, "visibility": 0.75 }
+  ],
+  "motion": { "heading": 90, "speed": 30 },
+  "precip": [ { "polygon": [[-74, 40.6], [-73.9, 40.6], [-73.9, 40.7], [-74, 40.7]], "dbz": 45, "end": 60 } ]
+}`
+	var sw ScriptedWeather
+	if err := json.Unmarshal([]byte(spec), &sw); err != nil {
+		t.Fatal(err)
+	}
+	if err := sw.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2025, 6, 1, 18, 10, 0, 0, time.UTC)
+	origin := math.Point2LL{-73.78, 40.64}
+	a := sw.Anchor(start, origin)
+
+	metarAt := func(metar []METAR, t time.Time) METAR {
+		var m METAR
+		for _, mm := range metar {
+			if !mm.Time.After(t) {
+				m = mm
+			}
+		}
+		return m
+	}
+
+	metar := a.METAR("KJFK", origin)
+	if metar[0].Time.After(start) {
+		t.Errorf("first METAR %s is after the start time", metar[0].Raw)
+	}
+
+	// Before the front: southerly wind, VMC.
+	m := metarAt(metar, start.Add(30*time.Minute))
+	if m.WindDir == nil || *m.WindDir != 200 || !m.IsVMC() {
+		t.Errorf("unexpected pre-frontal METAR %s", m.Raw)
+	}
+
+	// The frontal passage should be reported immediately with a SPECI.
+	m = metarAt(metar, start.Add(46*time.Minute))
+	if !strings.HasPrefix(m.Raw, "SPECI") || m.WindDir == nil || *m.WindDir != 310 || m.WindGust == nil {
+		t.Errorf("expected SPECI for frontal passage, got %s", m.Raw)
+	}
+
+	// Midway through the trend, the ceiling is between the two.
+	m = metarAt(metar, start.Add(75*time.Minute))
+	if c, err := m.Ceiling(); err != nil || c >= 2500 || c <= 400 {
+		t.Errorf("expected trending ceiling, got %s", m.Raw)
+	}
+
+	// And at the end, it's IMC.
+	m = metarAt(metar, start.Add(3*time.Hour))
+	if c, _ := m.Ceiling(); c != 400 || m.IsVMC() {
+		t.Errorf("expected 400' ceiling, got %s", m.Raw)
+	}
+
+	// The weather is moving east, so the front reaches an airport 30nm
+	// to the west an hour before it gets to the origin.
+	west := math.NM2LL(math.Sub2f(math.LL2NM(origin, math.NMPerLongitudeAt(origin)), [2]float32{30, 0}),
+		math.NMPerLongitudeAt(origin))
+	if s := a.surfaceAt(west, start.Add(-10*time.Minute)); s.windDir != 310 {
+		t.Errorf("expected post-frontal wind to the west, got %f", s.windDir)
+	}
+
+	if dbz := a.PrecipAt(start).Lookup(math.Point2LL{-73.95, 40.65}); dbz != 45 {
+		t.Errorf("expected 45 dBZ, got %d", dbz)
+	}
+	if dbz := a.PrecipAt(start.Add(61 * time.Minute)).Lookup(math.Point2LL{-73.95, 40.65}); dbz != 0 {
+		t.Errorf("expected precip to have ended, got %d dBZ", dbz)
+	}
+}
+
+func TestScriptedUpdateTimes(t *testing.T) {
+	start := time.Date(2025, time.November, 1, 12, 0, 0, 0, time.UTC)
+	sw := (&ScriptedWeather{Conditions: []ScriptedConditions{{At: 0}}}).Anchor(start, math.Point2LL{-73, 41})
+
+	cur, next := sw.UpdateTimes(start.Add(7*time.Minute + 30*time.Second))
+	if !cur.Equal(start.Add(5*time.Minute)) || !next.Equal(start.Add(10*time.Minute)) {
+		t.Errorf("unexpected update times %s, %s", cur, next)
+	}
+
+	// The provider uses the same times, so clients that generate the
+	// precipitation with UpdateTimes see what the sim does.
+	_, pnext, err := MakeScriptedProvider(sw).GetPrecip("", start.Add(7*time.Minute))
+	if err != nil || !pnext.Equal(next) {
+		t.Errorf("provider's next precipitation time %s doesn't match %s (%v)", pnext, next, err)
+	}
+}